@@ -27,9 +27,12 @@ import (
 	"github.com/jfrog/jfrog-cli/artifactory/commands/mvn"
 	"github.com/jfrog/jfrog-cli/artifactory/commands/npm"
 	"github.com/jfrog/jfrog-cli/artifactory/commands/pip"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/pipeline"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/pnpm"
 	"github.com/jfrog/jfrog-cli/artifactory/commands/replication"
 	"github.com/jfrog/jfrog-cli/artifactory/commands/repository"
 	commandUtils "github.com/jfrog/jfrog-cli/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/yarn"
 	"github.com/jfrog/jfrog-cli/artifactory/spec"
 	"github.com/jfrog/jfrog-cli/artifactory/utils"
 	npmUtils "github.com/jfrog/jfrog-cli/artifactory/utils/npm"
@@ -37,8 +40,10 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildaddgit"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildclean"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildcollectenv"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/builddiff"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/builddiscard"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/builddistribute"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/buildimport"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildpromote"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildpublish"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildscan"
@@ -70,6 +75,9 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/artifactory/ping"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/pipconfig"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/pipinstall"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/pnpmconfig"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/pnpminstall"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/pnpmpublish"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/releasebundlecreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/releasebundledelete"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/releasebundledistribute"
@@ -82,15 +90,23 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/artifactory/repodelete"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/repotemplate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/repoupdate"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/runpipeline"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/search"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/setprops"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/specvalidate"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/sync"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/tokenlist"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/tokenrevoke"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/upload"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/use"
+	yarndocs "github.com/jfrog/jfrog-cli/docs/artifactory/yarn"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/yarnconfig"
 	"github.com/jfrog/jfrog-cli/docs/common"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
 	"github.com/jfrog/jfrog-cli/utils/config"
 	"github.com/jfrog/jfrog-cli/utils/ioutils"
 	logUtils "github.com/jfrog/jfrog-cli/utils/log"
+	"github.com/jfrog/jfrog-cli/utils/summary"
 	buildinfocmd "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	distributionServices "github.com/jfrog/jfrog-client-go/distribution/services"
@@ -204,6 +220,31 @@ func GetCommands() []cli.Command {
 				return searchCmd(c)
 			},
 		},
+		{
+			Name:         "sync",
+			Flags:        getSyncFlags(),
+			Usage:        sync.Description,
+			HelpName:     common.CreateUsage("rt sync", sync.Description, sync.Usage),
+			UsageText:    sync.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: common.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return syncCmd(c)
+			},
+		},
+		{
+			Name:         "spec-validate",
+			Flags:        getSpecValidateFlags(),
+			Aliases:      []string{"sv"},
+			Usage:        specvalidate.Description,
+			HelpName:     common.CreateUsage("rt spec-validate", specvalidate.Description, specvalidate.Usage),
+			UsageText:    specvalidate.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: common.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return specValidateCmd(c)
+			},
+		},
 		{
 			Name:         "set-props",
 			Flags:        getSetOrDeletePropsFlags(),
@@ -243,6 +284,19 @@ func GetCommands() []cli.Command {
 				return buildPublishCmd(c)
 			},
 		},
+		{
+			Name:         "build-import",
+			Flags:        getBuildImportFlags(),
+			Aliases:      []string{"bi"},
+			Usage:        buildimport.Description,
+			HelpName:     common.CreateUsage("rt build-import", buildimport.Description, buildimport.Usage),
+			UsageText:    buildimport.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: common.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return buildImportCmd(c)
+			},
+		},
 		{
 			Name:         "build-collect-env",
 			Aliases:      []string{"bce"},
@@ -345,6 +399,30 @@ func GetCommands() []cli.Command {
 				return buildDiscardCmd(c)
 			},
 		},
+		{
+			Name:         "build-diff",
+			Flags:        getBuildDiffFlags(),
+			Aliases:      []string{"bdiff"},
+			Usage:        builddiff.Description,
+			HelpName:     common.CreateUsage("rt build-diff", builddiff.Description, builddiff.Usage),
+			UsageText:    builddiff.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: common.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return buildDiffCmd(c)
+			},
+		},
+		{
+			Name:         "run-pipeline",
+			Usage:        runpipeline.Description,
+			HelpName:     common.CreateUsage("rt run-pipeline", runpipeline.Description, runpipeline.Usage),
+			UsageText:    runpipeline.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: common.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return runPipelineCmd(c)
+			},
+		},
 		{
 			Name:         "git-lfs-clean",
 			Flags:        getGitLfsCleanFlags(),
@@ -446,6 +524,71 @@ func GetCommands() []cli.Command {
 				return createNpmConfigCmd(c)
 			},
 		},
+		{
+			Name:         "pnpm-config",
+			Flags:        getCommonBuildToolsConfigFlags(),
+			Aliases:      []string{"pnpmc"},
+			Usage:        pnpmconfig.Description,
+			HelpName:     common.CreateUsage("rt pnpm-config", pnpmconfig.Description, pnpmconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: common.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return createPnpmConfigCmd(c)
+			},
+		},
+		{
+			Name:            "pnpm-install",
+			Flags:           getPnpmFlags(),
+			Aliases:         []string{"pnpmi"},
+			Usage:           pnpminstall.Description,
+			HelpName:        common.CreateUsage("rt pnpm-install", pnpminstall.Description, pnpminstall.Usage),
+			UsageText:       pnpminstall.Arguments,
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    common.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return pnpmInstallCmd(c)
+			},
+		},
+		{
+			Name:            "pnpm-publish",
+			Flags:           getBuildAndModuleFlags(),
+			Aliases:         []string{"pnpmp"},
+			Usage:           pnpmpublish.Description,
+			HelpName:        common.CreateUsage("rt pnpm-publish", pnpmpublish.Description, pnpmpublish.Usage),
+			UsageText:       pnpmpublish.Arguments,
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    common.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return pnpmPublishCmd(c)
+			},
+		},
+		{
+			Name:         "yarn-config",
+			Flags:        getResolverOnlyConfigFlags(),
+			Aliases:      []string{"yarnc"},
+			Usage:        yarnconfig.Description,
+			HelpName:     common.CreateUsage("rt yarn-config", yarnconfig.Description, yarnconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: common.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return createYarnConfigCmd(c)
+			},
+		},
+		{
+			Name:            "yarn",
+			Flags:           getYarnFlags(),
+			Usage:           yarndocs.Description,
+			HelpName:        common.CreateUsage("rt yarn", yarndocs.Description, yarndocs.Usage),
+			UsageText:       yarndocs.Arguments,
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    common.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return yarnCmd(c)
+			},
+		},
 		{
 			Name:            "npm-install",
 			Flags:           getNpmFlags(),
@@ -822,6 +965,32 @@ func GetCommands() []cli.Command {
 				return accessTokenCreateCmd(c)
 			},
 		},
+		{
+			Name:         "token-list",
+			Aliases:      []string{"tl"},
+			Flags:        getTokenListFlags(),
+			Usage:        tokenlist.Description,
+			HelpName:     common.CreateUsage("rt tl", tokenlist.Description, tokenlist.Usage),
+			UsageText:    tokenlist.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: common.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return tokenListCmd(c)
+			},
+		},
+		{
+			Name:         "token-revoke",
+			Aliases:      []string{"tr"},
+			Flags:        getTokenRevokeFlags(),
+			Usage:        tokenrevoke.Description,
+			HelpName:     common.CreateUsage("rt tr", tokenrevoke.Description, tokenrevoke.Usage),
+			UsageText:    tokenrevoke.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: common.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return tokenRevokeCmd(c)
+			},
+		},
 	}
 }
 
@@ -985,7 +1154,7 @@ func getCommonFlags() []cli.Flag {
 }
 
 func getServerFlags() []cli.Flag {
-	return append(getCommonFlags(), getServerIdFlag())
+	return append(getCommonFlags(), getServerIdFlag(), getTokenProfileFlag())
 }
 
 func getServerWithClientCertsFlags() []cli.Flag {
@@ -1059,6 +1228,10 @@ func getUploadFlags() []cli.Flag {
 		getFailNoOpFlag(),
 		getThreadsFlag(),
 		getSyncDeletesFlag("[Optional] Specific path in Artifactory, under which to sync artifacts after the upload. After the upload, this path will include only the artifacts uploaded during this upload operation. The other files under this path will be deleted.` `"),
+		cli.BoolFlag{
+			Name:  "resume",
+			Usage: "[Default: false] Set to true to record the uploaded files in a transfer journal. If the upload is interrupted, rerunning the same command with this option skips the files which were already uploaded and were not modified since. The uploaded files are recorded in batches, so the files of the batch which was interrupted are uploaded again. Resuming the upload of a partially uploaded file is not supported, so such a file is uploaded again from its start. The files of a File Spec which includes directories are uploaded again entirely.` `",
+		},
 		getQuiteFlag("[Default: $CI] Set to true to skip the sync-deletes confirmation message.` `"),
 		getInsecureTlsFlag(),
 	}...)
@@ -1108,6 +1281,10 @@ func getDownloadFlags() []cli.Flag {
 			Name:  "validate-symlinks",
 			Usage: "[Default: false] Set to true to perform a checksum validation when downloading symbolic links.` `",
 		},
+		cli.BoolFlag{
+			Name:  "cache",
+			Usage: "[Default: false] Set to true to use the download cache shared by all downloads on this machine. Files found in the cache by their checksum are copied from it instead of being downloaded, and the downloaded files are added to it. The cache is limited to $" + cliutils.DownloadCacheMaxSize + " MB, 10240 by default, and the least recently used files are evicted first.` `",
+		},
 		getBundleFlag(),
 		getIncludeDirsFlag(),
 		getPropertiesFlag("Only artifacts with these properties will be downloaded."),
@@ -1161,9 +1338,25 @@ func getAccessTokenCreateFlags() []cli.Flag {
 			Name:  "audience",
 			Usage: "[Optional] A space-separate list of the other Artifactory instances or services that should accept this token identified by their Artifactory Service IDs, as obtained by the 'jfrog rt curl api/system/service_id' command.` `",
 		},
+		cli.StringFlag{
+			Name:  "profile",
+			Usage: "[Optional] Save the token as a token profile with this name in the configuration of the server, instead of printing it. The token of a profile is refreshable, and it is rotated before it expires. Commands can authenticate with the token using the --token-profile option.` `",
+		},
 	}...)
 }
 
+func getTokenListFlags() []cli.Flag {
+	return append(getServerWithClientCertsFlags(), getInsecureTlsFlag())
+}
+
+func getTokenRevokeFlags() []cli.Flag {
+	return append(getServerWithClientCertsFlags(), getInsecureTlsFlag(),
+		cli.StringFlag{
+			Name:  "profile",
+			Usage: "[Optional] Revoke the token of the token profile with this name, and remove the profile from the configuration of the server.` `",
+		})
+}
+
 func getBuildFlags() []cli.Flag {
 	return []cli.Flag{
 		cli.StringFlag{
@@ -1194,7 +1387,14 @@ func getSkipLoginFlag() cli.Flag {
 func getServerIdFlag() cli.Flag {
 	return cli.StringFlag{
 		Name:  "server-id",
-		Usage: "[Optional] Artifactory server ID configured using the config command.` `",
+		Usage: "[Optional] Artifactory server ID configured using the config command. If not set, the server ID pinned by the .jfrog/config.yaml file of the project is used, or the default server.` `",
+	}
+}
+
+func getTokenProfileFlag() cli.Flag {
+	return cli.StringFlag{
+		Name:  "token-profile",
+		Usage: "[Optional] Token profile of the configured Artifactory server to authenticate with. The profile's access token is used instead of the server credentials, and it is rotated before it expires. Token profiles are created by the access-token-create command with the --profile option. If not set, the $" + cliutils.TokenProfile + " environment variable is used.` `",
 	}
 }
 
@@ -1208,7 +1408,7 @@ func getFailNoOpFlag() cli.Flag {
 func getDetailedSummaryFlag() cli.Flag {
 	return cli.BoolFlag{
 		Name:  "detailed-summary",
-		Usage: "[Default: false] Set to true to include a list of the affected files in the command summary.` `",
+		Usage: "[Default: false] Set to true to include a list of the affected files in the command summary. The failures of the delete, move, copy and properties commands are listed in the summary only with this option.` `",
 	}
 }
 
@@ -1240,16 +1440,49 @@ func getUploadExclusionsFlags() []cli.Flag {
 	}
 }
 
+func getSyncFlags() []cli.Flag {
+	return append(getServerWithClientCertsFlags(), []cli.Flag{
+		cli.StringFlag{
+			Name:  "direction",
+			Usage: "[Default: both] The direction of the sync. Possible values are: both, upload and download.` `",
+		},
+		cli.BoolFlag{
+			Name:  "delete",
+			Usage: "[Default: false] Set to true to delete the files which exist only in the destination of the sync. Can be used only with --direction=upload or --direction=download.` `",
+		},
+		cli.BoolFlag{
+			Name:  "plan",
+			Usage: "[Default: false] Set to true to print the changes needed to sync as JSON, without applying them.` `",
+		},
+		cli.StringFlag{
+			Name:  "retries",
+			Usage: "[Default: " + strconv.Itoa(cliutils.Retries) + "] Number of upload and download retries.` `",
+		},
+		getQuiteFlag("[Default: $CI] Set to true to skip the delete confirmation message.` `"),
+		getFailNoOpFlag(),
+		getThreadsFlag(),
+		getInsecureTlsFlag(),
+	}...)
+}
+
+func getSpecValidateFlags() []cli.Flag {
+	return []cli.Flag{getSpecVarsFlag()}
+}
+
 func getSpecFlags() []cli.Flag {
 	return []cli.Flag{
 		cli.StringFlag{
 			Name:  "spec",
-			Usage: "[Optional] Path to a File Spec.` `",
-		},
-		cli.StringFlag{
-			Name:  "spec-vars",
-			Usage: "[Optional] List of variables in the form of \"key1=value1;key2=value2;...\" to be replaced in the File Spec. In the File Spec, the variables should be used as follows: ${key1}.` `",
+			Usage: "[Optional] Path to a File Spec, in JSON or YAML format.` `",
 		},
+		getSpecVarsFlag(),
+	}
+}
+
+func getSpecVarsFlag() cli.Flag {
+	return cli.StringFlag{
+		Name:  "spec-vars",
+		Usage: "[Optional] List of variables in the form of \"key1=value1;key2=value2;...\" to be replaced in the File Spec. In the File Spec, the variables should be used as follows: ${key1}. A variable which is not in the list is read from the environment variable with the same name, and a default value can be provided as follows: ${key1:-value1}. Undefined variables without a default value fail the command. The File Spec can also use Go template conditionals and loops, such as {{if eq .key1 \"value1\"}}...{{end}} and {{range split .key2 \",\"}}...{{end}}.` `",
 	}
 }
 
@@ -1319,6 +1552,24 @@ func getNpmCommonFlags() []cli.Flag {
 	return append(getBuildAndModuleFlags(), npmFlags...)
 }
 
+func getPnpmFlags() []cli.Flag {
+	flag := cli.StringFlag{
+		Name:  "threads",
+		Value: "",
+		Usage: "[Default: 3] Number of working threads for build-info collection.` `",
+	}
+	return append([]cli.Flag{flag}, getBuildAndModuleFlags()...)
+}
+
+func getYarnFlags() []cli.Flag {
+	flag := cli.StringFlag{
+		Name:  "threads",
+		Value: "",
+		Usage: "[Default: 3] Number of working threads for build-info collection.` `",
+	}
+	return append([]cli.Flag{flag}, getBuildAndModuleFlags()...)
+}
+
 func getNpmFlags() []cli.Flag {
 	npmFlags := getNpmCommonFlags()
 	flag := cli.StringFlag{
@@ -1421,6 +1672,7 @@ func getMoveFlags() []cli.Flag {
 		getFailNoOpFlag(),
 		getArchiveEntriesFlag(),
 		getInsecureTlsFlag(),
+		getDetailedSummaryFlag(),
 	}...)
 
 }
@@ -1452,6 +1704,7 @@ func getCopyFlags() []cli.Flag {
 		getFailNoOpFlag(),
 		getArchiveEntriesFlag(),
 		getInsecureTlsFlag(),
+		getDetailedSummaryFlag(),
 	}...)
 }
 
@@ -1479,6 +1732,7 @@ func getDeleteFlags() []cli.Flag {
 		getThreadsFlag(),
 		getArchiveEntriesFlag(),
 		getInsecureTlsFlag(),
+		getDetailedSummaryFlag(),
 	}...)
 }
 
@@ -1521,6 +1775,7 @@ func getSetOrDeletePropsFlags() []cli.Flag {
 		getPropertiesFlag("Only artifacts with these properties are affected."),
 		getExcludePropertiesFlag("Only artifacts without the specified properties are affected"),
 		getInsecureTlsFlag(),
+		getDetailedSummaryFlag(),
 	}...)
 	return append(flags, getPropertiesFlags()...)
 }
@@ -1621,6 +1876,20 @@ func getBuildPublishFlags() []cli.Flag {
 			Name:  "env-exclude",
 			Usage: "[Default: *password*;*psw*;*secret*;*key*;*token*] List of case insensitive patterns in the form of \"value1;value2;...\". Environment variables match those patterns will be excluded.` `",
 		},
+		cli.StringFlag{
+			Name:  "output-file",
+			Usage: "[Optional] Path to a file to which the build info is written, instead of publishing it to Artifactory. The file can be published later using the build-import command.` `",
+		},
+		getInsecureTlsFlag(),
+	}...)
+}
+
+func getBuildImportFlags() []cli.Flag {
+	return append(getServerFlags(), []cli.Flag{
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "[Default: false] Set to true to get a preview of the imported build info, without publishing it to Artifactory.` `",
+		},
 		getInsecureTlsFlag(),
 	}...)
 }
@@ -1739,6 +2008,11 @@ func getConfigFlags() []cli.Flag {
 				"Username and password/API key will still be used with commands which use external tools or the JFrog Distribution service. " +
 				"Can only be passed along with username and password/API key options.` `",
 		},
+		cli.StringFlag{
+			Name: "credentials-helper",
+			Usage: "[Optional] An executable which stores the password, API key or access token instead of the config file, such as a docker credential helper. " +
+				"The executable is run with the 'get', 'store' and 'erase' actions, and exchanges the credentials with JFrog CLI through its standard input and output, as defined by the docker credential helpers protocol.` `",
+		},
 	}
 	flags = append(flags, getBaseFlags()...)
 	flags = append(flags, getClientCertsFlags()...)
@@ -1781,6 +2055,28 @@ func getBuildDiscardFlags() []cli.Flag {
 	}...)
 }
 
+func getBuildDiffFlags() []cli.Flag {
+	return append(getServerFlags(), []cli.Flag{
+		cli.BoolFlag{
+			Name:  "local",
+			Usage: "[Default: false] Set to true to compare the published base build with the build-info collected locally for the target build number, before it is published.` `",
+		},
+		cli.StringFlag{
+			Name:  "format",
+			Usage: "[Default: table] The output format of the differences. Accepts 'table' or 'json'.` `",
+		},
+		cli.StringFlag{
+			Name:  "env-include",
+			Usage: "[Default: *] List of patterns in the form of \"value1;value2;...\" Only environment variables match those patterns will be included in the local build-info.` `",
+		},
+		cli.StringFlag{
+			Name:  "env-exclude",
+			Usage: "[Default: *password*;*psw*;*secret*;*key*;*token*] List of case insensitive patterns in the form of \"value1;value2;...\". Environment variables match those patterns will be excluded from the local build-info.` `",
+		},
+		getInsecureTlsFlag(),
+	}...)
+}
+
 func getReleaseBundleCreateUpdateFlags() []cli.Flag {
 	releaseBundleFlags := append(getServerFlags(), getSpecFlags()...)
 	return append(releaseBundleFlags, []cli.Flag{
@@ -1840,7 +2136,7 @@ func getReleaseBundleDistributeFlags() []cli.Flag {
 		},
 		cli.StringFlag{
 			Name:  "dist-rules",
-			Usage: "Path to distribution rules.` `",
+			Usage: "Path to distribution rules, in JSON or YAML format.` `",
 		},
 		cli.StringFlag{
 			Name:  "site",
@@ -1892,6 +2188,10 @@ func getBuildAddGitFlags() []cli.Flag {
 			Name:  "config",
 			Usage: "[Optional] Path to a configuration file.` `",
 		},
+		cli.StringFlag{
+			Name:  "dot-git-path",
+			Usage: "[Optional] Semicolon-separated list of paths to additional directories containing .git, to collect VCS details from. The submodules of each repository are collected as well. The details of these repositories are saved with the build, but not published in the build-info.` `",
+		},
 	}
 	return append(bagFlags, getServerIdFlag())
 }
@@ -2050,6 +2350,9 @@ func configCmd(c *cli.Context) error {
 			return nil
 		}
 		if c.Args()[0] == "delete" {
+			if artDetails.IsFromEnv() {
+				return errors.New("the server '" + serverId + "' is set by the " + cliutils.EnvServerId + " environment variable, and can't be deleted from the config file")
+			}
 			if configCommandConfiguration.Interactive {
 				if !cliutils.InteractiveConfirm("Are you sure you want to delete \""+serverId+"\" configuration?", false) {
 					return nil
@@ -2085,7 +2388,7 @@ func configCmd(c *cli.Context) error {
 }
 
 func mvnLegacyCmd(c *cli.Context) error {
-	log.Warn(deprecatedWarning(utils.Maven, os.Args[2], "mvnc"))
+	log.Warn(deprecatedWarning(utils.Maven, c.Command.Name, "mvnc"))
 	if c.NArg() != 2 {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
 	}
@@ -2181,7 +2484,7 @@ func gradleCmd(c *cli.Context) error {
 }
 
 func gradleLegacyCmd(c *cli.Context) error {
-	log.Warn(deprecatedWarning(utils.Gradle, os.Args[2], "gradlec"))
+	log.Warn(deprecatedWarning(utils.Gradle, c.Command.Name, "gradlec"))
 
 	if c.NArg() != 2 {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
@@ -2301,7 +2604,7 @@ func nugetCmd(c *cli.Context) error {
 }
 
 func nugetLegacyCmd(c *cli.Context) error {
-	log.Warn(deprecatedWarning(utils.Nuget, os.Args[2], "nugetc"))
+	log.Warn(deprecatedWarning(utils.Nuget, c.Command.Name, "nugetc"))
 	if c.NArg() != 2 {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
 	}
@@ -2375,7 +2678,7 @@ func dotnetCmd(c *cli.Context) error {
 }
 
 func npmLegacyInstallCmd(c *cli.Context) error {
-	log.Warn(deprecatedWarning(utils.Npm, os.Args[2], "npmc"))
+	log.Warn(deprecatedWarning(utils.Npm, c.Command.Name, "npmc"))
 	if c.NArg() != 1 {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
 	}
@@ -2429,8 +2732,68 @@ func npmInstallOrCiCmd(c *cli.Context, npmCmd *npm.NpmInstallOrCiCommand, npmLeg
 	return npmLegacyCommand(c)
 }
 
+func pnpmInstallCmd(c *cli.Context) error {
+	if show, err := showCmdHelpIfNeeded(c); show || err != nil {
+		return err
+	}
+	configFilePath, args, err := getPnpmConfigAndArgs(c)
+	if err != nil {
+		return err
+	}
+	pnpmCmd := pnpm.NewPnpmInstallCommand()
+	pnpmCmd.SetConfigFilePath(configFilePath).SetArgs(args)
+	return commands.Exec(pnpmCmd)
+}
+
+func pnpmPublishCmd(c *cli.Context) error {
+	if show, err := showCmdHelpIfNeeded(c); show || err != nil {
+		return err
+	}
+	configFilePath, args, err := getPnpmConfigAndArgs(c)
+	if err != nil {
+		return err
+	}
+	pnpmCmd := pnpm.NewPnpmPublishCommand()
+	pnpmCmd.SetConfigFilePath(configFilePath).SetArgs(args)
+	return commands.Exec(pnpmCmd)
+}
+
+func getPnpmConfigAndArgs(c *cli.Context) (configFilePath string, args []string, err error) {
+	configFilePath, exists, err := utils.GetProjectConfFilePath(utils.Pnpm)
+	if err != nil {
+		return
+	}
+	if !exists {
+		err = errors.New(fmt.Sprintf("no pnpm configuration was found. Please run 'jfrog rt pnpm-config' command prior to running 'jfrog rt %s'", c.Command.Name))
+		return
+	}
+	args, err = utils.ParseArgs(extractCommand(c))
+	err = errorutils.CheckError(err)
+	return
+}
+
+func yarnCmd(c *cli.Context) error {
+	if show, err := showCmdHelpIfNeeded(c); show || err != nil {
+		return err
+	}
+
+	configFilePath, exists, err := utils.GetProjectConfFilePath(utils.Yarn)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.New("no yarn configuration was found. Please run 'jfrog rt yarn-config' command prior to running 'jfrog rt yarn'")
+	}
+	args, err := utils.ParseArgs(extractCommand(c))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	yarnCmd := yarn.NewYarnCommand().SetConfigFilePath(configFilePath).SetArgs(args)
+	return commands.Exec(yarnCmd)
+}
+
 func npmLegacyCiCmd(c *cli.Context) error {
-	log.Warn(deprecatedWarning(utils.Npm, os.Args[2], "npmc"))
+	log.Warn(deprecatedWarning(utils.Npm, c.Command.Name, "npmc"))
 	if c.NArg() != 1 {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
 	}
@@ -2480,7 +2843,7 @@ func npmPublishCmd(c *cli.Context) error {
 }
 
 func npmLegacyPublishCmd(c *cli.Context) error {
-	log.Warn(deprecatedWarning(utils.Npm, os.Args[2], "npmc"))
+	log.Warn(deprecatedWarning(utils.Npm, c.Command.Name, "npmc"))
 	if c.NArg() != 1 {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
 	}
@@ -2546,17 +2909,7 @@ func showCmdHelpIfNeeded(c *cli.Context) (bool, error) {
 }
 
 func shouldSkipGoFlagParsing() bool {
-	// This function is executed by code-gangsta, regardless of the CLI command being executed.
-	// There's no need to run the code of this function, if the command is not "jfrog rt go*".
-	if len(os.Args) < 3 || os.Args[2] != "go" {
-		return false
-	}
-
-	_, exists, err := utils.GetProjectConfFilePath(utils.Go)
-	if err != nil {
-		cliutils.ExitOnErr(err)
-	}
-	return exists
+	return shouldSkipCurrentCommandFlagParsing("go")
 }
 
 func shouldSkipNpmFlagParsing() bool {
@@ -2565,48 +2918,48 @@ func shouldSkipNpmFlagParsing() bool {
 	if len(os.Args) < 3 || !npmUtils.IsNpmCommand(os.Args[2]) {
 		return false
 	}
-
-	_, exists, err := utils.GetProjectConfFilePath(utils.Npm)
-	if err != nil {
-		cliutils.ExitOnErr(err)
-	}
-	return exists
+	return shouldSkipFlagParsing(os.Args[2])
 }
 
 func shouldSkipNugetFlagParsing() bool {
-	// This function is executed by code-gangsta, regardless of the CLI command being executed.
-	// There's no need to run the code of this function, if the command is not "jfrog rt nuget*".
-	if len(os.Args) < 3 || os.Args[2] != "nuget" {
-		return false
-	}
-
-	_, exists, err := utils.GetProjectConfFilePath(utils.Nuget)
-	if err != nil {
-		cliutils.ExitOnErr(err)
-	}
-	return exists
+	return shouldSkipCurrentCommandFlagParsing("nuget")
 }
 
 func shouldSkipMavenFlagParsing() bool {
-	// This function is executed by code-gangsta, regardless of the CLI command being executed.
-	// There's no need to run the code of this function, if the command is not "jfrog rt mvn*".
-	if len(os.Args) < 3 || os.Args[2] != "mvn" {
-		return false
-	}
-	_, exists, err := utils.GetProjectConfFilePath(utils.Maven)
-	if err != nil {
-		cliutils.ExitOnErr(err)
-	}
-	return exists
+	return shouldSkipCurrentCommandFlagParsing("mvn")
 }
 
 func shouldSkipGradleFlagParsing() bool {
+	return shouldSkipCurrentCommandFlagParsing("gradle")
+}
+
+func shouldSkipCurrentCommandFlagParsing(commandName string) bool {
 	// This function is executed by code-gangsta, regardless of the CLI command being executed.
-	// There's no need to run the code of this function, if the command is not "jfrog rt gradle*".
-	if len(os.Args) < 3 || os.Args[2] != "gradle" {
+	// There's no need to run the code of this function, if the command is not "jfrog rt <commandName>".
+	if len(os.Args) < 3 || os.Args[2] != commandName {
 		return false
 	}
-	_, exists, err := utils.GetProjectConfFilePath(utils.Gradle)
+	return shouldSkipFlagParsing(commandName)
+}
+
+// The native commands of the build tools parse their own flags, if the project is configured by a project config file.
+func shouldSkipFlagParsing(commandName string) bool {
+	var projectType utils.ProjectType
+	switch {
+	case commandName == "go":
+		projectType = utils.Go
+	case commandName == "nuget":
+		projectType = utils.Nuget
+	case commandName == "mvn":
+		projectType = utils.Maven
+	case commandName == "gradle":
+		projectType = utils.Gradle
+	case npmUtils.IsNpmCommand(commandName):
+		projectType = utils.Npm
+	default:
+		return false
+	}
+	_, exists, err := utils.GetProjectConfFilePath(projectType)
 	if err != nil {
 		cliutils.ExitOnErr(err)
 	}
@@ -2633,7 +2986,7 @@ func goCmd(c *cli.Context) error {
 }
 
 func goLegacyCmd(c *cli.Context) error {
-	log.Warn(deprecatedWarning(utils.Go, os.Args[2], "go-config"))
+	log.Warn(deprecatedWarning(utils.Go, c.Command.Name, "go-config"))
 	// When the no-registry set to false (default), two arguments are mandatory: go command and the target repository
 	if !c.Bool("no-registry") && c.NArg() != 2 {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
@@ -2735,6 +3088,20 @@ func createNpmConfigCmd(c *cli.Context) error {
 	return commandUtils.CreateBuildConfig(c, utils.Npm)
 }
 
+func createPnpmConfigCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	return commandUtils.CreateBuildConfig(c, utils.Pnpm)
+}
+
+func createYarnConfigCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	return commandUtils.CreateBuildConfig(c, utils.Yarn)
+}
+
 func createNugetConfigCmd(c *cli.Context) error {
 	if c.NArg() != 0 {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
@@ -2816,11 +3183,21 @@ func downloadCmd(c *cli.Context) error {
 	err = commands.Exec(downloadCommand)
 	defer logUtils.CloseLogFile(downloadCommand.LogFile())
 	result := downloadCommand.Result()
-	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), result.Reader(), rtDetails.Url, err)
+	err = cliutils.PrintSummary(createResultSummaryReport(result, err), result.Reader(), rtDetails.Url, err)
 
 	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
 
+// Creates the summary of the command, including the failures, retries, transferred bytes and duration collected by the command.
+func createResultSummaryReport(result *commandUtils.Result, err error) *summary.Summary {
+	summaryReport := cliutils.CreateSummaryReport(result.SuccessCount(), result.FailCount(), err)
+	summaryReport.Failures = result.Failures()
+	summaryReport.Totals.Retries = result.Retries()
+	summaryReport.Totals.Bytes = result.TransferredBytes()
+	summaryReport.Totals.DurationMs = result.Duration().Milliseconds()
+	return summaryReport
+}
+
 func uploadCmd(c *cli.Context) error {
 	if c.NArg() > 0 && c.IsSet("spec") {
 		return cliutils.PrintHelpAndReturnError("No arguments should be sent when the spec option is used.", c)
@@ -2828,6 +3205,9 @@ func uploadCmd(c *cli.Context) error {
 	if !(c.NArg() == 2 || (c.NArg() == 0 && c.IsSet("spec"))) {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
 	}
+	if c.Bool("resume") && c.IsSet("sync-deletes") {
+		return cliutils.PrintHelpAndReturnError("The --resume option cannot be used together with the --sync-deletes option.", c)
+	}
 
 	var uploadSpec *spec.SpecFiles
 	var err error
@@ -2861,7 +3241,7 @@ func uploadCmd(c *cli.Context) error {
 	err = commands.Exec(uploadCmd)
 	defer logUtils.CloseLogFile(uploadCmd.LogFile())
 	result := uploadCmd.Result()
-	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), nil, "", err)
+	err = cliutils.PrintSummary(createResultSummaryReport(result, err), nil, "", err)
 
 	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
@@ -2893,10 +3273,10 @@ func moveCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	moveCmd.SetDryRun(c.Bool("dry-run")).SetRtDetails(rtDetails).SetSpec(moveSpec)
+	moveCmd.SetDryRun(c.Bool("dry-run")).SetRtDetails(rtDetails).SetSpec(moveSpec).SetDetailedSummaryt(c.Bool("detailed-summary"))
 	err = commands.Exec(moveCmd)
 	result := moveCmd.Result()
-	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), nil, "", err)
+	err = cliutils.PrintSummary(createResultSummaryReport(result, err), result.Reader(), rtDetails.Url, err)
 
 	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
@@ -2929,10 +3309,10 @@ func copyCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	copyCommand.SetSpec(copySpec).SetDryRun(c.Bool("dry-run")).SetRtDetails(rtDetails)
+	copyCommand.SetSpec(copySpec).SetDryRun(c.Bool("dry-run")).SetRtDetails(rtDetails).SetDetailedSummaryt(c.Bool("detailed-summary"))
 	err = commands.Exec(copyCommand)
 	result := copyCommand.Result()
-	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), nil, "", err)
+	err = cliutils.PrintSummary(createResultSummaryReport(result, err), result.Reader(), rtDetails.Url, err)
 
 	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
@@ -2970,10 +3350,10 @@ func deleteCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	deleteCommand.SetThreads(threads).SetQuiet(cliutils.GetQuietValue(c)).SetDryRun(c.Bool("dry-run")).SetRtDetails(rtDetails).SetSpec(deleteSpec)
+	deleteCommand.SetThreads(threads).SetQuiet(cliutils.GetQuietValue(c)).SetDryRun(c.Bool("dry-run")).SetRtDetails(rtDetails).SetSpec(deleteSpec).SetDetailedSummaryt(c.Bool("detailed-summary"))
 	err = commands.Exec(deleteCommand)
 	result := deleteCommand.Result()
-	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), nil, "", err)
+	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), result.Reader(), rtDetails.Url, err)
 
 	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
@@ -3061,7 +3441,7 @@ func preparePropsCmd(c *cli.Context) (*generic.PropsCommand, error) {
 	}
 
 	cmd := command.SetProps(props)
-	cmd.SetThreads(threads).SetSpec(propsSpec).SetDryRun(c.Bool("dry-run")).SetRtDetails(rtDetails)
+	cmd.SetThreads(threads).SetSpec(propsSpec).SetDryRun(c.Bool("dry-run")).SetRtDetails(rtDetails).SetDetailedSummaryt(c.Bool("detailed-summary"))
 	return cmd, nil
 }
 
@@ -3070,11 +3450,15 @@ func setPropsCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	rtDetails, err := cmd.RtDetails()
+	if err != nil {
+		return err
+	}
 
 	propsCmd := generic.NewSetPropsCommand().SetPropsCommand(*cmd)
 	err = commands.Exec(propsCmd)
 	result := propsCmd.Result()
-	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), nil, "", err)
+	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), result.Reader(), rtDetails.Url, err)
 
 	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
@@ -3084,11 +3468,15 @@ func deletePropsCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	rtDetails, err := cmd.RtDetails()
+	if err != nil {
+		return err
+	}
 
 	propsCmd := generic.NewDeletePropsCommand().DeletePropsCommand(*cmd)
 	err = commands.Exec(propsCmd)
 	result := propsCmd.Result()
-	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), nil, "", err)
+	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), result.Reader(), rtDetails.Url, err)
 
 	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
@@ -3106,11 +3494,24 @@ func buildPublishCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	buildPublishCmd := buildinfo.NewBuildPublishCommand().SetRtDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetConfig(buildInfoConfiguration)
+	buildPublishCmd := buildinfo.NewBuildPublishCommand().SetRtDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetConfig(buildInfoConfiguration).SetOutputFile(c.String("output-file"))
 
 	return commands.Exec(buildPublishCmd)
 }
 
+func buildImportCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
+	if err != nil {
+		return err
+	}
+	buildImportCmd := buildinfo.NewBuildImportCommand().SetRtDetails(rtDetails).SetFilePath(c.Args().Get(0)).SetDryRun(c.Bool("dry-run"))
+
+	return commands.Exec(buildImportCmd)
+}
+
 func buildAddDependenciesCmd(c *cli.Context) error {
 	if c.NArg() > 2 && c.IsSet("spec") {
 		return cliutils.PrintHelpAndReturnError("Only path or spec is allowed, not both.", c)
@@ -3169,7 +3570,8 @@ func buildAddGitCmd(c *cli.Context) error {
 		return err
 	}
 
-	buildAddGitConfigurationCmd := buildinfo.NewBuildAddGitCommand().SetBuildConfiguration(buildConfiguration).SetConfigFilePath(c.String("config")).SetServerId(c.String("server-id"))
+	buildAddGitConfigurationCmd := buildinfo.NewBuildAddGitCommand().SetBuildConfiguration(buildConfiguration).SetConfigFilePath(c.String("config")).SetServerId(c.String("server-id")).
+		SetDotGitPaths(cliutils.GetStringsArrFlagValue(c, "dot-git-path"))
 	if c.NArg() == 3 {
 		buildAddGitConfigurationCmd.SetDotGitPath(c.Args().Get(2))
 	} else if c.NArg() == 1 {
@@ -3178,6 +3580,108 @@ func buildAddGitCmd(c *cli.Context) error {
 	return commands.Exec(buildAddGitConfigurationCmd)
 }
 
+func syncCmd(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	direction := generic.SyncBoth
+	if c.IsSet("direction") {
+		direction = generic.SyncDirection(c.String("direction"))
+		if direction != generic.SyncBoth && direction != generic.SyncUpload && direction != generic.SyncDownload {
+			return cliutils.PrintHelpAndReturnError("The --direction option should be one of: both, upload, download.", c)
+		}
+	}
+	if c.Bool("delete") && direction == generic.SyncBoth {
+		return cliutils.PrintHelpAndReturnError("The --delete option can be used only with --direction=upload or --direction=download.", c)
+	}
+	threads, err := getThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
+	if err != nil {
+		return err
+	}
+	syncCommand := generic.NewSyncCommand().SetLocalPath(c.Args().Get(0)).SetRepoPath(c.Args().Get(1)).SetDirection(direction).
+		SetDeleteExtraneous(c.Bool("delete")).SetPlanOnly(c.Bool("plan")).SetThreads(threads).SetRetries(retries)
+	syncCommand.SetQuiet(cliutils.GetQuietValue(c)).SetRtDetails(rtDetails)
+	err = commands.Exec(syncCommand)
+	if c.Bool("plan") {
+		return err
+	}
+	result := syncCommand.Result()
+	err = cliutils.PrintSummary(createResultSummaryReport(result, err), nil, "", err)
+
+	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
+}
+
+func specValidateCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	specValidateCommand := generic.NewSpecValidateCommand().SetSpecFilePath(c.Args().Get(0)).SetSpecVars(cliutils.SpecVarsStringToMap(c.String("spec-vars")))
+	return commands.Exec(specValidateCommand)
+}
+
+func runPipelineCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	runPipelineCommand := pipeline.NewRunPipelineCommand().SetPipelineFilePath(c.Args().Get(0)).SetStepExecutor(runPipelineStep)
+	return commands.Exec(runPipelineCommand)
+}
+
+// Runs a pipeline step as a 'jfrog rt' command.
+// The server ID of the pipeline is passed to the command, if it supports the server-id option and the step does not set it.
+func runPipelineStep(pipelineDetails *pipeline.Pipeline, step *pipeline.Step) error {
+	return runPipelineStepCommand(GetCommands(), pipelineDetails, step)
+}
+
+func runPipelineStepCommand(rtCommands []cli.Command, pipelineDetails *pipeline.Pipeline, step *pipeline.Step) error {
+	var command *cli.Command
+	for i := range rtCommands {
+		if rtCommands[i].HasName(step.Command) {
+			command = &rtCommands[i]
+			break
+		}
+	}
+	if command == nil {
+		return errorutils.CheckError(errors.New("Unknown command in pipeline step " + step.GetName() + ": " + step.Command))
+	}
+	if command.Name == "run-pipeline" {
+		return errorutils.CheckError(errors.New("A pipeline step cannot run another pipeline."))
+	}
+	// The flag parsing of the commands was set according to the 'run-pipeline' command, so it is set again for the step command.
+	command.SkipFlagParsing = command.SkipFlagParsing || shouldSkipFlagParsing(command.Name)
+
+	args := append([]string{"jfrog rt", command.Name}, step.GetFlags()...)
+	if pipelineDetails.ServerId != "" && !step.HasFlag("server-id") && hasFlag(command, "server-id") {
+		args = append(args, "--server-id="+pipelineDetails.ServerId)
+	}
+	args = append(args, step.Args...)
+	log.Debug("Running pipeline step:", strings.Join(args, " "))
+
+	app := cli.NewApp()
+	app.Name = "jfrog rt"
+	app.Commands = rtCommands
+	return app.Run(args)
+}
+
+func hasFlag(command *cli.Command, flagName string) bool {
+	for _, flag := range command.Flags {
+		for _, name := range strings.Split(flag.GetName(), ",") {
+			if strings.TrimSpace(name) == flagName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func buildScanCmd(c *cli.Context) error {
 	if c.NArg() > 2 {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
@@ -3273,6 +3777,24 @@ func buildDiscardCmd(c *cli.Context) error {
 	return commands.Exec(buildDiscardCmd)
 }
 
+func buildDiffCmd(c *cli.Context) error {
+	if c.NArg() != 3 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	format := c.String("format")
+	if format == "" {
+		format = buildinfo.DiffTableFormat
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
+	if err != nil {
+		return err
+	}
+	buildDiffCmd := buildinfo.NewBuildDiffCommand().SetBuildName(c.Args().Get(0)).SetBaseBuildNumber(c.Args().Get(1)).SetTargetBuildNumber(c.Args().Get(2)).
+		SetLocal(c.Bool("local")).SetFormat(format).SetConfig(createBuildInfoConfiguration(c)).SetRtDetails(rtDetails)
+
+	return commands.Exec(buildDiffCmd)
+}
+
 func releaseBundleCreateCmd(c *cli.Context) error {
 	if !(c.NArg() == 2 && c.IsSet("spec") || (c.NArg() == 3 && !c.IsSet("spec"))) {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
@@ -3613,10 +4135,13 @@ func accessTokenCreateCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if expiry == 0 && c.String("profile") != "" {
+		return cliutils.PrintHelpAndReturnError("The token of a token profile must expire, to be rotated.", c)
+	}
 	accessTokenCreateCmd := generic.NewAccessTokenCreateCommand()
-	accessTokenCreateCmd.SetUserName(c.Args().Get(0)).SetRtDetails(rtDetails).SetRefreshable(c.Bool("refreshable")).SetExpiry(expiry).SetGroups(c.String("groups")).SetAudience(c.String("audience")).SetGrantAdmin(c.Bool("grant-admin"))
+	accessTokenCreateCmd.SetUserName(c.Args().Get(0)).SetRtDetails(rtDetails).SetRefreshable(c.Bool("refreshable")).SetExpiry(expiry).SetGroups(c.String("groups")).SetAudience(c.String("audience")).SetGrantAdmin(c.Bool("grant-admin")).SetProfile(c.String("profile"))
 	err = commands.Exec(accessTokenCreateCmd)
-	if err != nil {
+	if err != nil || c.String("profile") != "" {
 		return err
 	}
 	resString, err := accessTokenCreateCmd.Response()
@@ -3628,6 +4153,39 @@ func accessTokenCreateCmd(c *cli.Context) error {
 	return nil
 }
 
+func tokenListCmd(c *cli.Context) error {
+	if c.NArg() > 0 {
+		return cliutils.PrintHelpAndReturnError("No arguments should be sent.", c)
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
+	if err != nil {
+		return err
+	}
+	tokenListCmd := generic.NewTokenListCommand().SetRtDetails(rtDetails)
+	err = commands.Exec(tokenListCmd)
+	if err != nil {
+		return err
+	}
+	resString, err := tokenListCmd.Response()
+	if err != nil {
+		return err
+	}
+	log.Output(clientutils.IndentJson(resString))
+	return nil
+}
+
+func tokenRevokeCmd(c *cli.Context) error {
+	if c.IsSet("profile") == (c.NArg() == 1) || c.NArg() > 1 {
+		return cliutils.PrintHelpAndReturnError("Either a token ID argument or the --profile option should be sent.", c)
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
+	if err != nil {
+		return err
+	}
+	tokenRevokeCmd := generic.NewTokenRevokeCommand().SetRtDetails(rtDetails).SetTokenId(c.Args().Get(0)).SetProfile(c.String("profile"))
+	return commands.Exec(tokenRevokeCmd)
+}
+
 func validateBuildConfiguration(c *cli.Context, buildConfiguration *utils.BuildConfiguration) error {
 	if buildConfiguration.BuildName == "" || buildConfiguration.BuildNumber == "" {
 		return cliutils.PrintHelpAndReturnError("Build name and build number are expected as command arguments or environment variables.", c)
@@ -3636,8 +4194,13 @@ func validateBuildConfiguration(c *cli.Context, buildConfiguration *utils.BuildC
 }
 
 func offerConfig(c *cli.Context) (*config.ArtifactoryDetails, error) {
+	// Servers set by environment variables are used without the config file, which shouldn't be created for them.
+	envDetails, err := config.GetEnvArtifactoryConf()
+	if err != nil || envDetails != nil {
+		return nil, err
+	}
 	var exists bool
-	exists, err := config.IsArtifactoryConfExists()
+	exists, err = config.IsArtifactoryConfExists()
 	if err != nil || exists {
 		return nil, err
 	}
@@ -3689,6 +4252,9 @@ func createArtifactoryDetailsWithConfigOffer(c *cli.Context, excludeRefreshableT
 	// For security reasons, we'd like to avoid using part of the connection details from command options and the rest from the config.
 	// Either use command options only or config only.
 	if credentialsChanged(details) {
+		if c.IsSet("token-profile") {
+			return nil, errors.New("the --token-profile option can only be used with a server configured using the config command")
+		}
 		return details, nil
 	}
 
@@ -3705,13 +4271,27 @@ func createArtifactoryDetailsWithConfigOffer(c *cli.Context, excludeRefreshableT
 		}
 	}
 
-	// Take InsecureTls value from options since it is not saved in config.
-	confDetails.InsecureTls = details.InsecureTls
+	if tokenProfile := getTokenProfile(c); tokenProfile != "" {
+		if err = confDetails.SelectTokenProfile(tokenProfile, excludeRefreshableTokens); err != nil {
+			return nil, err
+		}
+	}
+
+	// Take InsecureTls value from options since it is not saved in config. Servers set by environment variables may also set it.
+	confDetails.InsecureTls = confDetails.InsecureTls || details.InsecureTls
 	confDetails.Url = clientutils.AddTrailingSlashIfNeeded(confDetails.Url)
 	confDetails.DistributionUrl = clientutils.AddTrailingSlashIfNeeded(confDetails.DistributionUrl)
 	return confDetails, nil
 }
 
+// Returns the token profile to authenticate with, from the --token-profile option or from the environment.
+func getTokenProfile(c *cli.Context) string {
+	if c.IsSet("token-profile") {
+		return c.String("token-profile")
+	}
+	return os.Getenv(cliutils.TokenProfile)
+}
+
 func createArtifactoryDetailsFromOptions(c *cli.Context) (details *config.ArtifactoryDetails) {
 	details = new(config.ArtifactoryDetails)
 	details.Url = c.String("url")
@@ -4004,6 +4584,7 @@ func createDownloadConfiguration(c *cli.Context) (downloadConfiguration *utils.D
 		return nil, err
 	}
 	downloadConfiguration.Symlink = true
+	downloadConfiguration.Cache = c.Bool("cache")
 	return
 }
 
@@ -4119,6 +4700,7 @@ func fixWinPathBySource(path string, fromSpec bool) string {
 func createUploadConfiguration(c *cli.Context) (uploadConfiguration *utils.UploadConfiguration, err error) {
 	uploadConfiguration = new(utils.UploadConfiguration)
 	uploadConfiguration.Symlink = c.Bool("symlinks")
+	uploadConfiguration.Resume = c.Bool("resume")
 	uploadConfiguration.Retries, err = getRetries(c)
 	if err != nil {
 		return nil, err
@@ -4148,6 +4730,7 @@ func createConfigCommandConfiguration(c *cli.Context) (configCommandConfiguratio
 	configCommandConfiguration.EncPassword = c.BoolT("enc-password")
 	configCommandConfiguration.Interactive = cliutils.GetInteractiveValue(c)
 	configCommandConfiguration.BasicAuthOnly = c.Bool("basic-auth-only")
+	configCommandConfiguration.ArtDetails.CredentialsHelper = c.String("credentials-helper")
 	return
 }
 
@@ -1,7 +1,15 @@
 package artifactory
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
+
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/pipeline"
+	"github.com/jfrog/jfrog-cli/utils/log"
 )
 
 func TestValidateGoNativeCommand(t *testing.T) {
@@ -29,3 +37,46 @@ func TestValidateGoNativeCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestRunPipelineStepWithNativeFlags(t *testing.T) {
+	log.SetDefaultLogger()
+	projectDir, err := ioutil.TempDir("", "pipeline-project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(projectDir)
+	if err = os.MkdirAll(filepath.Join(projectDir, ".jfrog", "projects"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(projectDir, ".jfrog", "projects", "maven.yaml"), []byte("version: 1\ntype: maven\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	if err = os.Chdir(projectDir); err != nil {
+		t.Fatal(err)
+	}
+
+	// The step command is replaced, to receive its arguments without running Maven.
+	var actualArgs []string
+	rtCommands := GetCommands()
+	for i := range rtCommands {
+		if rtCommands[i].Name == "mvn" {
+			rtCommands[i].Action = func(c *cli.Context) error {
+				actualArgs = c.Args()
+				return nil
+			}
+		}
+	}
+	step := &pipeline.Step{Command: "mvn", Args: []string{"clean", "install", "-DskipTests"}, Flags: map[string]interface{}{"build-name": "build"}}
+	if err = runPipelineStepCommand(rtCommands, &pipeline.Pipeline{}, step); err != nil {
+		t.Fatal(err)
+	}
+	expectedArgs := []string{"--build-name=build", "clean", "install", "-DskipTests"}
+	if !reflect.DeepEqual(expectedArgs, actualArgs) {
+		t.Errorf("Expected the step arguments: %v, got: %v", expectedArgs, actualArgs)
+	}
+}
@@ -3,8 +3,8 @@ package buildinfo
 import (
 	"errors"
 	"fmt"
-	gofrogcmd "github.com/jfrog/gofrog/io"
 	"github.com/jfrog/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/artifactory/utils/git"
 	utilsconfig "github.com/jfrog/jfrog-cli/utils/config"
 	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
@@ -13,10 +13,10 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/spf13/viper"
-	"io"
-	"os"
-	"os/exec"
+	"path/filepath"
+	"regexp"
 	"strconv"
+	"strings"
 )
 
 const (
@@ -29,6 +29,7 @@ const (
 type BuildAddGitCommand struct {
 	buildConfiguration *utils.BuildConfiguration
 	dotGitPath         string
+	dotGitPaths        []string
 	configFilePath     string
 	serverId           string
 	issuesConfig       *IssuesConfiguration
@@ -53,6 +54,12 @@ func (config *BuildAddGitCommand) SetDotGitPath(dotGitPath string) *BuildAddGitC
 	return config
 }
 
+// Paths to additional repositories, which VCS details should be collected for.
+func (config *BuildAddGitCommand) SetDotGitPaths(dotGitPaths []string) *BuildAddGitCommand {
+	config.dotGitPaths = dotGitPaths
+	return config
+}
+
 func (config *BuildAddGitCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *BuildAddGitCommand {
 	config.buildConfiguration = buildConfiguration
 	return config
@@ -71,26 +78,22 @@ func (config *BuildAddGitCommand) Run() error {
 	}
 
 	// Find .git folder if it wasn't provided in the command.
+	// The additional repositories are never the main repository, which the VCS details of the build-info and the issues are collected from.
 	if config.dotGitPath == "" {
-		var exists bool
-		config.dotGitPath, exists, err = fileutils.FindUpstream(".git", fileutils.Dir)
+		config.dotGitPath, err = findDotGitUpstream()
 		if err != nil {
 			return err
 		}
-		if !exists {
-			return errorutils.CheckError(errors.New("Could not find .git"))
-		}
 	}
 
-	// Collect URL and Revision into GitManager.
-	gitManager := clientutils.NewGitManager(config.dotGitPath)
-	err = gitManager.ReadConfig()
+	// Collect URL, revision, branch and message of the repositories and their submodules.
+	vcsList, err := config.collectVcsDetails()
 	if err != nil {
 		return err
 	}
 
 	// Collect issues if required.
-	var issues []buildinfo.AffectedIssue
+	var issues []utils.AffectedIssue
 	if config.configFilePath != "" {
 		issues, err = config.collectBuildIssues()
 		if err != nil {
@@ -99,31 +102,152 @@ func (config *BuildAddGitCommand) Run() error {
 	}
 
 	// Populate partials with VCS info.
-	populateFunc := func(partial *buildinfo.Partial) {
+	populateFunc := func(partial *utils.Partial) {
 		partial.Vcs = &buildinfo.Vcs{
-			Url:      gitManager.GetUrl(),
-			Revision: gitManager.GetRevision(),
+			Url:      vcsList[0].Url,
+			Revision: vcsList[0].Revision,
 		}
+		partial.VcsList = vcsList
 
 		if config.configFilePath != "" {
-			partial.Issues = &buildinfo.Issues{
-				Tracker:                &buildinfo.Tracker{Name: config.issuesConfig.TrackerName, Version: ""},
+			partial.Issues = &utils.Issues{
+				Tracker:                &buildinfo.Tracker{Name: config.issuesConfig.GetTrackersNames(), Version: ""},
 				AggregateBuildIssues:   config.issuesConfig.Aggregate,
 				AggregationBuildStatus: config.issuesConfig.AggregationStatus,
 				AffectedIssues:         issues,
 			}
 		}
 	}
-	err = utils.SavePartialBuildInfo(config.buildConfiguration.BuildName, config.buildConfiguration.BuildNumber, populateFunc)
+	err = utils.SaveExtendedPartialBuildInfo(config.buildConfiguration.BuildName, config.buildConfiguration.BuildNumber, populateFunc)
 	if err != nil {
 		return err
 	}
 
 	// Done.
-	log.Info("Collected VCS details for", config.buildConfiguration.BuildName+"/"+config.buildConfiguration.BuildNumber+".")
+	log.Info("Collected VCS details of", len(vcsList), "repositories for", config.buildConfiguration.BuildName+"/"+config.buildConfiguration.BuildNumber+".")
 	return nil
 }
 
+// Returns the closest directory containing .git, searching from the current directory upwards.
+// In submodules and linked worktrees, .git is a file rather than a directory.
+func findDotGitUpstream() (string, error) {
+	dirPath, dirExists, err := fileutils.FindUpstream(git.DotGit, fileutils.Dir)
+	if err != nil {
+		return "", err
+	}
+	filePath, fileExists, err := fileutils.FindUpstream(git.DotGit, fileutils.File)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case fileExists && (!dirExists || len(filePath) > len(dirPath)):
+		return filePath, nil
+	case dirExists:
+		return dirPath, nil
+	}
+	return "", errorutils.CheckError(errors.New("Could not find .git"))
+}
+
+// Collects the VCS details of the main repository, the additional repositories and all of their submodules.
+// The details of the main repository are always first.
+func (config *BuildAddGitCommand) collectVcsDetails() ([]utils.Vcs, error) {
+	var vcsList []utils.Vcs
+	visited := make(map[string]bool)
+	for _, path := range append([]string{config.dotGitPath}, config.dotGitPaths...) {
+		repoVcsList, err := collectRepositoryVcsDetails(path, visited)
+		if err != nil {
+			return nil, err
+		}
+		vcsList = append(vcsList, repoVcsList...)
+	}
+	return vcsList, nil
+}
+
+// Collects the VCS details of the repository and its initialized submodules, recursively.
+// Repositories which were already visited are skipped.
+func collectRepositoryVcsDetails(path string, visited map[string]bool) ([]utils.Vcs, error) {
+	repo, err := git.OpenRepository(path)
+	if err != nil {
+		return nil, err
+	}
+	dotGitPath, err := filepath.Abs(repo.GetDotGitPath())
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if visited[dotGitPath] {
+		return nil, nil
+	}
+	visited[dotGitPath] = true
+
+	vcs, err := readVcsDetails(repo)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Collected VCS details of", repo.GetWorkTreePath()+":", vcs.Url, vcs.Revision)
+	vcsList := []utils.Vcs{*vcs}
+
+	submodules, err := repo.GetSubmodulesPaths()
+	if err != nil {
+		return nil, err
+	}
+	for _, submodule := range submodules {
+		submodulePath := filepath.Join(repo.GetWorkTreePath(), submodule)
+		if !fileutils.IsPathExists(filepath.Join(submodulePath, git.DotGit), false) {
+			log.Debug("Skipping submodule", submodulePath+", since it is not initialized.")
+			continue
+		}
+		submoduleVcsList, err := collectRepositoryVcsDetails(submodulePath, visited)
+		if err != nil {
+			return nil, err
+		}
+		vcsList = append(vcsList, submoduleVcsList...)
+	}
+	return vcsList, nil
+}
+
+func readVcsDetails(repo *git.Repository) (*utils.Vcs, error) {
+	vcs := new(utils.Vcs)
+	url, err := repo.GetRemoteUrl("origin")
+	if err != nil {
+		return nil, err
+	}
+	vcs.Url, err = maskCredentials(url)
+	if err != nil {
+		return nil, err
+	}
+	vcs.Revision, err = repo.ResolveRevision("HEAD")
+	if err != nil {
+		return nil, err
+	}
+	vcs.Branch, err = repo.GetBranch()
+	if err != nil {
+		return nil, err
+	}
+	// The commit message is optional, since the commit object may be missing, for example in partial clones.
+	commit, err := repo.ReadCommit(vcs.Revision)
+	if err != nil {
+		log.Debug("Could not read the message of commit", vcs.Revision+":", err.Error())
+		return vcs, nil
+	}
+	vcs.Message = strings.TrimSpace(commit.Message)
+	return vcs, nil
+}
+
+// Adds the '.git' suffix to the URL, and masks the credentials in it.
+func maskCredentials(url string) (string, error) {
+	if !strings.HasSuffix(url, ".git") {
+		url += ".git"
+	}
+	credentialsRegExp, err := clientutils.GetRegExp(clientutils.CredentialsInUrlRegexp)
+	if err != nil {
+		return "", err
+	}
+	if credentials := credentialsRegExp.FindString(url); credentials != "" {
+		return clientutils.MaskCredentials(url, credentials), nil
+	}
+	return url, nil
+}
+
 // Priorities for selecting server:
 // 1. 'server-id' flag.
 // 2. 'serverID' in config file.
@@ -148,20 +272,14 @@ func (config *BuildAddGitCommand) CommandName() string {
 	return "rt_build_add_git"
 }
 
-func (config *BuildAddGitCommand) collectBuildIssues() ([]buildinfo.AffectedIssue, error) {
+func (config *BuildAddGitCommand) collectBuildIssues() ([]utils.AffectedIssue, error) {
 	log.Info("Collecting build issues from VCS...")
 
-	// Check that git exists in path.
-	_, err := exec.LookPath("git")
-	if err != nil {
-		return nil, errorutils.CheckError(err)
-	}
-
 	// Initialize issues-configuration.
 	config.issuesConfig = new(IssuesConfiguration)
 
 	// Create config's IssuesConfigurations from the provided spec file.
-	err = config.createIssuesConfigs()
+	err := config.createIssuesConfigs()
 	if err != nil {
 		return nil, err
 	}
@@ -176,55 +294,46 @@ func (config *BuildAddGitCommand) collectBuildIssues() ([]buildinfo.AffectedIssu
 	return config.DoCollect(config.issuesConfig, lastVcsRevision)
 }
 
-func (config *BuildAddGitCommand) DoCollect(issuesConfig *IssuesConfiguration, lastVcsRevision string) ([]buildinfo.AffectedIssue, error) {
-	// Create regex pattern.
-	issueRegexp, err := clientutils.GetRegExp(issuesConfig.Regexp)
-	if err != nil {
-		return nil, err
+func (config *BuildAddGitCommand) DoCollect(issuesConfig *IssuesConfiguration, lastVcsRevision string) ([]utils.AffectedIssue, error) {
+	// Create a regex pattern for each of the trackers.
+	trackers := issuesConfig.GetTrackers()
+	issueRegexps := make([]*regexp.Regexp, len(trackers))
+	for i, tracker := range trackers {
+		issueRegexp, err := clientutils.GetRegExp(tracker.Regexp)
+		if err != nil {
+			return nil, err
+		}
+		issueRegexps[i] = issueRegexp
 	}
 
 	// Get log with limit, starting from the latest commit.
-	logCmd := &LogCmd{logLimit: issuesConfig.LogLimit, lastVcsRevision: lastVcsRevision}
-	var foundIssues []buildinfo.AffectedIssue
-	logRegExp := gofrogcmd.CmdOutputPattern{
-		RegExp: issueRegexp,
-		ExecFunc: func(pattern *gofrogcmd.CmdOutputPattern) (string, error) {
-			// Reached here - means no error occurred.
-
-			// Check for out of bound results.
-			if len(pattern.MatchedResults)-1 < issuesConfig.KeyGroupIndex || len(pattern.MatchedResults)-1 < issuesConfig.SummaryGroupIndex {
-				return "", errors.New("Unexpected result while parsing issues from git log. Make sure that the regular expression used to find issues, includes two capturing groups, for the issue ID and the summary.")
-			}
-			// Create found Affected Issue.
-			foundIssue := buildinfo.AffectedIssue{Key: pattern.MatchedResults[issuesConfig.KeyGroupIndex], Summary: pattern.MatchedResults[issuesConfig.SummaryGroupIndex], Aggregated: false}
-			if issuesConfig.TrackerUrl != "" {
-				foundIssue.Url = issuesConfig.TrackerUrl + pattern.MatchedResults[issuesConfig.KeyGroupIndex]
-			}
-			foundIssues = append(foundIssues, foundIssue)
-			log.Debug("Found issue: " + pattern.MatchedResults[issuesConfig.KeyGroupIndex])
-			return "", nil
-		},
-	}
-
-	// Change working dir to where .git is.
-	wd, err := os.Getwd()
-	if errorutils.CheckError(err) != nil {
+	repo, err := git.OpenRepository(config.dotGitPath)
+	if err != nil {
 		return nil, err
 	}
-	defer os.Chdir(wd)
-	err = os.Chdir(config.dotGitPath)
-	if errorutils.CheckError(err) != nil {
-		return nil, err
+	commits, err := repo.Log("HEAD", lastVcsRevision, issuesConfig.LogLimit)
+	if err != nil {
+		// May happen when trying to get the log for non-existing revision.
+		return nil, errorutils.CheckError(errors.New("Failed reading git log: " + err.Error()))
 	}
 
-	// Run git command.
-	_, _, exitOk, err := gofrogcmd.RunCmdWithOutputParser(logCmd, false, &logRegExp)
-	if errorutils.CheckError(err) != nil {
-		return nil, err
-	}
-	if !exitOk {
-		// May happen when trying to run git log for non-existing revision.
-		return nil, errorutils.CheckError(errors.New("Failed executing git log command."))
+	// Search each of the commit messages for issues of all trackers.
+	var foundIssues []utils.AffectedIssue
+	for _, commit := range commits {
+		if strings.TrimSpace(commit.Message) == "" {
+			continue
+		}
+		message := parseCommitMessage(commit.Message)
+		for i, tracker := range trackers {
+			issues, err := tracker.findIssues(issueRegexps[i], message)
+			if err != nil {
+				return nil, err
+			}
+			for _, issue := range issues {
+				log.Debug("Found " + issue.Tracker + " issue: " + issue.Key)
+			}
+			foundIssues = append(foundIssues, issues...)
+		}
 	}
 
 	// Return found issues.
@@ -250,7 +359,7 @@ func (config *BuildAddGitCommand) createIssuesConfigs() (err error) {
 	}
 
 	// Add '/' suffix to URL if required.
-	if config.issuesConfig.TrackerUrl != "" {
+	if config.issuesConfig.TrackerUrl != "" && !strings.Contains(config.issuesConfig.TrackerUrl, IssueKeyPlaceholder) {
 		// Url should end with '/'
 		config.issuesConfig.TrackerUrl = clientutils.AddTrailingSlashIfNeeded(config.issuesConfig.TrackerUrl)
 	}
@@ -311,6 +420,17 @@ func (ic *IssuesConfiguration) populateIssuesConfigsFromSpec(configFilePath stri
 	// Set log limit.
 	ic.LogLimit = GitLogLimit
 
+	// Get the aggregation configuration, which is shared by all trackers.
+	err = ic.populateAggregationFromSpec(vConfig)
+	if err != nil {
+		return err
+	}
+
+	// Multiple trackers are configured by the 'issues.trackers' list.
+	if vConfig.IsSet(ConfigIssuesTrackers) {
+		return ic.populateTrackersFromSpec(vConfig)
+	}
+
 	// Get tracker data
 	if !vConfig.IsSet(ConfigIssuesPrefix + "trackerName") {
 		return errorutils.CheckError(errors.New(fmt.Sprintf(MissingConfigurationError, ConfigIssuesPrefix+"trackerName")))
@@ -346,6 +466,10 @@ func (ic *IssuesConfiguration) populateIssuesConfigsFromSpec(configFilePath stri
 		return errorutils.CheckError(errors.New(fmt.Sprintf(ConfigParseValueError, ConfigIssuesPrefix+"summaryGroupIndex", err.Error())))
 	}
 
+	return nil
+}
+
+func (ic *IssuesConfiguration) populateAggregationFromSpec(vConfig *viper.Viper) (err error) {
 	// Get aggregation aggregate
 	ic.Aggregate = false
 	if vConfig.IsSet(ConfigIssuesPrefix + "aggregate") {
@@ -363,6 +487,22 @@ func (ic *IssuesConfiguration) populateIssuesConfigsFromSpec(configFilePath stri
 	return nil
 }
 
+func (ic *IssuesConfiguration) populateTrackersFromSpec(vConfig *viper.Viper) error {
+	trackersConfig, ok := vConfig.Get(ConfigIssuesTrackers).([]interface{})
+	if !ok || len(trackersConfig) == 0 {
+		return errorutils.CheckError(errors.New(fmt.Sprintf(ConfigParseValueError, ConfigIssuesTrackers, "expected a non-empty list of trackers")))
+	}
+	ic.Trackers = nil
+	for i, trackerConfig := range trackersConfig {
+		tracker, err := parseIssueTracker(i, trackerConfig)
+		if err != nil {
+			return err
+		}
+		ic.Trackers = append(ic.Trackers, *tracker)
+	}
+	return nil
+}
+
 func (ic *IssuesConfiguration) setArtifactoryDetails() error {
 	// If no server-id provided, use default server.
 	artDetails, err := utilsconfig.GetArtifactorySpecificConfig(ic.ServerID, true, false)
@@ -384,31 +524,30 @@ type IssuesConfiguration struct {
 	Aggregate         bool
 	AggregationStatus string
 	ServerID          string
+	// Issue trackers configured by the 'issues.trackers' list.
+	// If empty, the single tracker configured by the fields above is used.
+	Trackers []IssueTracker
 }
 
-type LogCmd struct {
-	logLimit        int
-	lastVcsRevision string
+// Returns the issue trackers to collect issues for.
+func (ic *IssuesConfiguration) GetTrackers() []IssueTracker {
+	if len(ic.Trackers) > 0 {
+		return ic.Trackers
+	}
+	return []IssueTracker{{
+		Name:              ic.TrackerName,
+		Regexp:            ic.Regexp,
+		KeyGroupIndex:     ic.KeyGroupIndex,
+		SummaryGroupIndex: ic.SummaryGroupIndex,
+		Url:               ic.TrackerUrl,
+	}}
 }
 
-func (logCmd *LogCmd) GetCmd() *exec.Cmd {
-	var cmd []string
-	cmd = append(cmd, "git")
-	cmd = append(cmd, "log", "--pretty=format:%s", "-"+strconv.Itoa(logCmd.logLimit))
-	if logCmd.lastVcsRevision != "" {
-		cmd = append(cmd, logCmd.lastVcsRevision+"..")
+// Returns the names of the issue trackers, separated by commas.
+func (ic *IssuesConfiguration) GetTrackersNames() string {
+	var names []string
+	for _, tracker := range ic.GetTrackers() {
+		names = append(names, tracker.Name)
 	}
-	return exec.Command(cmd[0], cmd[1:]...)
-}
-
-func (logCmd *LogCmd) GetEnv() map[string]string {
-	return map[string]string{}
-}
-
-func (logCmd *LogCmd) GetStdWriter() io.WriteCloser {
-	return nil
-}
-
-func (logCmd *LogCmd) GetErrWriter() io.WriteCloser {
-	return nil
+	return strings.Join(names, ",")
 }
@@ -7,8 +7,12 @@ import (
 	"github.com/jfrog/jfrog-cli/utils/log"
 	"github.com/jfrog/jfrog-cli/utils/tests"
 	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -52,7 +56,7 @@ func checkFailureAndClean(t *testing.T, buildDir string, oldPath string) {
 	}
 }
 
-func getBuildInfoPartials(baseDir string, t *testing.T, buildName string, buildNumber string) buildinfo.Partials {
+func getBuildInfoPartials(baseDir string, t *testing.T, buildName string, buildNumber string) utils.Partials {
 	buildAddGitConfiguration := new(BuildAddGitCommand).SetDotGitPath(baseDir).SetBuildConfiguration(&utils.BuildConfiguration{BuildName: buildName, BuildNumber: buildNumber})
 	err := buildAddGitConfiguration.Run()
 	if err != nil {
@@ -76,7 +80,7 @@ func getBuildDir(t *testing.T) string {
 	return buildDir
 }
 
-func checkVCSUrl(partials buildinfo.Partials, t *testing.T) {
+func checkVCSUrl(partials utils.Partials, t *testing.T) {
 	for _, partial := range partials {
 		if partial.Vcs != nil {
 			url := partial.Vcs.Url
@@ -113,7 +117,7 @@ func TestPopulateIssuesConfigurations(t *testing.T) {
 		t.Error(fmt.Sprintf("Reading configurations file ended with error: %s", err.Error()))
 		t.FailNow()
 	}
-	if *ic != *expectedIssuesConfiguration {
+	if !reflect.DeepEqual(ic, expectedIssuesConfiguration) {
 		t.Error(fmt.Sprintf("Failed reading configurations file. Expected: %+v Received: %+v", *expectedIssuesConfiguration, *ic))
 		t.FailNow()
 	}
@@ -255,3 +259,89 @@ func TestRtDetailsWithoutConfigFile(t *testing.T) {
 		t.Error(fmt.Sprintf("Expected %s, got %s", details.User, expectedUser))
 	}
 }
+
+func TestAddGitDoCollectPackedRepository(t *testing.T) {
+	config := BuildAddGitCommand{dotGitPath: filepath.Join("..", "..", "..", "testsdata", "packedvcs", "gitdata")}
+	issuesConfig := &IssuesConfiguration{
+		LogLimit:          100,
+		SummaryGroupIndex: 2,
+		KeyGroupIndex:     1,
+		Regexp:            `(.+-[0-9]+)\s-\s(.+)`,
+		TrackerName:       "test",
+	}
+
+	issues, err := config.DoCollect(issuesConfig, "")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(issues) != 5 {
+		t.Errorf("Issues list expected to have 5 issues, instead found %d issues: %v", len(issues), issues)
+	}
+
+	// Only the issues of the commits after the provided revision should be collected.
+	issues, err = config.DoCollect(issuesConfig, "c9cef11a98be34e309f0f43ae3014a06d29082aa")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(issues) != 3 {
+		t.Errorf("Issues list expected to have 3 issues, instead found %d issues: %v", len(issues), issues)
+	}
+}
+
+// The VCS details of the repository created by createRepositoryWithSubmodule, its submodule and the packed repository.
+var expectedVcsListWithSubmodules = []utils.Vcs{
+	{Url: "https://github.com/jfrog/jfrog-cli.git", Revision: "d63c5957ad6819f4c02a817abe757f210d35ff92", Branch: "master", Message: "main"},
+	{Url: "https://github.com/jfrog/jfrog-client-go.git", Revision: "ad99b6c068283878fde4d49423728f0bdc00544a", Branch: "InnerGit", Message: "inner"},
+	{Url: "https://github.com/jfrog/jfrog-cli.git", Revision: "81b5274b4549697168ffcc315d5e8b957c52bae1", Branch: "master", Message: "JIRA-5 - Last change"},
+}
+
+// Creates a repository with the OtherGit submodule, from the testsdata/vcs repositories.
+func createRepositoryWithSubmodule(t *testing.T) string {
+	tempDir, err := ioutil.TempDir("", "vcs")
+	assert.NoError(t, err)
+	assert.NoError(t, fileutils.CopyDir(filepath.Join(testsdataPath(), "vcs"), tempDir, true, nil))
+	tests.RenamePath(filepath.Join(tempDir, "gitdata"), filepath.Join(tempDir, ".git"), t)
+	tests.RenamePath(filepath.Join(tempDir, "OtherGit", "gitdata"), filepath.Join(tempDir, "OtherGit", ".git"), t)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(tempDir, ".gitmodules"), []byte("[submodule \"OtherGit\"]\n\tpath = OtherGit\n\turl = https://github.com/jfrog/jfrog-client-go.git\n"), 0644))
+	return tempDir
+}
+
+func testsdataPath() string {
+	return filepath.Join("..", "..", "..", "testsdata")
+}
+
+func TestCollectVcsDetailsWithSubmodules(t *testing.T) {
+	tempDir := createRepositoryWithSubmodule(t)
+	defer os.RemoveAll(tempDir)
+
+	// The packed repository is added twice, to verify it is collected once.
+	packedRepoPath := filepath.Join(testsdataPath(), "packedvcs", "gitdata")
+	config := NewBuildAddGitCommand().SetDotGitPath(tempDir).SetDotGitPaths([]string{packedRepoPath, packedRepoPath})
+	vcsList, err := config.collectVcsDetails()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedVcsListWithSubmodules, vcsList)
+}
+
+func TestAddGitWithDotGitPathsFromWorkingDirectory(t *testing.T) {
+	tempDir := createRepositoryWithSubmodule(t)
+	defer os.RemoveAll(tempDir)
+	packedRepoPath, err := filepath.Abs(filepath.Join(testsdataPath(), "packedvcs", "gitdata"))
+	assert.NoError(t, err)
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(tempDir))
+	defer os.Chdir(wd)
+
+	// Without a positional path, the main repository is the one of the working directory, and the --dot-git-path repositories are added after it.
+	buildConfiguration := &utils.BuildConfiguration{BuildName: "TestAddGitWithDotGitPaths", BuildNumber: "1"}
+	defer utils.RemoveBuildDir(buildConfiguration.BuildName, buildConfiguration.BuildNumber)
+	config := NewBuildAddGitCommand().SetBuildConfiguration(buildConfiguration).SetDotGitPaths([]string{packedRepoPath})
+	assert.NoError(t, config.Run())
+
+	partials, err := utils.ReadPartialBuildInfoFiles(buildConfiguration.BuildName, buildConfiguration.BuildNumber)
+	assert.NoError(t, err)
+	if assert.Len(t, partials, 1) {
+		assert.Equal(t, &buildinfo.Vcs{Url: expectedVcsListWithSubmodules[0].Url, Revision: expectedVcsListWithSubmodules[0].Revision}, partials[0].Vcs)
+		assert.Equal(t, expectedVcsListWithSubmodules, partials[0].VcsList)
+	}
+}
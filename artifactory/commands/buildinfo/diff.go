@@ -0,0 +1,380 @@
+package buildinfo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jfrog/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	DiffTableFormat = "table"
+	DiffJsonFormat  = "json"
+)
+
+type BuildDiffCommand struct {
+	buildName         string
+	baseBuildNumber   string
+	targetBuildNumber string
+	local             bool
+	format            string
+	rtDetails         *config.ArtifactoryDetails
+	config            *buildinfo.Configuration
+	result            *BuildDiff
+}
+
+func NewBuildDiffCommand() *BuildDiffCommand {
+	return &BuildDiffCommand{format: DiffTableFormat}
+}
+
+func (bdc *BuildDiffCommand) SetBuildName(buildName string) *BuildDiffCommand {
+	bdc.buildName = buildName
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) SetBaseBuildNumber(baseBuildNumber string) *BuildDiffCommand {
+	bdc.baseBuildNumber = baseBuildNumber
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) SetTargetBuildNumber(targetBuildNumber string) *BuildDiffCommand {
+	bdc.targetBuildNumber = targetBuildNumber
+	return bdc
+}
+
+// If set to true, the target build-info is assembled from the local partials rather than fetched from Artifactory.
+func (bdc *BuildDiffCommand) SetLocal(local bool) *BuildDiffCommand {
+	bdc.local = local
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) SetFormat(format string) *BuildDiffCommand {
+	bdc.format = format
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) SetRtDetails(rtDetails *config.ArtifactoryDetails) *BuildDiffCommand {
+	bdc.rtDetails = rtDetails
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) SetConfig(config *buildinfo.Configuration) *BuildDiffCommand {
+	bdc.config = config
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) Result() *BuildDiff {
+	return bdc.result
+}
+
+func (bdc *BuildDiffCommand) CommandName() string {
+	return "rt_build_diff"
+}
+
+func (bdc *BuildDiffCommand) RtDetails() (*config.ArtifactoryDetails, error) {
+	return bdc.rtDetails, nil
+}
+
+func (bdc *BuildDiffCommand) Run() error {
+	if bdc.format != DiffTableFormat && bdc.format != DiffJsonFormat {
+		return errorutils.CheckError(errors.New("the --format option accepts one of: " + DiffTableFormat + ", " + DiffJsonFormat))
+	}
+	servicesManager, err := utils.CreateServiceManager(bdc.rtDetails, false)
+	if err != nil {
+		return err
+	}
+	baseBuildInfo, err := getPublishedBuildInfo(servicesManager, bdc.buildName, bdc.baseBuildNumber)
+	if err != nil {
+		return err
+	}
+
+	var targetBuildInfo *buildinfo.BuildInfo
+	if bdc.local {
+		log.Info("Reading the local build-info of", bdc.buildName+"/"+bdc.targetBuildNumber+"...")
+		buildConfiguration := &utils.BuildConfiguration{BuildName: bdc.buildName, BuildNumber: bdc.targetBuildNumber}
+		targetBuildInfo, err = NewBuildPublishCommand().SetRtDetails(bdc.rtDetails).SetBuildConfiguration(buildConfiguration).SetConfig(bdc.config).createBuildInfo()
+	} else {
+		targetBuildInfo, err = getPublishedBuildInfo(servicesManager, bdc.buildName, bdc.targetBuildNumber)
+	}
+	if err != nil {
+		return err
+	}
+
+	bdc.result = DiffBuildInfo(baseBuildInfo, targetBuildInfo)
+	if bdc.format == DiffJsonFormat {
+		content, err := json.Marshal(bdc.result)
+		if errorutils.CheckError(err) != nil {
+			return err
+		}
+		log.Output(clientutils.IndentJson(content))
+		return nil
+	}
+	log.Output(bdc.result.Table())
+	return nil
+}
+
+// Fetches the build-info of a published build.
+// The client returns an empty build-info when the build doesn't exist, so that case is reported as an error here.
+func getPublishedBuildInfo(servicesManager *artifactory.ArtifactoryServicesManager, buildName, buildNumber string) (*buildinfo.BuildInfo, error) {
+	log.Info("Fetching build-info", buildName+"/"+buildNumber, "from Artifactory...")
+	buildInfo, err := servicesManager.GetBuildInfo(services.BuildInfoParams{BuildName: buildName, BuildNumber: buildNumber})
+	if err != nil {
+		return nil, err
+	}
+	if buildInfo.Name == "" || buildInfo.Number == "" {
+		return nil, errorutils.CheckError(errors.New("build " + buildName + "/" + buildNumber + " was not found in Artifactory"))
+	}
+	return buildInfo, nil
+}
+
+// The differences between two build-info instances.
+type BuildDiff struct {
+	BaseBuild    string         `json:"baseBuild"`
+	TargetBuild  string         `json:"targetBuild"`
+	Artifacts    EntriesDiff    `json:"artifacts"`
+	Dependencies EntriesDiff    `json:"dependencies"`
+	Properties   PropertiesDiff `json:"properties"`
+	Vcs          *VcsDiff       `json:"vcs,omitempty"`
+}
+
+type EntriesDiff struct {
+	Added   []DiffEntry        `json:"added,omitempty"`
+	Removed []DiffEntry        `json:"removed,omitempty"`
+	Changed []ChangedDiffEntry `json:"changed,omitempty"`
+}
+
+type DiffEntry struct {
+	Module string `json:"module"`
+	Name   string `json:"name"`
+	Sha1   string `json:"sha1,omitempty"`
+	Md5    string `json:"md5,omitempty"`
+}
+
+type ChangedDiffEntry struct {
+	Module     string `json:"module"`
+	Name       string `json:"name"`
+	BaseSha1   string `json:"baseSha1,omitempty"`
+	TargetSha1 string `json:"targetSha1,omitempty"`
+}
+
+type PropertiesDiff struct {
+	Added   map[string]string `json:"added,omitempty"`
+	Removed map[string]string `json:"removed,omitempty"`
+	Changed []ChangedProperty `json:"changed,omitempty"`
+}
+
+type ChangedProperty struct {
+	Key         string `json:"key"`
+	BaseValue   string `json:"baseValue"`
+	TargetValue string `json:"targetValue"`
+}
+
+type VcsDiff struct {
+	BaseUrl        string `json:"baseUrl,omitempty"`
+	TargetUrl      string `json:"targetUrl,omitempty"`
+	BaseRevision   string `json:"baseRevision,omitempty"`
+	TargetRevision string `json:"targetRevision,omitempty"`
+}
+
+func (diff *BuildDiff) IsEmpty() bool {
+	return diff.Artifacts.isEmpty() && diff.Dependencies.isEmpty() && diff.Properties.isEmpty() && diff.Vcs == nil
+}
+
+func (diff *EntriesDiff) isEmpty() bool {
+	return len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0
+}
+
+func (diff *PropertiesDiff) isEmpty() bool {
+	return len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0
+}
+
+// Compares the base build-info with the target build-info.
+// Artifacts are matched by module and name and dependencies by module and id. A matched entry is reported as changed if its sha1 differs.
+func DiffBuildInfo(base, target *buildinfo.BuildInfo) *BuildDiff {
+	diff := &BuildDiff{
+		BaseBuild:   base.Name + "/" + base.Number,
+		TargetBuild: target.Name + "/" + target.Number,
+	}
+	diff.Artifacts = diffEntries(artifactsToDiffEntries(base.Modules), artifactsToDiffEntries(target.Modules))
+	diff.Dependencies = diffEntries(dependenciesToDiffEntries(base.Modules), dependenciesToDiffEntries(target.Modules))
+	diff.Properties = diffProperties(base.Properties, target.Properties)
+	diff.Vcs = diffVcs(base.Vcs, target.Vcs)
+	return diff
+}
+
+func artifactsToDiffEntries(modules []buildinfo.Module) map[string]DiffEntry {
+	entries := make(map[string]DiffEntry)
+	for _, module := range modules {
+		for _, artifact := range module.Artifacts {
+			entry := DiffEntry{Module: module.Id, Name: artifact.Name}
+			if artifact.Checksum != nil {
+				entry.Sha1, entry.Md5 = artifact.Sha1, artifact.Md5
+			}
+			entries[module.Id+"/"+artifact.Name] = entry
+		}
+	}
+	return entries
+}
+
+func dependenciesToDiffEntries(modules []buildinfo.Module) map[string]DiffEntry {
+	entries := make(map[string]DiffEntry)
+	for _, module := range modules {
+		for _, dependency := range module.Dependencies {
+			entry := DiffEntry{Module: module.Id, Name: dependency.Id}
+			if dependency.Checksum != nil {
+				entry.Sha1, entry.Md5 = dependency.Sha1, dependency.Md5
+			}
+			entries[module.Id+"/"+dependency.Id] = entry
+		}
+	}
+	return entries
+}
+
+func diffEntries(base, target map[string]DiffEntry) EntriesDiff {
+	var diff EntriesDiff
+	for key, targetEntry := range target {
+		baseEntry, exists := base[key]
+		if !exists {
+			diff.Added = append(diff.Added, targetEntry)
+			continue
+		}
+		if baseEntry.Sha1 != targetEntry.Sha1 {
+			diff.Changed = append(diff.Changed, ChangedDiffEntry{Module: targetEntry.Module, Name: targetEntry.Name, BaseSha1: baseEntry.Sha1, TargetSha1: targetEntry.Sha1})
+		}
+	}
+	for key, baseEntry := range base {
+		if _, exists := target[key]; !exists {
+			diff.Removed = append(diff.Removed, baseEntry)
+		}
+	}
+	sortDiffEntries(diff.Added)
+	sortDiffEntries(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		return diff.Changed[i].Module+"/"+diff.Changed[i].Name < diff.Changed[j].Module+"/"+diff.Changed[j].Name
+	})
+	return diff
+}
+
+func sortDiffEntries(entries []DiffEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Module+"/"+entries[i].Name < entries[j].Module+"/"+entries[j].Name
+	})
+}
+
+func diffProperties(base, target buildinfo.Env) PropertiesDiff {
+	diff := PropertiesDiff{Added: map[string]string{}, Removed: map[string]string{}}
+	for key, targetValue := range target {
+		baseValue, exists := base[key]
+		if !exists {
+			diff.Added[key] = targetValue
+			continue
+		}
+		if baseValue != targetValue {
+			diff.Changed = append(diff.Changed, ChangedProperty{Key: key, BaseValue: baseValue, TargetValue: targetValue})
+		}
+	}
+	for key, baseValue := range base {
+		if _, exists := target[key]; !exists {
+			diff.Removed[key] = baseValue
+		}
+	}
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		return diff.Changed[i].Key < diff.Changed[j].Key
+	})
+	return diff
+}
+
+// Returns nil if both VCS details are identical.
+func diffVcs(base, target *buildinfo.Vcs) *VcsDiff {
+	if base == nil {
+		base = &buildinfo.Vcs{}
+	}
+	if target == nil {
+		target = &buildinfo.Vcs{}
+	}
+	if *base == *target {
+		return nil
+	}
+	return &VcsDiff{BaseUrl: base.Url, TargetUrl: target.Url, BaseRevision: base.Revision, TargetRevision: target.Revision}
+}
+
+// Returns the diff as human readable tables.
+func (diff *BuildDiff) Table() string {
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf("Comparing build %s with build %s.\n", diff.BaseBuild, diff.TargetBuild))
+	if diff.IsEmpty() {
+		buffer.WriteString("No differences found.\n")
+		return buffer.String()
+	}
+	writeEntriesTable(&buffer, "Artifacts", diff.Artifacts)
+	writeEntriesTable(&buffer, "Dependencies", diff.Dependencies)
+	writePropertiesTable(&buffer, diff.Properties)
+	if diff.Vcs != nil {
+		buffer.WriteString("\nVCS:\n")
+		writer := tabwriter.NewWriter(&buffer, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "\tBASE\tTARGET")
+		fmt.Fprintf(writer, "URL\t%s\t%s\n", diff.Vcs.BaseUrl, diff.Vcs.TargetUrl)
+		fmt.Fprintf(writer, "REVISION\t%s\t%s\n", diff.Vcs.BaseRevision, diff.Vcs.TargetRevision)
+		writer.Flush()
+	}
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+func writeEntriesTable(buffer *bytes.Buffer, title string, diff EntriesDiff) {
+	if diff.isEmpty() {
+		return
+	}
+	buffer.WriteString("\n" + title + ":\n")
+	writer := tabwriter.NewWriter(buffer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "STATUS\tMODULE\tNAME\tBASE SHA1\tTARGET SHA1")
+	for _, entry := range diff.Added {
+		fmt.Fprintf(writer, "added\t%s\t%s\t\t%s\n", entry.Module, entry.Name, entry.Sha1)
+	}
+	for _, entry := range diff.Removed {
+		fmt.Fprintf(writer, "removed\t%s\t%s\t%s\t\n", entry.Module, entry.Name, entry.Sha1)
+	}
+	for _, entry := range diff.Changed {
+		fmt.Fprintf(writer, "changed\t%s\t%s\t%s\t%s\n", entry.Module, entry.Name, entry.BaseSha1, entry.TargetSha1)
+	}
+	writer.Flush()
+}
+
+func writePropertiesTable(buffer *bytes.Buffer, diff PropertiesDiff) {
+	if diff.isEmpty() {
+		return
+	}
+	buffer.WriteString("\nProperties:\n")
+	writer := tabwriter.NewWriter(buffer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "STATUS\tKEY\tBASE VALUE\tTARGET VALUE")
+	for _, key := range sortedKeys(diff.Added) {
+		fmt.Fprintf(writer, "added\t%s\t\t%s\n", key, diff.Added[key])
+	}
+	for _, key := range sortedKeys(diff.Removed) {
+		fmt.Fprintf(writer, "removed\t%s\t%s\t\n", key, diff.Removed[key])
+	}
+	for _, property := range diff.Changed {
+		fmt.Fprintf(writer, "changed\t%s\t%s\t%s\n", property.Key, property.BaseValue, property.TargetValue)
+	}
+	writer.Flush()
+}
+
+func sortedKeys(properties map[string]string) []string {
+	var keys []string
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,98 @@
+package buildinfo
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/log"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffBuildInfo(t *testing.T) {
+	base := &buildinfo.BuildInfo{
+		Name:   "diff-build",
+		Number: "1",
+		Modules: []buildinfo.Module{{
+			Id: "module",
+			Artifacts: []buildinfo.Artifact{
+				{Name: "a.jar", Checksum: &buildinfo.Checksum{Sha1: "111"}},
+				{Name: "b.jar", Checksum: &buildinfo.Checksum{Sha1: "222"}},
+			},
+			Dependencies: []buildinfo.Dependency{
+				{Id: "dep:1.0", Checksum: &buildinfo.Checksum{Sha1: "333"}},
+			},
+		}},
+		Properties: buildinfo.Env{"buildInfo.env.A": "a", "buildInfo.env.B": "b"},
+		Vcs:        &buildinfo.Vcs{Url: "https://github.com/jfrog/jfrog-cli.git", Revision: "abc"},
+	}
+	target := &buildinfo.BuildInfo{
+		Name:   "diff-build",
+		Number: "2",
+		Modules: []buildinfo.Module{{
+			Id: "module",
+			Artifacts: []buildinfo.Artifact{
+				{Name: "a.jar", Checksum: &buildinfo.Checksum{Sha1: "111"}},
+				{Name: "c.jar", Checksum: &buildinfo.Checksum{Sha1: "444"}},
+			},
+			Dependencies: []buildinfo.Dependency{
+				{Id: "dep:1.0", Checksum: &buildinfo.Checksum{Sha1: "555"}},
+			},
+		}},
+		Properties: buildinfo.Env{"buildInfo.env.A": "a2", "buildInfo.env.C": "c"},
+		Vcs:        &buildinfo.Vcs{Url: "https://github.com/jfrog/jfrog-cli.git", Revision: "def"},
+	}
+
+	diff := DiffBuildInfo(base, target)
+	assert.Equal(t, "diff-build/1", diff.BaseBuild)
+	assert.Equal(t, "diff-build/2", diff.TargetBuild)
+	assert.Equal(t, []DiffEntry{{Module: "module", Name: "c.jar", Sha1: "444"}}, diff.Artifacts.Added)
+	assert.Equal(t, []DiffEntry{{Module: "module", Name: "b.jar", Sha1: "222"}}, diff.Artifacts.Removed)
+	assert.Empty(t, diff.Artifacts.Changed)
+	assert.Empty(t, diff.Dependencies.Added)
+	assert.Empty(t, diff.Dependencies.Removed)
+	assert.Equal(t, []ChangedDiffEntry{{Module: "module", Name: "dep:1.0", BaseSha1: "333", TargetSha1: "555"}}, diff.Dependencies.Changed)
+	assert.Equal(t, map[string]string{"buildInfo.env.C": "c"}, diff.Properties.Added)
+	assert.Equal(t, map[string]string{"buildInfo.env.B": "b"}, diff.Properties.Removed)
+	assert.Equal(t, []ChangedProperty{{Key: "buildInfo.env.A", BaseValue: "a", TargetValue: "a2"}}, diff.Properties.Changed)
+	assert.Equal(t, &VcsDiff{BaseUrl: "https://github.com/jfrog/jfrog-cli.git", TargetUrl: "https://github.com/jfrog/jfrog-cli.git", BaseRevision: "abc", TargetRevision: "def"}, diff.Vcs)
+	assert.False(t, diff.IsEmpty())
+}
+
+func TestDiffIdenticalBuildInfo(t *testing.T) {
+	build := &buildinfo.BuildInfo{
+		Name:   "diff-build",
+		Number: "1",
+		Modules: []buildinfo.Module{{
+			Id:        "module",
+			Artifacts: []buildinfo.Artifact{{Name: "a.jar", Checksum: &buildinfo.Checksum{Sha1: "111"}}},
+		}},
+		Properties: buildinfo.Env{"buildInfo.env.A": "a"},
+	}
+	diff := DiffBuildInfo(build, build)
+	assert.True(t, diff.IsEmpty())
+	assert.Contains(t, diff.Table(), "No differences found.")
+}
+
+func TestDiffMissingBuild(t *testing.T) {
+	log.SetDefaultLogger()
+	// Artifactory responds with an error body for builds which don't exist, which the client reads as an empty build-info.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/build/diff-build/1" {
+			fmt.Fprint(w, `{"buildInfo":{"name":"diff-build","number":"1"}}`)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors":[{"status":404,"message":"No build was found"}]}`)
+	}))
+	defer server.Close()
+
+	diffCommand := NewBuildDiffCommand().SetBuildName("diff-build").SetBaseBuildNumber("1").SetTargetBuildNumber("2").
+		SetFormat(DiffJsonFormat).SetRtDetails(&config.ArtifactoryDetails{Url: server.URL + "/"})
+	err := diffCommand.Run()
+	assert.EqualError(t, err, "build diff-build/2 was not found in Artifactory")
+	assert.Nil(t, diffCommand.Result())
+}
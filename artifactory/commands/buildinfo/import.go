@@ -0,0 +1,80 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/jfrog/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type BuildImportCommand struct {
+	rtDetails *config.ArtifactoryDetails
+	filePath  string
+	dryRun    bool
+}
+
+func NewBuildImportCommand() *BuildImportCommand {
+	return &BuildImportCommand{}
+}
+
+func (bic *BuildImportCommand) SetRtDetails(rtDetails *config.ArtifactoryDetails) *BuildImportCommand {
+	bic.rtDetails = rtDetails
+	return bic
+}
+
+// Path to a build-info file, created by the build-publish command with the --output-file option.
+func (bic *BuildImportCommand) SetFilePath(filePath string) *BuildImportCommand {
+	bic.filePath = filePath
+	return bic
+}
+
+func (bic *BuildImportCommand) SetDryRun(dryRun bool) *BuildImportCommand {
+	bic.dryRun = dryRun
+	return bic
+}
+
+func (bic *BuildImportCommand) CommandName() string {
+	return "rt_build_import"
+}
+
+func (bic *BuildImportCommand) RtDetails() (*config.ArtifactoryDetails, error) {
+	return bic.rtDetails, nil
+}
+
+func (bic *BuildImportCommand) Run() error {
+	buildInfo, err := ReadBuildInfoFile(bic.filePath)
+	if err != nil {
+		return err
+	}
+	if buildInfo.ArtifactoryPrincipal == "" {
+		buildInfo.ArtifactoryPrincipal = bic.rtDetails.User
+	}
+	servicesManager, err := utils.CreateServiceManager(bic.rtDetails, bic.dryRun)
+	if err != nil {
+		return err
+	}
+	log.Info("Importing build info of", buildInfo.Name+"/"+buildInfo.Number, "from", bic.filePath+"...")
+	return servicesManager.PublishBuildInfo(buildInfo)
+}
+
+// Reads a build-info file and validates that it includes the build name and number.
+func ReadBuildInfoFile(filePath string) (*buildinfo.BuildInfo, error) {
+	content, err := fileutils.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	buildInfo := new(buildinfo.BuildInfo)
+	err = json.Unmarshal(content, buildInfo)
+	if err != nil {
+		return nil, errorutils.CheckError(errors.New("Failed parsing the build-info file " + filePath + ": " + err.Error()))
+	}
+	if buildInfo.Name == "" || buildInfo.Number == "" {
+		return nil, errorutils.CheckError(errors.New("The build-info file " + filePath + " must include the build name and build number."))
+	}
+	return buildInfo, nil
+}
@@ -0,0 +1,54 @@
+package buildinfo
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportAndReadBuildInfoFile(t *testing.T) {
+	buildConfiguration := &utils.BuildConfiguration{BuildName: "TestExportBuildInfo", BuildNumber: "1"}
+	defer utils.RemoveBuildDir(buildConfiguration.BuildName, buildConfiguration.BuildNumber)
+	assert.NoError(t, utils.SaveBuildGeneralDetails(buildConfiguration.BuildName, buildConfiguration.BuildNumber))
+	populateFunc := func(partial *buildinfo.Partial) {
+		partial.Artifacts = []buildinfo.Artifact{{Name: "a.zip", Checksum: &buildinfo.Checksum{Sha1: "111", Md5: "222"}}}
+		partial.ModuleId = "module"
+	}
+	assert.NoError(t, utils.SavePartialBuildInfo(buildConfiguration.BuildName, buildConfiguration.BuildNumber, populateFunc))
+
+	tempDir, err := ioutil.TempDir("", "build-export")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	outputFile := filepath.Join(tempDir, "build-info.json")
+	publishCmd := NewBuildPublishCommand().SetBuildConfiguration(buildConfiguration).SetRtDetails(&config.ArtifactoryDetails{User: "admin"}).
+		SetConfig(&buildinfo.Configuration{}).SetOutputFile(outputFile)
+	assert.NoError(t, publishCmd.Run())
+
+	buildInfo, err := ReadBuildInfoFile(outputFile)
+	assert.NoError(t, err)
+	assert.Equal(t, buildConfiguration.BuildName, buildInfo.Name)
+	assert.Equal(t, buildConfiguration.BuildNumber, buildInfo.Number)
+	assert.Equal(t, "admin", buildInfo.ArtifactoryPrincipal)
+	if assert.Len(t, buildInfo.Modules, 1) {
+		assert.Equal(t, "module", buildInfo.Modules[0].Id)
+		assert.Equal(t, "111", buildInfo.Modules[0].Artifacts[0].Sha1)
+	}
+}
+
+func TestReadBuildInfoFileWithoutBuildName(t *testing.T) {
+	tempFile, err := ioutil.TempFile("", "build-info")
+	assert.NoError(t, err)
+	defer os.Remove(tempFile.Name())
+	_, err = tempFile.WriteString(`{"number": "1"}`)
+	assert.NoError(t, err)
+	assert.NoError(t, tempFile.Close())
+
+	_, err = ReadBuildInfoFile(tempFile.Name())
+	assert.Error(t, err)
+}
@@ -0,0 +1,211 @@
+package buildinfo
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	ConfigIssuesTrackers = ConfigIssuesPrefix + "trackers"
+	// Placeholder in a tracker URL, which is replaced by the issue key.
+	IssueKeyPlaceholder = "{key}"
+)
+
+// The parts of a commit message an issue tracker's regexp can be matched against.
+const (
+	MatchOnSubject  = "subject"
+	MatchOnBody     = "body"
+	MatchOnTrailers = "trailers"
+)
+
+// Matches a git trailer line, such as 'Fixes: JIRA-123'.
+var trailerRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*:\s*\S`)
+
+type IssueTracker struct {
+	Name              string
+	Regexp            string
+	KeyGroupIndex     int
+	SummaryGroupIndex int
+	// The issue URL. The {key} placeholder is replaced by the issue key. If the URL has no placeholder, the key is appended to it.
+	Url string
+	// The parts of the commit message to search for issues. Defaults to the subject only.
+	MatchOn []string
+}
+
+func (tracker *IssueTracker) issueUrl(key string) string {
+	if tracker.Url == "" {
+		return ""
+	}
+	if strings.Contains(tracker.Url, IssueKeyPlaceholder) {
+		return strings.Replace(tracker.Url, IssueKeyPlaceholder, key, -1)
+	}
+	return clientutils.AddTrailingSlashIfNeeded(tracker.Url) + key
+}
+
+// Returns the lines of the commit message, which should be searched for issues of this tracker.
+func (tracker *IssueTracker) linesToMatch(message *commitMessage) []string {
+	matchOn := tracker.MatchOn
+	if len(matchOn) == 0 {
+		matchOn = []string{MatchOnSubject}
+	}
+	var lines []string
+	for _, part := range matchOn {
+		switch part {
+		case MatchOnSubject:
+			lines = append(lines, message.subject)
+		case MatchOnBody:
+			lines = append(lines, message.body...)
+		case MatchOnTrailers:
+			lines = append(lines, message.trailers...)
+		}
+	}
+	return lines
+}
+
+// Finds the issues of this tracker in the commit message.
+func (tracker *IssueTracker) findIssues(issueRegexp *regexp.Regexp, message *commitMessage) ([]utils.AffectedIssue, error) {
+	var issues []utils.AffectedIssue
+	for _, line := range tracker.linesToMatch(message) {
+		matchedResults := issueRegexp.FindStringSubmatch(line)
+		if matchedResults == nil {
+			continue
+		}
+		// Check for out of bound results.
+		if len(matchedResults)-1 < tracker.KeyGroupIndex || len(matchedResults)-1 < tracker.SummaryGroupIndex {
+			return nil, errorutils.CheckError(errors.New("Unexpected result while parsing issues of tracker " + tracker.Name + " from git log. Make sure that the regular expression used to find issues, includes two capturing groups, for the issue ID and the summary."))
+		}
+		key := matchedResults[tracker.KeyGroupIndex]
+		issues = append(issues, utils.AffectedIssue{
+			AffectedIssue: buildinfo.AffectedIssue{Key: key, Url: tracker.issueUrl(key), Summary: matchedResults[tracker.SummaryGroupIndex], Aggregated: false},
+			Tracker:       tracker.Name,
+		})
+	}
+	return issues, nil
+}
+
+// Parses a single element of the 'issues.trackers' list in the configuration file.
+func parseIssueTracker(index int, trackerConfig interface{}) (*IssueTracker, error) {
+	prefix := ConfigIssuesTrackers + "[" + strconv.Itoa(index) + "]."
+	// Configuration keys are case insensitive.
+	values := make(map[string]interface{})
+	switch configMap := trackerConfig.(type) {
+	case map[interface{}]interface{}:
+		for key, value := range configMap {
+			values[strings.ToLower(fmt.Sprint(key))] = value
+		}
+	case map[string]interface{}:
+		for key, value := range configMap {
+			values[strings.ToLower(key)] = value
+		}
+	default:
+		return nil, errorutils.CheckError(errors.New(fmt.Sprintf(ConfigParseValueError, ConfigIssuesTrackers+"["+strconv.Itoa(index)+"]", "expected a map of tracker properties")))
+	}
+	getString := func(key string, required bool) (string, error) {
+		value, ok := values[strings.ToLower(key)]
+		if !ok {
+			if required {
+				return "", errorutils.CheckError(errors.New(fmt.Sprintf(MissingConfigurationError, prefix+key)))
+			}
+			return "", nil
+		}
+		return fmt.Sprint(value), nil
+	}
+	getInt := func(key string) (int, error) {
+		value, err := getString(key, true)
+		if err != nil {
+			return 0, err
+		}
+		intValue, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, errorutils.CheckError(errors.New(fmt.Sprintf(ConfigParseValueError, prefix+key, err.Error())))
+		}
+		return intValue, nil
+	}
+
+	var err error
+	tracker := new(IssueTracker)
+	if tracker.Name, err = getString("name", true); err != nil {
+		return nil, err
+	}
+	if tracker.Regexp, err = getString("regexp", true); err != nil {
+		return nil, err
+	}
+	if tracker.Url, err = getString("url", false); err != nil {
+		return nil, err
+	}
+	if tracker.KeyGroupIndex, err = getInt("keyGroupIndex"); err != nil {
+		return nil, err
+	}
+	if tracker.SummaryGroupIndex, err = getInt("summaryGroupIndex"); err != nil {
+		return nil, err
+	}
+	if matchOn, ok := values[strings.ToLower("matchOn")]; ok {
+		parts, ok := matchOn.([]interface{})
+		if !ok {
+			return nil, errorutils.CheckError(errors.New(fmt.Sprintf(ConfigParseValueError, prefix+"matchOn", "expected a list")))
+		}
+		for _, value := range parts {
+			part := fmt.Sprint(value)
+			if part != MatchOnSubject && part != MatchOnBody && part != MatchOnTrailers {
+				return nil, errorutils.CheckError(errors.New(fmt.Sprintf(ConfigParseValueError, prefix+"matchOn",
+					"unknown value '"+part+"'. Supported values are: "+strings.Join([]string{MatchOnSubject, MatchOnBody, MatchOnTrailers}, ", "))))
+			}
+			tracker.MatchOn = append(tracker.MatchOn, part)
+		}
+	}
+	return tracker, nil
+}
+
+type commitMessage struct {
+	subject  string
+	body     []string
+	trailers []string
+}
+
+// Splits a raw commit message into its subject, body lines and trailer lines.
+// Trailers are the lines of the last paragraph of the message, if all of them have the 'Key: value' format.
+func parseCommitMessage(rawMessage string) *commitMessage {
+	lines := strings.Split(strings.TrimSpace(strings.Replace(rawMessage, "\r\n", "\n", -1)), "\n")
+	message := &commitMessage{subject: strings.TrimSpace(lines[0])}
+	rest := lines[1:]
+
+	// Find the last paragraph.
+	lastParagraphStart := 0
+	for i, line := range rest {
+		if strings.TrimSpace(line) == "" {
+			lastParagraphStart = i + 1
+		}
+	}
+	isTrailers := lastParagraphStart < len(rest)
+	for _, line := range rest[lastParagraphStart:] {
+		if !trailerRegexp.MatchString(line) {
+			isTrailers = false
+			break
+		}
+	}
+	if isTrailers {
+		message.trailers = trimLines(rest[lastParagraphStart:])
+		rest = rest[:lastParagraphStart]
+	}
+	message.body = trimLines(rest)
+	return message
+}
+
+// Returns the non-empty lines, trimmed.
+func trimLines(lines []string) []string {
+	var result []string
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); line != "" {
+			result = append(result, line)
+		}
+	}
+	return result
+}
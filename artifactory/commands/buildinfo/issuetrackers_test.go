@@ -0,0 +1,77 @@
+package buildinfo
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPopulateIssueTrackersConfigurations(t *testing.T) {
+	ic := new(IssuesConfiguration)
+	err := ic.populateIssuesConfigsFromSpec(filepath.Join("..", "testdata", "buildissues", "issuesconfig_success_trackers.yaml"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	expectedTrackers := []IssueTracker{
+		{Name: "JIRA", Url: "https://jira.example.com/browse/{key}", Regexp: `([A-Z]+-[0-9]+)\s-\s(.*)`, KeyGroupIndex: 1, SummaryGroupIndex: 2},
+		{Name: "GitHub", Url: "https://github.com/jfrog/jfrog-cli/issues", Regexp: `(?:Fixes|Closes):\s#([0-9]+)\s?(.*)`, KeyGroupIndex: 1, SummaryGroupIndex: 2, MatchOn: []string{MatchOnBody, MatchOnTrailers}},
+	}
+	assert.Equal(t, expectedTrackers, ic.GetTrackers())
+	assert.Equal(t, "JIRA,GitHub", ic.GetTrackersNames())
+	assert.Equal(t, "local", ic.ServerID)
+	assert.True(t, ic.Aggregate)
+	assert.Equal(t, "RELEASE", ic.AggregationStatus)
+
+	failing := []string{
+		filepath.Join("..", "testdata", "buildissues", "issuesconfig_fail_trackers_missing_regexp.yaml"),
+		filepath.Join("..", "testdata", "buildissues", "issuesconfig_fail_trackers_invalid_matchon.yaml"),
+	}
+	for _, config := range failing {
+		assert.Error(t, new(IssuesConfiguration).populateIssuesConfigsFromSpec(config), config)
+	}
+}
+
+func TestParseCommitMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected *commitMessage
+	}{
+		{"subjectOnly", "JIRA-1 - Subject\n", &commitMessage{subject: "JIRA-1 - Subject"}},
+		{"body", "Subject\n\nFirst line\nSecond line\n", &commitMessage{subject: "Subject", body: []string{"First line", "Second line"}}},
+		{"trailers", "Subject\n\nBody line\n\nFixes: #12\nSigned-off-by: Someone <someone@example.com>\n",
+			&commitMessage{subject: "Subject", body: []string{"Body line"}, trailers: []string{"Fixes: #12", "Signed-off-by: Someone <someone@example.com>"}}},
+		{"noTrailers", "Subject\n\nBody line\n\nNot: a trailer\nplain text\n",
+			&commitMessage{subject: "Subject", body: []string{"Body line", "Not: a trailer", "plain text"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, parseCommitMessage(test.message))
+		})
+	}
+}
+
+func TestFindIssuesMultipleTrackers(t *testing.T) {
+	message := parseCommitMessage("JIRA-1 - Fix the upload\n\nAlso fixes the download.\n\nCloses: #42 Download fails\n")
+	ic := &IssuesConfiguration{Trackers: []IssueTracker{
+		{Name: "JIRA", Url: "https://jira.example.com/browse/{key}", Regexp: `([A-Z]+-[0-9]+)\s-\s(.*)`, KeyGroupIndex: 1, SummaryGroupIndex: 2},
+		{Name: "GitHub", Url: "https://github.com/jfrog/jfrog-cli/issues", Regexp: `(?:Fixes|Closes):\s#([0-9]+)\s?(.*)`, KeyGroupIndex: 1, SummaryGroupIndex: 2, MatchOn: []string{MatchOnTrailers}},
+	}}
+	var issues []utils.AffectedIssue
+	for _, tracker := range ic.GetTrackers() {
+		issueRegexp, err := clientutils.GetRegExp(tracker.Regexp)
+		assert.NoError(t, err)
+		trackerIssues, err := tracker.findIssues(issueRegexp, message)
+		assert.NoError(t, err)
+		issues = append(issues, trackerIssues...)
+	}
+	expected := []utils.AffectedIssue{
+		{AffectedIssue: buildinfo.AffectedIssue{Key: "JIRA-1", Url: "https://jira.example.com/browse/JIRA-1", Summary: "Fix the upload"}, Tracker: "JIRA"},
+		{AffectedIssue: buildinfo.AffectedIssue{Key: "42", Url: "https://github.com/jfrog/jfrog-cli/issues/42", Summary: "Download fails"}, Tracker: "GitHub"},
+	}
+	assert.Equal(t, expected, issues)
+}
@@ -1,7 +1,10 @@
 package buildinfo
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"sort"
 
 	"github.com/jfrog/jfrog-cli/artifactory/utils"
@@ -9,12 +12,14 @@ import (
 	"github.com/jfrog/jfrog-cli/utils/config"
 	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
 type BuildPublishCommand struct {
 	buildConfiguration *utils.BuildConfiguration
 	rtDetails          *config.ArtifactoryDetails
 	config             *buildinfo.Configuration
+	outputFile         string
 }
 
 func NewBuildPublishCommand() *BuildPublishCommand {
@@ -36,6 +41,12 @@ func (bpc *BuildPublishCommand) SetBuildConfiguration(buildConfiguration *utils.
 	return bpc
 }
 
+// If set, the build-info is written to this file instead of being published to Artifactory.
+func (bpc *BuildPublishCommand) SetOutputFile(outputFile string) *BuildPublishCommand {
+	bpc.outputFile = outputFile
+	return bpc
+}
+
 func (bpc *BuildPublishCommand) CommandName() string {
 	return "rt_build_publish"
 }
@@ -45,33 +56,73 @@ func (bpc *BuildPublishCommand) RtDetails() (*config.ArtifactoryDetails, error)
 }
 
 func (bpc *BuildPublishCommand) Run() error {
+	if bpc.outputFile != "" {
+		return bpc.exportBuildInfo()
+	}
 	servicesManager, err := utils.CreateServiceManager(bpc.rtDetails, bpc.config.DryRun)
 	if err != nil {
 		return err
 	}
 
-	buildInfo, err := bpc.createBuildInfoFromPartials()
+	buildInfo, err := bpc.createBuildInfo()
 	if err != nil {
 		return err
 	}
 
-	generatedBuildsInfo, err := utils.GetGeneratedBuildsInfo(bpc.buildConfiguration.BuildName, bpc.buildConfiguration.BuildNumber)
-	if err != nil {
+	if err = servicesManager.PublishBuildInfo(buildInfo); err != nil {
 		return err
 	}
 
-	for _, v := range generatedBuildsInfo {
-		buildInfo.Append(v)
+	if !bpc.config.DryRun {
+		return utils.RemoveBuildDir(bpc.buildConfiguration.BuildName, bpc.buildConfiguration.BuildNumber)
 	}
+	return nil
+}
 
-	if err = servicesManager.PublishBuildInfo(buildInfo); err != nil {
+// Writes the build-info to the output file, so that it can be published later using the build-import command.
+func (bpc *BuildPublishCommand) exportBuildInfo() error {
+	buildInfo, err := bpc.createBuildInfo()
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(buildInfo)
+	if errorutils.CheckError(err) != nil {
 		return err
 	}
+	var content bytes.Buffer
+	err = json.Indent(&content, b, "", "  ")
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	if bpc.config.DryRun {
+		log.Info("[Dry run] Logging Build info preview...")
+		log.Output(content.String())
+		return nil
+	}
+	err = ioutil.WriteFile(bpc.outputFile, content.Bytes(), 0644)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	log.Info("Build info successfully exported to " + bpc.outputFile)
+	return utils.RemoveBuildDir(bpc.buildConfiguration.BuildName, bpc.buildConfiguration.BuildNumber)
+}
 
-	if !bpc.config.DryRun {
-		return utils.RemoveBuildDir(bpc.buildConfiguration.BuildName, bpc.buildConfiguration.BuildNumber)
+// Assembles the build-info from the local partials and the generated build-info files of the build.
+func (bpc *BuildPublishCommand) createBuildInfo() (*buildinfo.BuildInfo, error) {
+	buildInfo, err := bpc.createBuildInfoFromPartials()
+	if err != nil {
+		return nil, err
 	}
-	return nil
+
+	generatedBuildsInfo, err := utils.GetGeneratedBuildsInfo(bpc.buildConfiguration.BuildName, bpc.buildConfiguration.BuildNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range generatedBuildsInfo {
+		buildInfo.Append(v)
+	}
+	return buildInfo, nil
 }
 
 func (bpc *BuildPublishCommand) createBuildInfoFromPartials() (*buildinfo.BuildInfo, error) {
@@ -99,6 +150,10 @@ func (bpc *BuildPublishCommand) createBuildInfoFromPartials() (*buildinfo.BuildI
 	if err != nil {
 		return nil, err
 	}
+	if vcsList := extractVcsList(partials); len(vcsList) > 1 {
+		// The build-info of jfrog-client-go holds the VCS details of a single repository.
+		log.Info("The build-info includes the VCS details of the main repository only. The details of", len(vcsList)-1, "additional repositories collected by build-add-git are not published.")
+	}
 	if len(env) != 0 {
 		buildInfo.Properties = env
 	}
@@ -110,7 +165,7 @@ func (bpc *BuildPublishCommand) createBuildInfoFromPartials() (*buildinfo.BuildI
 	}
 	// Check for Tracker as it must be set
 	if issues.Tracker != nil && issues.Tracker.Name != "" {
-		buildInfo.Issues = &issues
+		buildInfo.Issues = toBuildInfoIssues(issues)
 	}
 	for _, module := range modules {
 		if module.Id == "" {
@@ -121,12 +176,12 @@ func (bpc *BuildPublishCommand) createBuildInfoFromPartials() (*buildinfo.BuildI
 	return buildInfo, nil
 }
 
-func extractBuildInfoData(partials buildinfo.Partials, includeFilter, excludeFilter buildinfo.Filter) ([]buildinfo.Module, buildinfo.Env, buildinfo.Vcs, buildinfo.Issues, error) {
+func extractBuildInfoData(partials utils.Partials, includeFilter, excludeFilter buildinfo.Filter) ([]buildinfo.Module, buildinfo.Env, buildinfo.Vcs, utils.Issues, error) {
 	var vcs buildinfo.Vcs
-	var issues buildinfo.Issues
+	var issues utils.Issues
 	env := make(map[string]string)
 	partialModules := make(map[string]partialModule)
-	issuesMap := make(map[string]*buildinfo.AffectedIssue)
+	issuesMap := make(map[string]*utils.AffectedIssue)
 	for _, partial := range partials {
 		switch {
 		case partial.Artifacts != nil:
@@ -149,7 +204,7 @@ func extractBuildInfoData(partials buildinfo.Partials, includeFilter, excludeFil
 			// If affected issues exist, add them to issues map
 			if partial.Issues.AffectedIssues != nil {
 				for i, issue := range partial.Issues.AffectedIssues {
-					issuesMap[issue.Key] = &partial.Issues.AffectedIssues[i]
+					issuesMap[issue.Tracker+"/"+issue.Key] = &partial.Issues.AffectedIssues[i]
 				}
 			}
 		case partial.Env != nil:
@@ -169,6 +224,44 @@ func extractBuildInfoData(partials buildinfo.Partials, includeFilter, excludeFil
 	return partialModulesToModules(partialModules), env, vcs, issuesMapToArray(issues, issuesMap), nil
 }
 
+// Collects the VCS details of all partials, without duplicates.
+func extractVcsList(partials utils.Partials) []utils.Vcs {
+	var vcsList []utils.Vcs
+	collected := make(map[string]bool)
+	for _, partial := range partials {
+		partialVcsList := partial.VcsList
+		if len(partialVcsList) == 0 && partial.Vcs != nil {
+			partialVcsList = []utils.Vcs{{Url: partial.Vcs.Url, Revision: partial.Vcs.Revision}}
+		}
+		for _, vcs := range partialVcsList {
+			if collected[vcs.Url+"@"+vcs.Revision] {
+				continue
+			}
+			collected[vcs.Url+"@"+vcs.Revision] = true
+			vcsList = append(vcsList, vcs)
+		}
+	}
+	return vcsList
+}
+
+// The build-info supports a single issue tracker, so the affected issues of all the trackers are listed under the tracker names.
+// When there are several trackers, the key of each issue is prefixed with the name of its tracker, as in JIRA/PROJ-1.
+func toBuildInfoIssues(issues utils.Issues) *buildinfo.Issues {
+	buildInfoIssues := &buildinfo.Issues{
+		Tracker:                issues.Tracker,
+		AggregateBuildIssues:   issues.AggregateBuildIssues,
+		AggregationBuildStatus: issues.AggregationBuildStatus,
+	}
+	for _, issue := range issues.AffectedIssues {
+		buildInfoIssue := issue.AffectedIssue
+		if issue.Tracker != "" && issue.Tracker != issues.Tracker.Name {
+			buildInfoIssue.Key = issue.Tracker + "/" + issue.Key
+		}
+		buildInfoIssues.AffectedIssues = append(buildInfoIssues.AffectedIssues, buildInfoIssue)
+	}
+	return buildInfoIssues
+}
+
 func partialModulesToModules(partialModules map[string]partialModule) []buildinfo.Module {
 	var modules []buildinfo.Module
 	for moduleId, singlePartialModule := range partialModules {
@@ -179,7 +272,7 @@ func partialModulesToModules(partialModules map[string]partialModule) []buildinf
 	return modules
 }
 
-func issuesMapToArray(issues buildinfo.Issues, issuesMap map[string]*buildinfo.AffectedIssue) buildinfo.Issues {
+func issuesMapToArray(issues utils.Issues, issuesMap map[string]*utils.AffectedIssue) utils.Issues {
 	for _, issue := range issuesMap {
 		issues.AffectedIssues = append(issues.AffectedIssues, *issue)
 	}
@@ -4,6 +4,8 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/jfrog/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/utils/config"
 	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 )
 
@@ -67,3 +69,88 @@ func TestExcludePasswordsPattern(t *testing.T) {
 		t.Error("expected:", expected, "got:", filteredKeys)
 	}
 }
+
+func TestExtractVcsList(t *testing.T) {
+	legacyPartial := &utils.Partial{Partial: buildinfo.Partial{Vcs: &buildinfo.Vcs{Url: "https://github.com/jfrog/jfrog-cli.git", Revision: "111"}}}
+	partial := &utils.Partial{
+		Partial: buildinfo.Partial{Vcs: &buildinfo.Vcs{Url: "https://github.com/jfrog/jfrog-cli.git", Revision: "111"}},
+		VcsList: []utils.Vcs{
+			{Url: "https://github.com/jfrog/jfrog-cli.git", Revision: "111", Branch: "master", Message: "main"},
+			{Url: "https://github.com/jfrog/jfrog-client-go.git", Revision: "222", Branch: "dev", Message: "inner"},
+		},
+	}
+	envPartial := &utils.Partial{Partial: buildinfo.Partial{Env: buildinfo.Env{"key": "value"}}}
+
+	vcsList := extractVcsList(utils.Partials{partial, envPartial, legacyPartial})
+	if !reflect.DeepEqual(partial.VcsList, vcsList) {
+		t.Error("expected:", partial.VcsList, "got:", vcsList)
+	}
+	vcsList = extractVcsList(utils.Partials{legacyPartial})
+	expected := []utils.Vcs{{Url: "https://github.com/jfrog/jfrog-cli.git", Revision: "111"}}
+	if !reflect.DeepEqual(expected, vcsList) {
+		t.Error("expected:", expected, "got:", vcsList)
+	}
+}
+
+func TestCreateBuildInfoFromExtendedPartials(t *testing.T) {
+	buildConfiguration := &utils.BuildConfiguration{BuildName: "TestExtendedPartials", BuildNumber: "1"}
+	defer utils.RemoveBuildDir(buildConfiguration.BuildName, buildConfiguration.BuildNumber)
+	if err := utils.SaveBuildGeneralDetails(buildConfiguration.BuildName, buildConfiguration.BuildNumber); err != nil {
+		t.Fatal(err)
+	}
+	populateFunc := func(partial *utils.Partial) {
+		partial.Vcs = &buildinfo.Vcs{Url: "https://github.com/jfrog/jfrog-cli.git", Revision: "111"}
+		partial.VcsList = []utils.Vcs{
+			{Url: "https://github.com/jfrog/jfrog-cli.git", Revision: "111", Branch: "master"},
+			{Url: "https://github.com/jfrog/jfrog-client-go.git", Revision: "222"},
+		}
+		partial.Issues = &utils.Issues{
+			Tracker: &buildinfo.Tracker{Name: "JIRA,GitHub"},
+			AffectedIssues: []utils.AffectedIssue{
+				{AffectedIssue: buildinfo.AffectedIssue{Key: "42", Url: "https://jira.example.com/browse/42"}, Tracker: "JIRA"},
+				{AffectedIssue: buildinfo.AffectedIssue{Key: "42", Url: "https://github.com/jfrog/jfrog-cli/issues/42"}, Tracker: "GitHub"},
+			},
+		}
+	}
+	if err := utils.SaveExtendedPartialBuildInfo(buildConfiguration.BuildName, buildConfiguration.BuildNumber, populateFunc); err != nil {
+		t.Fatal(err)
+	}
+
+	buildInfo, err := NewBuildPublishCommand().SetBuildConfiguration(buildConfiguration).SetRtDetails(&config.ArtifactoryDetails{}).SetConfig(&buildinfo.Configuration{}).createBuildInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buildInfo.Url != "https://github.com/jfrog/jfrog-cli.git" || buildInfo.Revision != "111" {
+		t.Error("Unexpected VCS details:", buildInfo.Vcs)
+	}
+	// The VCS details of the additional repositories aren't published.
+	if buildInfo.Properties != nil {
+		t.Error("Unexpected build properties:", buildInfo.Properties)
+	}
+	if buildInfo.Issues == nil || buildInfo.Issues.Tracker.Name != "JIRA,GitHub" {
+		t.Fatal("Unexpected issues:", buildInfo.Issues)
+	}
+	// The issues of both trackers have the same key, and are told apart by their tracker.
+	expectedIssues := map[string]string{
+		"JIRA/42":   "https://jira.example.com/browse/42",
+		"GitHub/42": "https://github.com/jfrog/jfrog-cli/issues/42",
+	}
+	actualIssues := make(map[string]string)
+	for _, issue := range buildInfo.Issues.AffectedIssues {
+		actualIssues[issue.Key] = issue.Url
+	}
+	if !reflect.DeepEqual(expectedIssues, actualIssues) {
+		t.Error("expected:", expectedIssues, "got:", actualIssues)
+	}
+}
+
+func TestToBuildInfoIssuesWithSingleTracker(t *testing.T) {
+	issues := utils.Issues{
+		Tracker:        &buildinfo.Tracker{Name: "JIRA"},
+		AffectedIssues: []utils.AffectedIssue{{AffectedIssue: buildinfo.AffectedIssue{Key: "PROJ-1"}, Tracker: "JIRA"}},
+	}
+	// The keys of a single tracker are published as they are.
+	if key := toBuildInfoIssues(issues).AffectedIssues[0].Key; key != "PROJ-1" {
+		t.Error("expected: PROJ-1, got:", key)
+	}
+}
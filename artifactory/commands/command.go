@@ -4,9 +4,11 @@ import (
 	"github.com/jfrog/jfrog-cli/artifactory/utils"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
 	"github.com/jfrog/jfrog-cli/utils/config"
+	logUtils "github.com/jfrog/jfrog-cli/utils/log"
 	"github.com/jfrog/jfrog-client-go/artifactory/usage"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
+	"time"
 )
 
 type Command interface {
@@ -22,13 +24,28 @@ func Exec(command Command) error {
 	channel := make(chan bool)
 	// Triggers the report usage.
 	go reportUsage(command, channel)
+	logUtils.LogEvent(logUtils.CommandStartedEvent, map[string]interface{}{"command": command.CommandName()})
+	startTime := time.Now()
 	// Invoke the command interface
 	err := command.Run()
+	logCommandFinished(command, startTime, err)
 	// Waits for the signal from the report usage to be done.
 	<-channel
 	return err
 }
 
+func logCommandFinished(command Command, startTime time.Time, err error) {
+	fields := map[string]interface{}{
+		"command":     command.CommandName(),
+		"duration_ms": time.Since(startTime).Milliseconds(),
+		"success":     err == nil,
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	logUtils.LogEvent(logUtils.CommandFinishedEvent, fields)
+}
+
 func reportUsage(command Command, channel chan<- bool) {
 	defer signalReportUsageFinished(channel)
 	reportUsage, err := clientutils.GetBoolEnvValue(cliutils.ReportUsage, true)
@@ -102,6 +102,10 @@ func (cc *ConfigCommand) Config() error {
 	if err != nil {
 		return err
 	}
+	if cc.details.CredentialsHelper != "" {
+		// The refreshable tokens are saved in the config file, so they aren't used with a credentials helper.
+		cc.useBasicAuthOnly = true
+	}
 	if cc.interactive {
 		err = cc.getConfigurationFromUser()
 		if err != nil {
@@ -129,6 +133,17 @@ func (cc *ConfigCommand) Config() error {
 		cc.configRefreshableToken()
 	}
 
+	if cc.details.CredentialsHelper != "" {
+		// The credentials helper keeps a single secret for each URL, so a server sharing it would override the secret of the other.
+		if other := config.GetServerSharingHelperCredentials(cc.details, configurations); other != nil {
+			return errorutils.CheckError(errors.New("the server '" + other.ServerId + "' is configured with the same URL and credentials helper. Use a different credentials helper for each server of the same URL"))
+		}
+		err = config.StoreCredentialsInHelper(cc.details)
+		if err != nil {
+			return err
+		}
+	}
+
 	return config.SaveArtifactoryConf(configurations)
 }
 
@@ -386,6 +401,9 @@ func printConfigs(configuration []*config.ArtifactoryDetails) {
 		if details.RefreshToken != "" {
 			log.Output("Refresh token: ***")
 		}
+		if details.CredentialsHelper != "" {
+			log.Output("Credentials helper: " + details.CredentialsHelper)
+		}
 		if details.SshKeyPath != "" {
 			log.Output("SSH key file path: " + details.SshKeyPath)
 		}
@@ -406,10 +424,16 @@ func DeleteConfig(serverName string) error {
 		return err
 	}
 	var isDefault, isFoundName bool
-	for i, config := range configurations {
-		if config.ServerId == serverName {
-			isDefault = config.IsDefault
+	for i, serverConfig := range configurations {
+		if serverConfig.ServerId == serverName {
+			isDefault = serverConfig.IsDefault
 			configurations = append(configurations[:i], configurations[i+1:]...)
+			// The credentials are kept in the helper if another server still uses them.
+			if serverConfig.CredentialsHelper != "" && config.GetServerSharingHelperCredentials(serverConfig, configurations) == nil {
+				if err = config.EraseCredentialsFromHelper(serverConfig); err != nil {
+					log.Warn(err.Error())
+				}
+			}
 			isFoundName = true
 			break
 		}
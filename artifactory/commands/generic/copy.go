@@ -1,12 +1,11 @@
 package generic
 
 import (
-	"errors"
+	"time"
 
 	"github.com/jfrog/jfrog-cli/artifactory/spec"
 	"github.com/jfrog/jfrog-cli/artifactory/utils"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
-	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
 type CopyCommand struct {
@@ -23,38 +22,13 @@ func (cc *CopyCommand) CommandName() string {
 
 // Copies the artifacts using the specified move pattern.
 func (cc *CopyCommand) Run() error {
+	defer cc.recordDuration(time.Now())
 	// Create Service Manager:
 	servicesManager, err := utils.CreateServiceManager(cc.rtDetails, cc.dryRun)
 	if err != nil {
 		return err
 	}
-
-	var errorOccurred = false
-	// Copy Loop:
-	for i := 0; i < len(cc.spec.Files); i++ {
-
-		copyParams, err := getCopyParams(cc.spec.Get(i))
-		if err != nil {
-			errorOccurred = true
-			log.Error(err)
-			continue
-		}
-
-		partialSuccess, partialFailed, err := servicesManager.Copy(copyParams)
-		success := cc.result.SuccessCount() + partialSuccess
-		cc.result.SetSuccessCount(success)
-		failed := cc.result.FailCount() + partialFailed
-		cc.result.SetFailCount(failed)
-		if err != nil {
-			errorOccurred = true
-			log.Error(err)
-			continue
-		}
-	}
-	if errorOccurred {
-		return errors.New("Copy finished with errors, please review the logs.")
-	}
-	return err
+	return runMoveCopy(servicesManager, &cc.GenericCommand, services.COPY, getCopyParams)
 }
 
 func getCopyParams(f *spec.File) (copyParams services.MoveCopyParams, err error) {
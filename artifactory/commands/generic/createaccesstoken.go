@@ -6,6 +6,7 @@ import (
 	"github.com/jfrog/jfrog-cli/utils/config"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
 const (
@@ -22,6 +23,7 @@ type AccessTokenCreateCommand struct {
 	audience    string
 	groups      string
 	grantAdmin  bool
+	profile     string
 	response    *services.CreateTokenResponseData
 }
 
@@ -64,6 +66,12 @@ func (atcc *AccessTokenCreateCommand) SetGroups(groups string) *AccessTokenCreat
 	return atcc
 }
 
+// If set, the token is saved as a token profile with this name in the configuration of the server.
+func (atcc *AccessTokenCreateCommand) SetProfile(profile string) *AccessTokenCreateCommand {
+	atcc.profile = profile
+	return atcc
+}
+
 func (atcc *AccessTokenCreateCommand) Response() ([]byte, error) {
 	content, err := json.Marshal(*atcc.response)
 	return content, errorutils.CheckError(err)
@@ -87,8 +95,31 @@ func (atcc *AccessTokenCreateCommand) Run() error {
 		return err
 	}
 
+	if atcc.profile != "" {
+		if err = config.ValidateTokenProfilesSupported(atcc.rtDetails); err != nil {
+			return err
+		}
+		// The token of a profile is refreshed before it expires.
+		tokenParams.Refreshable = true
+	}
 	*atcc.response, err = servicesManager.CreateToken(tokenParams)
-	return err
+	if err != nil || atcc.profile == "" {
+		return err
+	}
+	profile := &config.TokenProfile{
+		Name:         atcc.profile,
+		User:         tokenParams.Username,
+		Scope:        tokenParams.Scope,
+		Audience:     tokenParams.Audience,
+		ExpiresIn:    tokenParams.ExpiresIn,
+		AccessToken:  atcc.response.AccessToken,
+		RefreshToken: atcc.response.RefreshToken,
+	}
+	if err = config.SaveTokenProfile(atcc.rtDetails.ServerId, profile); err != nil {
+		return err
+	}
+	log.Info("The token was saved as the token profile '" + atcc.profile + "'.")
+	return nil
 }
 
 func (atcc *AccessTokenCreateCommand) getTokenParams() (tokenParams services.CreateTokenParams, err error) {
@@ -3,6 +3,7 @@ package generic
 import (
 	"github.com/jfrog/jfrog-cli/artifactory/spec"
 	"github.com/jfrog/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -98,10 +99,29 @@ func (dc *DeleteCommand) DeleteFiles() (successCount, failedCount int, err error
 	if err != nil {
 		return 0, 0, err
 	}
+	if dc.DetailedSummary() {
+		return dc.deleteFilesWithDetails(servicesManager)
+	}
 	deletedCount, err := servicesManager.DeleteFiles(dc.deleteItems)
 	return deletedCount, len(dc.deleteItems) - deletedCount, err
 }
 
+// Deletes the files one by one, and records the result of each file for the detailed summary.
+func (dc *DeleteCommand) deleteFilesWithDetails(servicesManager *artifactory.ArtifactoryServicesManager) (successCount, failedCount int, err error) {
+	results, err := newDetailedResults()
+	if err != nil {
+		return 0, 0, err
+	}
+	err = results.run(dc.deleteItems, dc.Threads(), createDeleteTaskFunc(servicesManager))
+	reader, closeErr := results.close()
+	dc.result.SetReader(reader)
+	dc.result.AddFailures(results.failures...)
+	if err == nil {
+		err = closeErr
+	}
+	return results.successCount, results.failCount, err
+}
+
 func getDeleteParams(f *spec.File) (deleteParams services.DeleteParams, err error) {
 	deleteParams = services.NewDeleteParams()
 	deleteParams.ArtifactoryCommonParams = f.ToArtifactoryCommonParams()
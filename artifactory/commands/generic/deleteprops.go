@@ -33,8 +33,13 @@ func (deleteProps *DeletePropsCommand) Run() error {
 
 	resultItems, searchErr := searchItems(deleteProps.Spec(), servicesManager)
 
-	propsParams := GetPropsParams(resultItems, deleteProps.props)
-	success, err := servicesManager.DeleteProps(propsParams)
+	var success int
+	if deleteProps.DetailedSummary() {
+		success, err = deleteProps.runWithDetails(servicesManager, resultItems, true)
+	} else {
+		propsParams := GetPropsParams(resultItems, deleteProps.props)
+		success, err = servicesManager.DeleteProps(propsParams)
+	}
 	result := deleteProps.Result()
 	result.SetSuccessCount(success)
 	result.SetFailCount(len(resultItems) - success)
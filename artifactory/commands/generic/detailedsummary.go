@@ -0,0 +1,315 @@
+package generic
+
+import (
+	"errors"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-cli/artifactory/spec"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The services of jfrog-client-go return only the total success and failure counts of delete, move, copy and properties operations.
+// When a detailed summary is requested, the services are therefore called for every artifact separately,
+// so that the result of every artifact, including the response of Artifactory to a failed request, can be reported.
+// Move and copy are always done for every artifact separately, to report their failures in the summary of the command.
+
+const detailedSummaryArrayKey = "files"
+
+var moveCopyMsgs = map[services.MoveType]struct{ operation, movingMsg string }{
+	services.MOVE: {operation: "Move", movingMsg: "Moving"},
+	services.COPY: {operation: "Copy", movingMsg: "Copying"},
+}
+
+// Runs an operation on a single artifact.
+// Returns the path the artifact was moved or copied to, or an empty string for operations without a target.
+type itemTaskFunc func(threadId int, item serviceutils.ResultItem) (targetPath string, err error)
+
+// Collects the results of the operations on the artifacts into a temp file, which is read to print the detailed summary.
+// The failures are also kept in memory, to be reported in the summary of the command.
+type detailedResults struct {
+	writer        *content.ContentWriter
+	successCount  int
+	failCount     int
+	hasRecords    bool
+	failures      []summary.FileFailure
+	failuresMutex sync.Mutex
+}
+
+func newDetailedResults() (*detailedResults, error) {
+	writer, err := content.NewContentWriter(detailedSummaryArrayKey, true, false)
+	if err != nil {
+		return nil, err
+	}
+	return &detailedResults{writer: writer}, nil
+}
+
+// Runs the task on each of the items, using the provided number of threads.
+// Returns the first error returned by the tasks. The rest of the items are processed even if a task fails.
+func (dr *detailedResults) run(items []serviceutils.ResultItem, threads int, task itemTaskFunc) error {
+	if len(items) == 0 {
+		return nil
+	}
+	dr.hasRecords = true
+	successCounters := make([]int, threads)
+	producerConsumer := parallel.NewBounedRunner(threads, false)
+	errorsQueue := clientutils.NewErrorsQueue(1)
+	go func() {
+		defer producerConsumer.Done()
+		for _, item := range items {
+			producerConsumer.AddTaskWithError(dr.createTaskFunc(item, task, successCounters), errorsQueue.AddError)
+		}
+	}()
+	producerConsumer.Run()
+	success := serviceutils.SumIntArray(successCounters)
+	dr.successCount += success
+	dr.failCount += len(items) - success
+	return errorsQueue.GetError()
+}
+
+func (dr *detailedResults) createTaskFunc(item serviceutils.ResultItem, task itemTaskFunc, successCounters []int) parallel.TaskFunc {
+	return func(threadId int) error {
+		record := cliutils.ResultRecord{ArtifactoryPath: item.GetItemRelativePath()}
+		targetPath, err := task(threadId, item)
+		record.TargetArtifactoryPath = targetPath
+		if err != nil {
+			record.Error = err.Error()
+			dr.addFailure(record.ArtifactoryPath, err)
+		} else {
+			successCounters[threadId]++
+		}
+		dr.writer.Write(record)
+		return err
+	}
+}
+
+func (dr *detailedResults) addFailure(artifactoryPath string, err error) {
+	failure := summary.FileFailure{Path: artifactoryPath, Error: err.Error()}
+	if respErr, ok := err.(*responseError); ok {
+		failure.StatusCode = respErr.statusCode
+	}
+	dr.failuresMutex.Lock()
+	defer dr.failuresMutex.Unlock()
+	dr.failures = append(dr.failures, failure)
+}
+
+// Finishes writing the results. Returns a reader of the results, or nil if there are no results.
+func (dr *detailedResults) close() (*content.ContentReader, error) {
+	if !dr.hasRecords {
+		return nil, dr.writer.RemoveOutputFilePath()
+	}
+	if err := dr.writer.Close(); err != nil {
+		return nil, err
+	}
+	return content.NewContentReader(dr.writer.GetFilePath(), detailedSummaryArrayKey), nil
+}
+
+func createDeleteTaskFunc(servicesManager *artifactory.ArtifactoryServicesManager) itemTaskFunc {
+	return func(threadId int, item serviceutils.ResultItem) (string, error) {
+		_, err := servicesManager.DeleteFiles([]serviceutils.ResultItem{item})
+		return "", err
+	}
+}
+
+func createPropsTaskFunc(servicesManager *artifactory.ArtifactoryServicesManager, props string, isDelete bool) itemTaskFunc {
+	return func(threadId int, item serviceutils.ResultItem) (string, error) {
+		params := services.PropsParams{Items: []serviceutils.ResultItem{item}, Props: props}
+		var err error
+		if isDelete {
+			_, err = servicesManager.DeleteProps(params)
+		} else {
+			_, err = servicesManager.SetProps(params)
+		}
+		return "", err
+	}
+}
+
+// Moves or copies the artifacts matching the spec.
+// The artifacts are moved one by one, to record the source, the target and the failure reason of each artifact.
+// The result of each artifact is reported only if a detailed summary is requested, and the failures are reported in any case.
+func runMoveCopy(servicesManager *artifactory.ArtifactoryServicesManager, gc *GenericCommand, moveType services.MoveType,
+	getParams func(*spec.File) (services.MoveCopyParams, error)) error {
+	results, err := newDetailedResults()
+	if err != nil {
+		return err
+	}
+	var errorOccurred = false
+	for i := 0; i < len(gc.Spec().Files); i++ {
+		params, err := getParams(gc.Spec().Get(i))
+		if err != nil {
+			errorOccurred = true
+			log.Error(err)
+			continue
+		}
+		resultItems, err := searchMoveCopyItems(servicesManager, params, moveType, gc.DryRun())
+		if err != nil {
+			errorOccurred = true
+			log.Error(err)
+			continue
+		}
+		// The artifacts are moved one after the other, as done by the move service of jfrog-client-go.
+		if err = results.run(resultItems, 1, createMoveCopyTaskFunc(servicesManager, params, moveType, gc.DryRun())); err != nil {
+			errorOccurred = true
+		}
+	}
+	reader, err := results.close()
+	if reader != nil && !gc.DetailedSummary() {
+		if err = reader.Close(); err != nil {
+			log.Error(err)
+		}
+		reader = nil
+	}
+	gc.result.SetReader(reader)
+	gc.result.SetSuccessCount(results.successCount)
+	gc.result.SetFailCount(results.failCount)
+	gc.result.AddFailures(results.failures...)
+	if errorOccurred {
+		return errors.New(moveCopyMsgs[moveType].operation + " finished with errors, please review the logs.")
+	}
+	return err
+}
+
+func searchMoveCopyItems(servicesManager *artifactory.ArtifactoryServicesManager, params services.MoveCopyParams, moveType services.MoveType,
+	dryRun bool) (resultItems []serviceutils.ResultItem, err error) {
+	// The move service is used as the search configuration, since it holds the Artifactory details and the dry-run value.
+	searchConf := services.NewMoveCopyService(servicesManager.Client(), moveType)
+	searchConf.ArtDetails = servicesManager.GetConfig().GetServiceDetails()
+	searchConf.DryRun = dryRun
+	log.Info("Searching items...")
+	switch params.GetSpecType() {
+	case serviceutils.BUILD:
+		resultItems, err = serviceutils.SearchBySpecWithBuild(params.GetFile(), searchConf)
+	case serviceutils.AQL:
+		resultItems, err = serviceutils.SearchBySpecWithAql(params.GetFile(), searchConf, serviceutils.NONE)
+	case serviceutils.WILDCARD:
+		params.SetIncludeDir(true)
+		resultItems, err = serviceutils.SearchBySpecWithPattern(params.GetFile(), searchConf, serviceutils.NONE)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if params.IsFlat() {
+		resultItems = serviceutils.ReduceDirResult(resultItems, serviceutils.FilterBottomChainResults)
+	} else {
+		resultItems = serviceutils.ReduceDirResult(resultItems, serviceutils.FilterTopChainResults)
+	}
+	serviceutils.LogSearchResults(len(resultItems))
+	return resultItems, nil
+}
+
+// Moves or copies a single artifact by the move or copy REST API, as done by the move service of jfrog-client-go for each artifact.
+// The API is called directly, since the service does not return the response of a failed request.
+func createMoveCopyTaskFunc(servicesManager *artifactory.ArtifactoryServicesManager, params services.MoveCopyParams, moveType services.MoveType,
+	dryRun bool) itemTaskFunc {
+	return func(threadId int, item serviceutils.ResultItem) (string, error) {
+		targetPath, err := getMoveCopyTargetPath(item, params)
+		if err != nil {
+			log.Error(err)
+			return "", err
+		}
+		if strings.HasSuffix(targetPath, "/") {
+			if item.Type != "folder" {
+				targetPath += item.Name
+			} else if err = createArtifactoryPath(servicesManager, targetPath, dryRun); err != nil {
+				log.Error(err)
+				return targetPath, err
+			}
+		}
+		err = moveCopyItem(servicesManager, item.GetItemRelativePath(), targetPath, moveType, dryRun)
+		if err != nil {
+			log.Error(err)
+		}
+		return targetPath, err
+	}
+}
+
+func moveCopyItem(servicesManager *artifactory.ArtifactoryServicesManager, sourcePath, targetPath string, moveType services.MoveType, dryRun bool) error {
+	message := moveCopyMsgs[moveType].movingMsg + " artifact: " + sourcePath + " to: " + targetPath
+	params := map[string]string{"to": targetPath}
+	if dryRun {
+		log.Info("[Dry run]", message)
+		params["dry"] = "1"
+	} else {
+		log.Info(message)
+	}
+	artDetails := servicesManager.GetConfig().GetServiceDetails()
+	requestFullUrl, err := serviceutils.BuildArtifactoryUrl(artDetails.GetUrl(), path.Join("api", string(moveType), sourcePath), params)
+	if err != nil {
+		return err
+	}
+	httpClientsDetails := artDetails.CreateHttpClientDetails()
+	resp, body, err := servicesManager.Client().SendPost(requestFullUrl, nil, &httpClientsDetails)
+	if err != nil {
+		return err
+	}
+	log.Debug("Artifactory response:", resp.Status)
+	if resp.StatusCode != http.StatusOK {
+		return errorutils.CheckError(&responseError{statusCode: resp.StatusCode, status: resp.Status, body: body})
+	}
+	return nil
+}
+
+// Creates a folder in Artifactory, to which a folder is moved or copied.
+func createArtifactoryPath(servicesManager *artifactory.ArtifactoryServicesManager, targetPath string, dryRun bool) error {
+	if dryRun {
+		log.Info("[Dry run]", "Create path:", targetPath)
+		return nil
+	}
+	artDetails := servicesManager.GetConfig().GetServiceDetails()
+	requestFullUrl, err := serviceutils.BuildArtifactoryUrl(artDetails.GetUrl(), targetPath, map[string]string{})
+	if err != nil {
+		return err
+	}
+	httpClientsDetails := artDetails.CreateHttpClientDetails()
+	resp, body, err := servicesManager.Client().SendPut(requestFullUrl, nil, &httpClientsDetails)
+	if err != nil {
+		return err
+	}
+	log.Debug("Artifactory response:", resp.Status)
+	if resp.StatusCode != http.StatusCreated {
+		return errorutils.CheckError(&responseError{statusCode: resp.StatusCode, status: resp.Status, body: body})
+	}
+	return nil
+}
+
+// An unexpected response of Artifactory to the request of a single artifact.
+type responseError struct {
+	statusCode int
+	status     string
+	body       []byte
+}
+
+func (re *responseError) Error() string {
+	message := "Artifactory response: " + re.status
+	if len(re.body) > 0 {
+		message += "\n" + clientutils.IndentJson(re.body)
+	}
+	return message
+}
+
+// Returns the path in Artifactory the artifact should be moved or copied to, as calculated by the move service of jfrog-client-go.
+// A path ending with a slash is a folder, to which the artifact should be moved with its name.
+func getMoveCopyTargetPath(item serviceutils.ResultItem, params services.MoveCopyParams) (string, error) {
+	target := params.GetFile().Target
+	if !params.IsFlat() {
+		if strings.Contains(target, "/") {
+			file, dir := fileutils.GetFileAndDirFromPath(target)
+			target = clientutils.TrimPath(dir + "/" + item.Path + "/" + file)
+		} else {
+			target = clientutils.TrimPath(target + "/" + item.Path + "/")
+		}
+	}
+	return clientutils.BuildTargetPath(params.GetFile().Pattern, item.GetItemRelativePath(), target, true)
+}
@@ -0,0 +1,233 @@
+package generic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/artifactory/spec"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/log"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+)
+
+// Artifactory mock, which fails the requests of paths containing 'fail' or 'unavailable', and records the requests it receives.
+// A search for a specific file name returns only the matching search results, and any other search returns all of them.
+// All the downloaded files have the same content.
+type artifactoryMock struct {
+	server   *httptest.Server
+	requests []string
+	// The AQL queries of the searches.
+	queries []string
+	mutex   sync.Mutex
+}
+
+func newArtifactoryMock(searchResults string) *artifactoryMock {
+	mock := &artifactoryMock{}
+	mock.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/search/aql" {
+			query, _ := ioutil.ReadAll(r.Body)
+			mock.mutex.Lock()
+			mock.queries = append(mock.queries, string(query))
+			mock.mutex.Unlock()
+			fmt.Fprint(w, filterSearchResults(searchResults, string(query)))
+			return
+		}
+		ioutil.ReadAll(r.Body)
+		mock.mutex.Lock()
+		mock.requests = append(mock.requests, r.Method+" "+r.URL.RequestURI())
+		mock.mutex.Unlock()
+		switch {
+		case strings.Contains(r.URL.Path, "fail"):
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"errors":[{"status":404,"message":"Not found"}]}`)
+		case strings.Contains(r.URL.Path, "unavailable"):
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.Method == http.MethodPut && !strings.HasPrefix(r.URL.Path, "/api/"):
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodGet && !strings.HasPrefix(r.URL.Path, "/api/"):
+			fmt.Fprint(w, "content")
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	return mock
+}
+
+func filterSearchResults(searchResults, query string) string {
+	var parsed struct {
+		Results []serviceutils.ResultItem `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(searchResults), &parsed); err != nil {
+		return searchResults
+	}
+	var matching []serviceutils.ResultItem
+	for _, item := range parsed.Results {
+		if strings.Contains(query, `"`+item.Name+`"`) {
+			matching = append(matching, item)
+		}
+	}
+	if len(matching) == 0 {
+		return searchResults
+	}
+	parsed.Results = matching
+	filtered, _ := json.Marshal(parsed)
+	return string(filtered)
+}
+
+func (mock *artifactoryMock) rtDetails() *config.ArtifactoryDetails {
+	return &config.ArtifactoryDetails{Url: mock.server.URL + "/"}
+}
+
+func (mock *artifactoryMock) sortedRequests() []string {
+	sort.Strings(mock.requests)
+	return mock.requests
+}
+
+func setJfrogHomeDir(t *testing.T) func() {
+	homeDir, err := ioutil.TempDir("", "jfrog-home")
+	assert.NoError(t, err)
+	oldHomeDir, exists := os.LookupEnv(cliutils.HomeDir)
+	os.Setenv(cliutils.HomeDir, homeDir)
+	return func() {
+		if exists {
+			os.Setenv(cliutils.HomeDir, oldHomeDir)
+		} else {
+			os.Unsetenv(cliutils.HomeDir)
+		}
+		os.RemoveAll(homeDir)
+	}
+}
+
+func readResultRecords(t *testing.T, reader *content.ContentReader) map[string]cliutils.ResultRecord {
+	records := make(map[string]cliutils.ResultRecord)
+	if !assert.NotNil(t, reader) {
+		return records
+	}
+	defer reader.Close()
+	for record := new(cliutils.ResultRecord); reader.NextRecord(record) == nil; record = new(cliutils.ResultRecord) {
+		records[record.ArtifactoryPath] = *record
+	}
+	return records
+}
+
+func TestDeleteWithDetailedSummary(t *testing.T) {
+	log.SetDefaultLogger()
+	defer setJfrogHomeDir(t)()
+	mock := newArtifactoryMock("")
+	defer mock.server.Close()
+
+	deleteCommand := NewDeleteCommand()
+	deleteCommand.SetThreads(2).SetDeleteItems([]serviceutils.ResultItem{
+		{Repo: "repo", Path: "dir", Name: "a.txt", Type: "file"},
+		{Repo: "repo", Path: "dir", Name: "fail.txt", Type: "file"},
+	})
+	deleteCommand.SetRtDetails(mock.rtDetails()).SetDetailedSummaryt(true)
+	success, failed, err := deleteCommand.DeleteFiles()
+	assert.Error(t, err)
+	assert.Equal(t, 1, success)
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, []string{"DELETE /repo/dir/a.txt", "DELETE /repo/dir/fail.txt"}, mock.sortedRequests())
+
+	records := readResultRecords(t, deleteCommand.Result().Reader())
+	assert.Len(t, records, 2)
+	assert.Empty(t, records["repo/dir/a.txt"].Error)
+	assert.Contains(t, records["repo/dir/fail.txt"].Error, "404")
+}
+
+func TestSetPropsWithDetailedSummary(t *testing.T) {
+	log.SetDefaultLogger()
+	defer setJfrogHomeDir(t)()
+	mock := newArtifactoryMock(`{"results":[{"repo":"repo","path":"dir","name":"a.txt","type":"file"},{"repo":"repo","path":"dir","name":"fail.txt","type":"file"}]}`)
+	defer mock.server.Close()
+
+	propsCommand := NewPropsCommand().SetProps("a=1;b=2")
+	propsCommand.SetThreads(1).SetSpec(spec.NewBuilder().Pattern("repo/dir/*").BuildSpec()).SetRtDetails(mock.rtDetails()).SetDetailedSummaryt(true)
+	setPropsCommand := NewSetPropsCommand().SetPropsCommand(*propsCommand)
+	assert.Error(t, setPropsCommand.Run())
+	assert.Equal(t, 1, setPropsCommand.Result().SuccessCount())
+	assert.Equal(t, 1, setPropsCommand.Result().FailCount())
+	assert.Equal(t, []string{"PUT /api/storage/repo/dir/a.txt?properties=a=1;b=2", "PUT /api/storage/repo/dir/fail.txt?properties=a=1;b=2"}, mock.sortedRequests())
+
+	records := readResultRecords(t, setPropsCommand.Result().Reader())
+	assert.Len(t, records, 2)
+	assert.Empty(t, records["repo/dir/a.txt"].Error)
+	assert.NotEmpty(t, records["repo/dir/fail.txt"].Error)
+}
+
+func TestMoveWithDetailedSummary(t *testing.T) {
+	log.SetDefaultLogger()
+	defer setJfrogHomeDir(t)()
+	mock := newArtifactoryMock(`{"results":[{"repo":"repo","path":"dir","name":"a.txt","type":"file"},{"repo":"repo","path":"dir","name":"fail.txt","type":"file"}]}`)
+	defer mock.server.Close()
+
+	moveCommand := NewMoveCommand()
+	moveCommand.SetSpec(spec.NewBuilder().Pattern("repo/dir/*").Target("target/").Flat(true).BuildSpec()).SetRtDetails(mock.rtDetails()).SetDetailedSummaryt(true)
+	assert.Error(t, moveCommand.Run())
+	assert.Equal(t, 1, moveCommand.Result().SuccessCount())
+	assert.Equal(t, 1, moveCommand.Result().FailCount())
+	assert.Equal(t, []string{"POST /api/move/repo/dir/a.txt?to=target%2Fa.txt", "POST /api/move/repo/dir/fail.txt?to=target%2Ffail.txt"}, mock.sortedRequests())
+
+	records := readResultRecords(t, moveCommand.Result().Reader())
+	assert.Len(t, records, 2)
+	assert.Equal(t, cliutils.ResultRecord{ArtifactoryPath: "repo/dir/a.txt", TargetArtifactoryPath: "target/a.txt"}, records["repo/dir/a.txt"])
+	assert.Equal(t, "target/fail.txt", records["repo/dir/fail.txt"].TargetArtifactoryPath)
+	assert.NotEmpty(t, records["repo/dir/fail.txt"].Error)
+	failures := moveCommand.Result().Failures()
+	if assert.Len(t, failures, 1) {
+		assert.Equal(t, "repo/dir/fail.txt", failures[0].Path)
+		assert.Equal(t, http.StatusNotFound, failures[0].StatusCode)
+		assert.Contains(t, failures[0].Error, "404 Not Found")
+		assert.Contains(t, failures[0].Error, `"message": "Not found"`)
+		assert.Zero(t, failures[0].Attempts)
+	}
+}
+
+func TestMoveWithoutDetailedSummary(t *testing.T) {
+	log.SetDefaultLogger()
+	defer setJfrogHomeDir(t)()
+	mock := newArtifactoryMock(`{"results":[{"repo":"repo","path":"dir","name":"a.txt","type":"file"},{"repo":"repo","path":"dir","name":"fail.txt","type":"file"}]}`)
+	defer mock.server.Close()
+
+	moveCommand := NewMoveCommand()
+	moveCommand.SetSpec(spec.NewBuilder().Pattern("repo/dir/*").Target("target/").Flat(true).BuildSpec()).SetRtDetails(mock.rtDetails())
+	assert.Error(t, moveCommand.Run())
+	assert.Equal(t, 1, moveCommand.Result().SuccessCount())
+	assert.Equal(t, 1, moveCommand.Result().FailCount())
+	assert.Equal(t, []string{"POST /api/move/repo/dir/a.txt?to=target%2Fa.txt", "POST /api/move/repo/dir/fail.txt?to=target%2Ffail.txt"}, mock.sortedRequests())
+	// Without a detailed summary, the result of each artifact isn't reported, but the failures are.
+	assert.Nil(t, moveCommand.Result().Reader())
+	failures := moveCommand.Result().Failures()
+	if assert.Len(t, failures, 1) {
+		assert.Equal(t, "repo/dir/fail.txt", failures[0].Path)
+		assert.Equal(t, http.StatusNotFound, failures[0].StatusCode)
+	}
+	assert.NotZero(t, moveCommand.Result().Duration())
+}
+
+func TestCopyFolderWithDetailedSummary(t *testing.T) {
+	log.SetDefaultLogger()
+	defer setJfrogHomeDir(t)()
+	mock := newArtifactoryMock(`{"results":[{"repo":"repo","path":".","name":"dir","type":"folder"}]}`)
+	defer mock.server.Close()
+
+	copyCommand := NewCopyCommand()
+	copyCommand.SetSpec(spec.NewBuilder().Pattern("repo/dir").Target("target/").Flat(true).BuildSpec()).SetRtDetails(mock.rtDetails()).SetDetailedSummaryt(true)
+	assert.NoError(t, copyCommand.Run())
+	assert.Equal(t, 1, copyCommand.Result().SuccessCount())
+	assert.Equal(t, 0, copyCommand.Result().FailCount())
+	// The target folder is created before the folder is copied into it.
+	assert.Equal(t, []string{"PUT /target/", "POST /api/copy/repo/dir?to=target%2F"}, mock.requests)
+}
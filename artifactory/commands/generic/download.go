@@ -7,6 +7,8 @@ import (
 	"github.com/jfrog/jfrog-cli/artifactory/utils"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
 	"github.com/jfrog/jfrog-cli/utils/progressbar"
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
@@ -19,6 +21,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 )
 
 type DownloadCommand struct {
@@ -59,6 +62,7 @@ func (dc *DownloadCommand) Run() error {
 		"You can avoid this confirmation message by adding --quiet to the command.", false) {
 		return nil
 	}
+	defer dc.recordDuration(time.Now())
 	// Initialize Progress bar, set logger to a log file
 	var err error
 	var progressBar ioUtils.Progress
@@ -71,7 +75,8 @@ func (dc *DownloadCommand) Run() error {
 	}
 
 	// Create Service Manager:
-	servicesManager, err := utils.CreateDownloadServiceManager(dc.rtDetails, dc.configuration.Threads, dc.DryRun(), progressBar)
+	collector := newTransferCollector(progressBar)
+	servicesManager, err := utils.CreateDownloadServiceManager(dc.rtDetails, dc.configuration.Threads, dc.DryRun(), collector)
 	if err != nil {
 		return err
 	}
@@ -96,23 +101,34 @@ func (dc *DownloadCommand) Run() error {
 		}
 		downloadParamsArray = append(downloadParamsArray, downParams)
 	}
+	var cached *cachedDownload
+	if dc.configuration.Cache && !dc.DryRun() {
+		cache, err := utils.NewDownloadCache()
+		if err != nil {
+			return err
+		}
+		cached, downloadParamsArray = prepareCachedDownload(cache, servicesManager, downloadParamsArray)
+	}
 	// Perform download.
-	// In case of build-info collection/sync-deletes operation/a detailed summary is required, we use the download service which provides results file reader,
-	// otherwise we use the download service which provides only general counters.
+	// The results file reader is always created, since the downloaded files are needed to report the failed ones,
+	// in addition to the build-info collection, the sync-deletes operation and the detailed summary.
 	var totalDownloaded, totalExpected int
 	var resultsReader *content.ContentReader = nil
-	if isCollectBuildInfo || dc.SyncDeletesPath() != "" || dc.DetailedSummary() {
-		resultsReader, totalDownloaded, totalExpected, err = servicesManager.DownloadFilesWithResultReader(downloadParamsArray...)
-		dc.result.SetReader(resultsReader)
-	} else {
-		totalDownloaded, totalExpected, err = servicesManager.DownloadFiles(downloadParamsArray...)
-	}
+	resultsReader, totalDownloaded, totalExpected, err = downloadFilesWithResultReader(servicesManager, collector, downloadParamsArray...)
+	dc.result.SetReader(resultsReader)
 	if err != nil {
 		errorOccurred = true
 		log.Error(err)
 	}
+	if cached != nil {
+		cached.addDownloadedFiles()
+	}
 	dc.result.SetSuccessCount(totalDownloaded)
 	dc.result.SetFailCount(totalExpected - totalDownloaded)
+	dc.result.SetTransferredBytes(collector.bytes())
+	if resultsReader != nil && totalDownloaded < totalExpected && !dc.DryRun() {
+		dc.result.AddFailures(collectDownloadFailures(servicesManager, downloadParamsArray, resultsReader)...)
+	}
 	// If the 'details summary' was requested, then the reader should not be closed now.
 	// It will be closed after it will be used to generate the summary.
 	if resultsReader != nil && !dc.DetailedSummary() {
@@ -174,6 +190,65 @@ func (dc *DownloadCommand) Run() error {
 	return err
 }
 
+// Downloads the files by the download service of jfrog-client-go, which writes the downloaded files to a results file.
+// Unlike the DownloadFilesWithResultReader of the services manager, the reader of the results is returned also when some of the downloads fail.
+func downloadFilesWithResultReader(servicesManager *artifactory.ArtifactoryServicesManager, progress ioUtils.Progress,
+	downloadParamsArray ...services.DownloadParams) (resultsReader *content.ContentReader, totalDownloaded, totalExpected int, err error) {
+	downloadService := services.NewDownloadService(servicesManager.Client())
+	downloadService.DryRun = servicesManager.GetConfig().IsDryRun()
+	downloadService.ArtDetails = servicesManager.GetConfig().GetServiceDetails()
+	downloadService.Threads = servicesManager.GetConfig().GetThreads()
+	downloadService.Progress = progress
+	downloadService.ResultWriter, err = content.NewContentWriter("results", true, false)
+	if err != nil {
+		return
+	}
+	// The results file is closed by the download service, even if the download fails.
+	totalDownloaded, totalExpected, err = downloadService.DownloadFiles(downloadParamsArray...)
+	resultsReader = content.NewContentReader(downloadService.ResultWriter.GetFilePath(), "results")
+	return
+}
+
+// Returns the failures of the files which were expected to be downloaded, but are missing in the downloaded files returned by jfrog-client-go.
+// jfrog-client-go returns only the downloaded files, so the files to download are searched again to find the failed ones.
+// It doesn't return the responses of the failed downloads either, so their reasons are reported in the logs only.
+func collectDownloadFailures(servicesManager *artifactory.ArtifactoryServicesManager, downloadParamsArray []services.DownloadParams,
+	resultsReader *content.ContentReader) (failures []summary.FileFailure) {
+	downloaded := make(map[string]bool)
+	for fileInfo := new(clientutils.FileInfo); resultsReader.NextRecord(fileInfo) == nil; fileInfo = new(clientutils.FileInfo) {
+		downloaded[fileInfo.ArtifactoryPath] = true
+	}
+	resultsReader.Reset()
+	searchConf := services.NewDownloadService(servicesManager.Client())
+	searchConf.ArtDetails = servicesManager.GetConfig().GetServiceDetails()
+	for _, downloadParams := range downloadParamsArray {
+		resultItems, err := searchDownloadItems(downloadParams, searchConf)
+		if err != nil {
+			log.Debug("Couldn't search the files to download to report the failed ones:", err.Error())
+			continue
+		}
+		for _, item := range resultItems {
+			if item.Type != "folder" && !downloaded[item.GetItemRelativePath()] {
+				failures = append(failures, summary.FileFailure{Path: item.GetItemRelativePath(), Error: "The file was not downloaded, please review the logs."})
+			}
+		}
+	}
+	return
+}
+
+// Searches the items of the download params, as done by the download service of jfrog-client-go.
+func searchDownloadItems(downloadParams services.DownloadParams, searchConf *services.DownloadService) (resultItems []clientutils.ResultItem, err error) {
+	switch downloadParams.GetSpecType() {
+	case clientutils.WILDCARD:
+		resultItems, err = clientutils.SearchBySpecWithPattern(downloadParams.GetFile(), searchConf, clientutils.SYMLINK)
+	case clientutils.BUILD:
+		resultItems, err = clientutils.SearchBySpecWithBuild(downloadParams.GetFile(), searchConf)
+	case clientutils.AQL:
+		resultItems, err = clientutils.SearchBySpecWithAql(downloadParams.GetFile(), searchConf, clientutils.SYMLINK)
+	}
+	return
+}
+
 func convertFileInfoToBuildDependencies(filesInfo []clientutils.FileInfo) []buildinfo.Dependency {
 	buildDependencies := make([]buildinfo.Dependency, len(filesInfo))
 	for i, fileInfo := range filesInfo {
@@ -0,0 +1,166 @@
+package generic
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The maximum number of items looked up by jfrog-client-go in a single AQL query, when downloading the items found by the download cache.
+const cachedDownloadItemsPerQuery = 500
+
+// Serves the files to download from the download cache, before they are downloaded by jfrog-client-go.
+// jfrog-client-go doesn't download files which already exist locally with the expected checksums, so the files placed from the cache aren't downloaded.
+type cachedDownload struct {
+	cache *utils.DownloadCache
+	files []*cachedFile
+}
+
+type cachedFile struct {
+	localPath string
+	sha1      string
+	size      int64
+	// True if the file was placed from the cache.
+	extracted bool
+}
+
+// Searches the files to download, and places the cached files in their local paths.
+// Returns the download params to download the files with. To avoid searching the files again, the returned params look up the found items by their exact paths.
+// If the files of a download params can't be searched, the download params is returned as is, to be searched and reported by jfrog-client-go.
+func prepareCachedDownload(cache *utils.DownloadCache, servicesManager *artifactory.ArtifactoryServicesManager, downloadParamsArray []services.DownloadParams) (*cachedDownload, []services.DownloadParams) {
+	searchConf := services.NewDownloadService(servicesManager.Client())
+	searchConf.ArtDetails = servicesManager.GetConfig().GetServiceDetails()
+	cd := &cachedDownload{cache: cache}
+	var cachedParamsArray []services.DownloadParams
+	for _, downloadParams := range downloadParamsArray {
+		if downloadParams.IsExplode() {
+			// The extracted archives aren't kept locally, so they can't be served from the cache.
+			cachedParamsArray = append(cachedParamsArray, downloadParams)
+			continue
+		}
+		resultItems, err := cd.collectFiles(downloadParams, searchConf)
+		if err == nil {
+			var itemsParams []services.DownloadParams
+			itemsParams, err = createItemsDownloadParams(downloadParams, resultItems)
+			cachedParamsArray = append(cachedParamsArray, itemsParams...)
+		}
+		if err != nil {
+			log.Debug("Couldn't search the files to download in the download cache:", err.Error())
+			cachedParamsArray = append(cachedParamsArray, downloadParams)
+		}
+	}
+	cd.extract()
+	return cd, cachedParamsArray
+}
+
+// Searches the items of the download params, and collects the files which may be served from the cache.
+func (cd *cachedDownload) collectFiles(downloadParams services.DownloadParams, searchConf *services.DownloadService) ([]serviceutils.ResultItem, error) {
+	resultItems, err := searchDownloadItems(downloadParams, searchConf)
+	if err != nil {
+		return nil, err
+	}
+	var files []*cachedFile
+	for _, item := range resultItems {
+		if item.Type == "folder" || item.Actual_Sha1 == "" || (downloadParams.IsSymlink() && isSymlinkItem(item)) {
+			continue
+		}
+		target, err := clientutils.BuildTargetPath(downloadParams.GetPattern(), item.GetItemRelativePath(), downloadParams.GetTarget(), true)
+		if err != nil {
+			return nil, err
+		}
+		localPath, localFileName := fileutils.GetLocalPathAndFile(item.Name, item.Path, target, downloadParams.IsFlat())
+		files = append(files, &cachedFile{localPath: filepath.Join(localPath, localFileName), sha1: item.Actual_Sha1, size: item.Size})
+	}
+	cd.files = append(cd.files, files...)
+	return resultItems, nil
+}
+
+// Creates the download params which download the given items, looked up by their exact paths.
+// The pattern and target of the original download params are kept, so that the items are downloaded to the same local paths.
+func createItemsDownloadParams(downloadParams services.DownloadParams, items []serviceutils.ResultItem) ([]services.DownloadParams, error) {
+	var itemsParamsArray []services.DownloadParams
+	for start := 0; start < len(items); start += cachedDownloadItemsPerQuery {
+		end := start + cachedDownloadItemsPerQuery
+		if end > len(items) {
+			end = len(items)
+		}
+		var itemsQuery []map[string]string
+		for _, item := range items[start:end] {
+			itemType := item.Type
+			if itemType == "" {
+				itemType = "file"
+			}
+			itemsQuery = append(itemsQuery, map[string]string{"repo": item.Repo, "path": item.Path, "name": item.Name, "type": itemType})
+		}
+		aql, err := json.Marshal(map[string]interface{}{"$or": itemsQuery})
+		if errorutils.CheckError(err) != nil {
+			return nil, err
+		}
+		itemsParams := downloadParams
+		itemsParams.ArtifactoryCommonParams = &serviceutils.ArtifactoryCommonParams{
+			Aql:         serviceutils.Aql{ItemsFind: string(aql)},
+			Pattern:     downloadParams.GetPattern(),
+			Target:      downloadParams.GetTarget(),
+			IncludeDirs: downloadParams.IsIncludeDirs(),
+		}
+		itemsParamsArray = append(itemsParamsArray, itemsParams)
+	}
+	return itemsParamsArray, nil
+}
+
+func isSymlinkItem(item serviceutils.ResultItem) bool {
+	for _, property := range item.Properties {
+		if property.Key == serviceutils.ARTIFACTORY_SYMLINK {
+			return true
+		}
+	}
+	return false
+}
+
+// Places the cached files in their local paths.
+// Files which already exist locally are left for jfrog-client-go to verify, and files which can't be placed are downloaded.
+func (cd *cachedDownload) extract() {
+	extractedCount := 0
+	for _, file := range cd.files {
+		if _, err := os.Lstat(file.localPath); err == nil {
+			continue
+		}
+		if cd.cache.Extract(file.sha1, file.size, file.localPath) {
+			log.Debug("Placed", file.localPath, "from the download cache.")
+			file.extracted = true
+			extractedCount++
+		}
+	}
+	if extractedCount > 0 {
+		log.Info("Found", extractedCount, "files in the download cache.")
+	}
+}
+
+// Adds the downloaded files to the cache, and evicts the least recently used files if the cache exceeds its maximum size.
+// The files were already downloaded, so failing to update the cache doesn't fail the download.
+func (cd *cachedDownload) addDownloadedFiles() {
+	for _, file := range cd.files {
+		if file.extracted {
+			continue
+		}
+		fileInfo, err := os.Lstat(file.localPath)
+		if err != nil || !fileInfo.Mode().IsRegular() || fileInfo.Size() != file.size {
+			continue
+		}
+		if err = cd.cache.Add(file.sha1, file.localPath); err != nil {
+			log.Warn("Couldn't add", file.localPath, "to the download cache:", err.Error())
+		}
+	}
+	if err := cd.cache.Evict(); err != nil {
+		log.Warn("Couldn't evict files from the download cache:", err.Error())
+	}
+}
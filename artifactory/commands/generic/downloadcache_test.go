@@ -0,0 +1,57 @@
+package generic
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/artifactory/spec"
+	"github.com/jfrog/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+const downloadCacheSearchResults = `{"results":[
+{"repo":"repo","path":".","name":"a.txt","type":"file","size":7,"actual_sha1":"040f06fd774092478d450774f5ba30c5da78acc8","actual_md5":"9a0364b9e99bb480dd25e1f0284c8555"},
+{"repo":"repo","path":"dir","name":"b.txt","type":"file","size":7,"actual_sha1":"040f06fd774092478d450774f5ba30c5da78acc8","actual_md5":"9a0364b9e99bb480dd25e1f0284c8555"}]}`
+
+func TestDownloadCache(t *testing.T) {
+	log.SetDefaultLogger()
+	defer setJfrogHomeDir(t)()
+	mock := newArtifactoryMock(downloadCacheSearchResults)
+	defer mock.server.Close()
+
+	download := func(workspace string, cache bool) []string {
+		mock.requests, mock.queries = nil, nil
+		downloadCommand := NewDownloadCommand()
+		downloadCommand.SetConfiguration(&utils.DownloadConfiguration{Threads: 2, Cache: cache}).SetBuildConfiguration(&utils.BuildConfiguration{})
+		downloadCommand.SetSpec(spec.NewBuilder().Pattern("repo/*").Target(workspace + "/").BuildSpec()).SetRtDetails(mock.rtDetails())
+		assert.NoError(t, downloadCommand.Run())
+		assert.Equal(t, 2, downloadCommand.Result().SuccessCount())
+		for _, path := range []string{"a.txt", filepath.Join("dir", "b.txt")} {
+			content, err := ioutil.ReadFile(filepath.Join(workspace, path))
+			assert.NoError(t, err)
+			assert.Equal(t, "content", string(content))
+		}
+		// The files to download are searched once. With the cache, jfrog-client-go looks up the found files by their paths.
+		patternSearches := 0
+		for _, query := range mock.queries {
+			if strings.Contains(query, `"$match":"*"`) {
+				patternSearches++
+			}
+		}
+		assert.Equal(t, 1, patternSearches, mock.queries)
+		return mock.sortedRequests()
+	}
+
+	tempDir, err := ioutil.TempDir("", "download-cache")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	// Downloads without the cache neither use nor fill it.
+	assert.Len(t, download(filepath.Join(tempDir, "workspace1"), false), 2)
+	assert.Len(t, download(filepath.Join(tempDir, "workspace2"), true), 2)
+	// The files downloaded to the previous workspace are served from the cache.
+	assert.Empty(t, download(filepath.Join(tempDir, "workspace3"), true))
+}
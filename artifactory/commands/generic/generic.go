@@ -1,6 +1,8 @@
 package generic
 
 import (
+	"time"
+
 	commandsutils "github.com/jfrog/jfrog-cli/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli/artifactory/spec"
 	"github.com/jfrog/jfrog-cli/utils/config"
@@ -51,6 +53,11 @@ func (gc *GenericCommand) Result() *commandsutils.Result {
 	return gc.result
 }
 
+// Records the time passed since startTime as the command duration. Deferred when the command starts.
+func (gc *GenericCommand) recordDuration(startTime time.Time) {
+	gc.result.SetDuration(time.Since(startTime))
+}
+
 func (gc *GenericCommand) Spec() *spec.SpecFiles {
 	return gc.spec
 }
@@ -1,12 +1,11 @@
 package generic
 
 import (
-	"errors"
+	"time"
 
 	"github.com/jfrog/jfrog-cli/artifactory/spec"
 	"github.com/jfrog/jfrog-cli/artifactory/utils"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
-	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
 type MoveCommand struct {
@@ -19,39 +18,13 @@ func NewMoveCommand() *MoveCommand {
 
 // Moves the artifacts using the specified move pattern.
 func (mc *MoveCommand) Run() error {
+	defer mc.recordDuration(time.Now())
 	// Create Service Manager:
 	servicesManager, err := utils.CreateServiceManager(mc.rtDetails, mc.DryRun())
 	if err != nil {
 		return err
 	}
-
-	var errorOccurred = false
-	// Move Loop:
-	for i := 0; i < len(mc.Spec().Files); i++ {
-
-		moveParams, err := getMoveParams(mc.Spec().Get(i))
-		if err != nil {
-			errorOccurred = true
-			log.Error(err)
-			continue
-		}
-
-		partialSuccess, partialFailed, err := servicesManager.Move(moveParams)
-		success := mc.result.SuccessCount() + partialSuccess
-		mc.result.SetSuccessCount(success)
-		failed := mc.result.FailCount() + partialFailed
-		mc.result.SetFailCount(failed)
-		if err != nil {
-			errorOccurred = true
-			log.Error(err)
-			continue
-		}
-	}
-	if errorOccurred {
-		return errors.New("Move finished with errors, please review the logs.")
-	}
-	return err
-
+	return runMoveCopy(servicesManager, &mc.GenericCommand, services.MOVE, getMoveParams)
 }
 
 func (mc *MoveCommand) CommandName() string {
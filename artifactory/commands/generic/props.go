@@ -60,6 +60,22 @@ func createPropsServiceManager(threads int, artDetails *config.ArtifactoryDetail
 	return artifactory.New(&artAuth, serviceConfig)
 }
 
+// Sets or deletes the properties of the items one by one, and records the result of each item for the detailed summary.
+func (pc *PropsCommand) runWithDetails(servicesManager *artifactory.ArtifactoryServicesManager, resultItems []clientutils.ResultItem, isDelete bool) (int, error) {
+	results, err := newDetailedResults()
+	if err != nil {
+		return 0, err
+	}
+	err = results.run(resultItems, pc.threads, createPropsTaskFunc(servicesManager, pc.props, isDelete))
+	reader, closeErr := results.close()
+	pc.result.SetReader(reader)
+	pc.result.AddFailures(results.failures...)
+	if err == nil {
+		err = closeErr
+	}
+	return results.successCount, err
+}
+
 func searchItems(spec *spec.SpecFiles, servicesManager *artifactory.ArtifactoryServicesManager) (resultItems []clientutils.ResultItem, err error) {
 	var errorOccurred = false
 	for i := 0; i < len(spec.Files); i++ {
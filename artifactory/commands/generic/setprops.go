@@ -33,8 +33,13 @@ func (setProps *SetPropsCommand) Run() error {
 
 	resultItems, searchErr := searchItems(setProps.Spec(), servicesManager)
 
-	propsParams := GetPropsParams(resultItems, setProps.props)
-	success, err := servicesManager.SetProps(propsParams)
+	var success int
+	if setProps.DetailedSummary() {
+		success, err = setProps.runWithDetails(servicesManager, resultItems, false)
+	} else {
+		propsParams := GetPropsParams(resultItems, setProps.props)
+		success, err = servicesManager.SetProps(propsParams)
+	}
 
 	result := setProps.Result()
 	result.SetSuccessCount(success)
@@ -0,0 +1,97 @@
+package generic
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/jfrog/jfrog-cli/artifactory/spec"
+	"github.com/jfrog/jfrog-cli/utils/config"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Validates a File Spec offline, and prints the AQL query each of its file groups generates.
+type SpecValidateCommand struct {
+	specFilePath string
+	specVars     map[string]string
+}
+
+func NewSpecValidateCommand() *SpecValidateCommand {
+	return &SpecValidateCommand{}
+}
+
+func (svc *SpecValidateCommand) SetSpecFilePath(specFilePath string) *SpecValidateCommand {
+	svc.specFilePath = specFilePath
+	return svc
+}
+
+func (svc *SpecValidateCommand) SetSpecVars(specVars map[string]string) *SpecValidateCommand {
+	svc.specVars = specVars
+	return svc
+}
+
+func (svc *SpecValidateCommand) CommandName() string {
+	return "rt_spec_validate"
+}
+
+// The command works offline, so it has no Artifactory server to report its usage to.
+func (svc *SpecValidateCommand) RtDetails() (*config.ArtifactoryDetails, error) {
+	return nil, nil
+}
+
+func (svc *SpecValidateCommand) Run() error {
+	specFiles, issues, err := spec.LintSpecFile(svc.specFilePath, svc.specVars)
+	if err != nil {
+		return err
+	}
+	errorsCount := 0
+	for _, issue := range issues {
+		if issue.Severity == spec.IssueError {
+			errorsCount++
+			log.Error(issue.String())
+		} else {
+			log.Warn(issue.String())
+		}
+	}
+	for i, file := range specFiles.Files {
+		query, err := explainFileGroupQuery(file)
+		if err != nil {
+			query = "The query can't be created: " + err.Error()
+		}
+		log.Output(fmt.Sprintf("File group %d:\n%s", i+1, query))
+	}
+	if errorsCount > 0 {
+		return errorutils.CheckError(errors.New("The File Spec has " + strconv.Itoa(errorsCount) + " errors."))
+	}
+	log.Info("The File Spec is valid.")
+	return nil
+}
+
+// Returns the AQL query which the commands searching Artifactory, such as download and search, run for the file group.
+func explainFileGroupQuery(file spec.File) (string, error) {
+	params := file.ToArtifactoryCommonParams()
+	var err error
+	if params.Recursive, err = file.IsRecursive(true); err != nil {
+		return "", err
+	}
+	if params.IncludeDirs, err = file.IsIncludeDirs(false); err != nil {
+		return "", err
+	}
+	switch params.GetSpecType() {
+	case serviceutils.BUILD:
+		return "The artifacts of build " + params.Build + ", as listed in its build-info in Artifactory.", nil
+	case serviceutils.WILDCARD:
+		aqlBody, err := serviceutils.CreateAqlBodyForSpecWithPattern(params)
+		if err != nil {
+			return "", err
+		}
+		params.Aql = serviceutils.Aql{ItemsFind: aqlBody}
+	}
+	query := serviceutils.BuildQueryFromSpecFile(params, serviceutils.NONE)
+	if params.Build != "" {
+		query += "\nThe results are filtered by the artifacts of build " + params.Build + "."
+	}
+	return query, nil
+}
@@ -0,0 +1,31 @@
+package generic
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/artifactory/spec"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplainFileGroupQuery(t *testing.T) {
+	include := `.include("name","repo","path","actual_md5","actual_sha1","size","type","modified","created")`
+	tests := []struct {
+		name     string
+		file     spec.File
+		expected string
+	}{
+		{"pattern", spec.File{Pattern: "repo/dir/*.zip", Recursive: "false"},
+			`items.find({"path":{"$ne":"."},"$or":[{"$and":[{"repo":"repo","path":"dir","name":{"$match":"*.zip"}}]}]})` + include},
+		{"aql", spec.File{Aql: serviceutils.Aql{ItemsFind: `{"repo":"repo"}`}, SortBy: []string{"name"}, Limit: 2},
+			`items.find({"repo":"repo"})` + include + `.sort({"$asc":["name"]}).limit(2)`},
+		{"build", spec.File{Build: "name/1"}, "The artifacts of build name/1, as listed in its build-info in Artifactory."},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, err := explainFileGroupQuery(test.file)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, query)
+		})
+	}
+}
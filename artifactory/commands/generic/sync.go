@@ -0,0 +1,371 @@
+package generic
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli/artifactory/spec"
+	"github.com/jfrog/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	logUtils "github.com/jfrog/jfrog-cli/utils/log"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type SyncDirection string
+
+const (
+	// Files missing on either side are copied to the other side. Files which differ are reported as conflicts.
+	SyncBoth SyncDirection = "both"
+	// The local directory is the source. New and changed files are uploaded.
+	SyncUpload SyncDirection = "upload"
+	// The repository path is the source. New and changed files are downloaded.
+	SyncDownload SyncDirection = "download"
+)
+
+// The changes needed to sync a local directory and a repository path.
+// The files are listed by their paths relative to the synced directories.
+type SyncPlan struct {
+	Upload       []string `json:"upload,omitempty"`
+	Download     []string `json:"download,omitempty"`
+	DeleteRemote []string `json:"deleteRemote,omitempty"`
+	DeleteLocal  []string `json:"deleteLocal,omitempty"`
+	Conflicts    []string `json:"conflicts,omitempty"`
+}
+
+func (plan *SyncPlan) IsEmpty() bool {
+	return len(plan.Upload)+len(plan.Download)+len(plan.DeleteRemote)+len(plan.DeleteLocal)+len(plan.Conflicts) == 0
+}
+
+// Syncs a local directory and a repository path, by comparing the checksums of their files.
+// The changes are applied by the upload, download and delete commands.
+type SyncCommand struct {
+	GenericCommand
+	localPath        string
+	repoPath         string
+	direction        SyncDirection
+	deleteExtraneous bool
+	planOnly         bool
+	threads          int
+	retries          int
+	plan             *SyncPlan
+}
+
+func NewSyncCommand() *SyncCommand {
+	return &SyncCommand{GenericCommand: *NewGenericCommand(), direction: SyncBoth}
+}
+
+func (sc *SyncCommand) SetLocalPath(localPath string) *SyncCommand {
+	sc.localPath = localPath
+	return sc
+}
+
+func (sc *SyncCommand) SetRepoPath(repoPath string) *SyncCommand {
+	sc.repoPath = strings.Trim(repoPath, "/")
+	return sc
+}
+
+func (sc *SyncCommand) SetDirection(direction SyncDirection) *SyncCommand {
+	sc.direction = direction
+	return sc
+}
+
+func (sc *SyncCommand) SetDeleteExtraneous(deleteExtraneous bool) *SyncCommand {
+	sc.deleteExtraneous = deleteExtraneous
+	return sc
+}
+
+func (sc *SyncCommand) SetPlanOnly(planOnly bool) *SyncCommand {
+	sc.planOnly = planOnly
+	return sc
+}
+
+func (sc *SyncCommand) SetThreads(threads int) *SyncCommand {
+	sc.threads = threads
+	return sc
+}
+
+func (sc *SyncCommand) SetRetries(retries int) *SyncCommand {
+	sc.retries = retries
+	return sc
+}
+
+func (sc *SyncCommand) Plan() *SyncPlan {
+	return sc.plan
+}
+
+func (sc *SyncCommand) CommandName() string {
+	return "rt_sync"
+}
+
+func (sc *SyncCommand) Run() error {
+	if sc.deleteExtraneous && sc.direction == SyncBoth {
+		return errorutils.CheckError(errors.New("Extraneous files can be deleted only when syncing in one direction, upload or download."))
+	}
+	defer sc.recordDuration(time.Now())
+	var err error
+	if sc.plan, err = sc.createPlan(); err != nil {
+		return err
+	}
+	if sc.planOnly {
+		content, err := json.Marshal(sc.plan)
+		if errorutils.CheckError(err) != nil {
+			return err
+		}
+		log.Output(clientutils.IndentJson(content))
+		return nil
+	}
+	if sc.plan.IsEmpty() {
+		log.Info("The local directory and the repository path are in sync.")
+		return nil
+	}
+	for _, conflict := range sc.plan.Conflicts {
+		log.Warn("Skipping", conflict+", which differs between the local directory and Artifactory.")
+	}
+	if len(sc.plan.DeleteRemote)+len(sc.plan.DeleteLocal) > 0 && !sc.Quiet() &&
+		!cliutils.InteractiveConfirm("The sync will delete "+sc.describeDeletes()+". Are you sure you want to continue?\n"+
+			"You can avoid this confirmation message by adding --quiet to the command.", false) {
+		return nil
+	}
+	return sc.applyPlan()
+}
+
+func (sc *SyncCommand) describeDeletes() string {
+	var deletes []string
+	if len(sc.plan.DeleteRemote) > 0 {
+		deletes = append(deletes, "files in Artifactory")
+	}
+	if len(sc.plan.DeleteLocal) > 0 {
+		deletes = append(deletes, "local files")
+	}
+	return strings.Join(deletes, " and ")
+}
+
+// Lists the files on both sides, and compares them by their SHA-1 checksums.
+func (sc *SyncCommand) createPlan() (*SyncPlan, error) {
+	localFiles, err := sc.listLocalFiles()
+	if err != nil {
+		return nil, err
+	}
+	remoteFiles, err := sc.listRemoteFiles()
+	if err != nil {
+		return nil, err
+	}
+	plan := new(SyncPlan)
+	for relativePath, localSha1 := range localFiles {
+		remoteSha1, exists := remoteFiles[relativePath]
+		switch {
+		case !exists && sc.direction != SyncDownload:
+			plan.Upload = append(plan.Upload, relativePath)
+		case !exists && sc.deleteExtraneous:
+			plan.DeleteLocal = append(plan.DeleteLocal, relativePath)
+		case !exists || remoteSha1 == localSha1:
+		case sc.direction == SyncUpload:
+			plan.Upload = append(plan.Upload, relativePath)
+		case sc.direction == SyncDownload:
+			plan.Download = append(plan.Download, relativePath)
+		default:
+			plan.Conflicts = append(plan.Conflicts, relativePath)
+		}
+	}
+	for relativePath := range remoteFiles {
+		if _, exists := localFiles[relativePath]; exists {
+			continue
+		}
+		if sc.direction != SyncUpload {
+			plan.Download = append(plan.Download, relativePath)
+		} else if sc.deleteExtraneous {
+			plan.DeleteRemote = append(plan.DeleteRemote, relativePath)
+		}
+	}
+	for _, paths := range [][]string{plan.Upload, plan.Download, plan.DeleteRemote, plan.DeleteLocal, plan.Conflicts} {
+		sort.Strings(paths)
+	}
+	return plan, nil
+}
+
+// Returns the SHA-1 checksums of the local files, by their slash-separated paths relative to the local directory.
+func (sc *SyncCommand) listLocalFiles() (map[string]string, error) {
+	files := make(map[string]string)
+	if _, err := os.Stat(sc.localPath); os.IsNotExist(err) {
+		return files, nil
+	}
+	err := filepath.Walk(sc.localPath, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			if !info.IsDir() {
+				log.Debug("Skipping", filePath+", which is not a regular file.")
+			}
+			return nil
+		}
+		details, err := fileutils.GetFileDetails(filePath)
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(sc.localPath, filePath)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(relativePath)] = details.Checksum.Sha1
+		return nil
+	})
+	return files, errorutils.CheckError(err)
+}
+
+// Returns the SHA-1 checksums of the files under the repository path, by their paths relative to the repository path.
+func (sc *SyncCommand) listRemoteFiles() (map[string]string, error) {
+	searchCmd := NewSearchCommand()
+	searchCmd.SetRtDetails(sc.rtDetails).SetSpec(spec.NewBuilder().Pattern(sc.repoPath + "/*").Recursive(true).BuildSpec())
+	if err := searchCmd.Search(); err != nil {
+		return nil, err
+	}
+	files := make(map[string]string)
+	for _, result := range searchCmd.SearchResult() {
+		if result.Type == "folder" {
+			continue
+		}
+		files[strings.TrimPrefix(result.Path, sc.repoPath+"/")] = result.Sha1
+	}
+	return files, nil
+}
+
+func (sc *SyncCommand) applyPlan() error {
+	var errs []string
+	for _, apply := range []func() error{sc.upload, sc.download, sc.deleteRemote, sc.deleteLocal} {
+		if err := apply(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return errorutils.CheckError(errors.New(strings.Join(errs, "\n")))
+	}
+	return nil
+}
+
+func (sc *SyncCommand) upload() error {
+	if len(sc.plan.Upload) == 0 {
+		return nil
+	}
+	uploadSpec := new(spec.SpecFiles)
+	for _, relativePath := range sc.plan.Upload {
+		uploadSpec.Files = append(uploadSpec.Files, spec.File{
+			Pattern: filepath.Join(sc.localPath, filepath.FromSlash(relativePath)),
+			Target:  sc.repoPath + "/" + relativePath,
+			Flat:    "true",
+		})
+	}
+	uploadCmd := NewUploadCommand()
+	uploadCmd.SetUploadConfiguration(&utils.UploadConfiguration{Threads: sc.threads, Retries: sc.retries}).
+		SetBuildConfiguration(&utils.BuildConfiguration{}).SetSpec(uploadSpec).SetRtDetails(sc.rtDetails)
+	err := uploadCmd.Run()
+	logUtils.CloseLogFile(uploadCmd.LogFile())
+	sc.addResult(uploadCmd.Result().SuccessCount(), uploadCmd.Result().FailCount())
+	return err
+}
+
+// Downloads the files of each remote directory with a single AQL query.
+func (sc *SyncCommand) download() error {
+	if len(sc.plan.Download) == 0 {
+		return nil
+	}
+	filesByDir := make(map[string][]string)
+	var dirs []string
+	for _, relativePath := range sc.plan.Download {
+		dir, name := path.Split(relativePath)
+		if _, exists := filesByDir[dir]; !exists {
+			dirs = append(dirs, dir)
+		}
+		filesByDir[dir] = append(filesByDir[dir], name)
+	}
+	downloadSpec := new(spec.SpecFiles)
+	for _, dir := range dirs {
+		aql, err := createDirFilesAql(sc.repoPath+"/"+dir, filesByDir[dir])
+		if err != nil {
+			return err
+		}
+		downloadSpec.Files = append(downloadSpec.Files, spec.File{
+			Aql:    serviceutils.Aql{ItemsFind: aql},
+			Target: filepath.Join(sc.localPath, filepath.FromSlash(dir)) + string(filepath.Separator),
+			Flat:   "true",
+		})
+	}
+	downloadCmd := NewDownloadCommand()
+	downloadCmd.SetConfiguration(&utils.DownloadConfiguration{Threads: sc.threads, Retries: sc.retries, Symlink: true}).
+		SetBuildConfiguration(&utils.BuildConfiguration{}).SetSpec(downloadSpec).SetRtDetails(sc.rtDetails)
+	err := downloadCmd.Run()
+	logUtils.CloseLogFile(downloadCmd.LogFile())
+	sc.addResult(downloadCmd.Result().SuccessCount(), downloadCmd.Result().FailCount())
+	return err
+}
+
+// Creates the AQL query of the files with the given names, in the given Artifactory directory.
+func createDirFilesAql(dirPath string, names []string) (string, error) {
+	dirPath = strings.TrimSuffix(dirPath, "/")
+	repo, itemPath := dirPath, "."
+	if i := strings.Index(dirPath, "/"); i >= 0 {
+		repo, itemPath = dirPath[:i], dirPath[i+1:]
+	}
+	var namesQuery []map[string]string
+	for _, name := range names {
+		namesQuery = append(namesQuery, map[string]string{"name": name})
+	}
+	aql, err := json.Marshal(map[string]interface{}{"repo": repo, "path": itemPath, "type": "file", "$or": namesQuery})
+	return string(aql), errorutils.CheckError(err)
+}
+
+func (sc *SyncCommand) deleteRemote() error {
+	if len(sc.plan.DeleteRemote) == 0 {
+		return nil
+	}
+	var deleteItems []serviceutils.ResultItem
+	for _, relativePath := range sc.plan.DeleteRemote {
+		fullPath := sc.repoPath + "/" + relativePath
+		item := serviceutils.ResultItem{Type: "file"}
+		item.Repo = fullPath[:strings.Index(fullPath, "/")]
+		dir, name := path.Split(fullPath[len(item.Repo)+1:])
+		item.Path, item.Name = strings.TrimSuffix(dir, "/"), name
+		if item.Path == "" {
+			item.Path = "."
+		}
+		deleteItems = append(deleteItems, item)
+	}
+	deleteCmd := NewDeleteCommand()
+	deleteCmd.SetThreads(sc.threads).SetDeleteItems(deleteItems).SetRtDetails(sc.rtDetails)
+	successCount, failCount, err := deleteCmd.DeleteFiles()
+	sc.addResult(successCount, failCount)
+	return err
+}
+
+func (sc *SyncCommand) deleteLocal() error {
+	var errs []string
+	for _, relativePath := range sc.plan.DeleteLocal {
+		localPath := filepath.Join(sc.localPath, filepath.FromSlash(relativePath))
+		log.Info("Deleting:", localPath)
+		if err := os.Remove(localPath); err != nil {
+			errs = append(errs, err.Error())
+			sc.addResult(0, 1)
+			continue
+		}
+		sc.addResult(1, 0)
+	}
+	if len(errs) > 0 {
+		return errorutils.CheckError(errors.New(strings.Join(errs, "\n")))
+	}
+	return nil
+}
+
+func (sc *SyncCommand) addResult(successCount, failCount int) {
+	sc.result.SetSuccessCount(sc.result.SuccessCount() + successCount)
+	sc.result.SetFailCount(sc.result.FailCount() + failCount)
+}
@@ -0,0 +1,69 @@
+package generic
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+// The checksum of the files in Artifactory is the checksum of "content".
+const syncSearchResults = `{"results":[
+{"repo":"repo","path":"dir","name":"same.txt","type":"file","size":7,"actual_sha1":"040f06fd774092478d450774f5ba30c5da78acc8"},
+{"repo":"repo","path":"dir","name":"changed.txt","type":"file","size":7,"actual_sha1":"040f06fd774092478d450774f5ba30c5da78acc8"},
+{"repo":"repo","path":"dir","name":"remote-only.txt","type":"file","size":7,"actual_sha1":"040f06fd774092478d450774f5ba30c5da78acc8"},
+{"repo":"repo","path":"dir/nested","name":"remote-only.txt","type":"file","size":7,"actual_sha1":"040f06fd774092478d450774f5ba30c5da78acc8"}]}`
+
+func TestSync(t *testing.T) {
+	log.SetDefaultLogger()
+	mock := newArtifactoryMock(syncSearchResults)
+	defer mock.server.Close()
+	localDir, err := ioutil.TempDir("", "sync")
+	assert.NoError(t, err)
+	defer os.RemoveAll(localDir)
+	for name, content := range map[string]string{"same.txt": "content", "changed.txt": "changed content", "local-only.txt": "content"} {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(localDir, name), []byte(content), 0644))
+	}
+	newSyncCommand := func(direction SyncDirection, deleteExtraneous bool) *SyncCommand {
+		syncCmd := NewSyncCommand().SetLocalPath(localDir).SetRepoPath("repo/dir/").SetDirection(direction).SetDeleteExtraneous(deleteExtraneous).SetThreads(1)
+		syncCmd.SetQuiet(true).SetRtDetails(mock.rtDetails())
+		return syncCmd
+	}
+
+	plans := []struct {
+		direction        SyncDirection
+		deleteExtraneous bool
+		expected         SyncPlan
+	}{
+		{SyncBoth, false, SyncPlan{Upload: []string{"local-only.txt"}, Download: []string{"nested/remote-only.txt", "remote-only.txt"}, Conflicts: []string{"changed.txt"}}},
+		{SyncUpload, false, SyncPlan{Upload: []string{"changed.txt", "local-only.txt"}}},
+		{SyncUpload, true, SyncPlan{Upload: []string{"changed.txt", "local-only.txt"}, DeleteRemote: []string{"nested/remote-only.txt", "remote-only.txt"}}},
+		{SyncDownload, true, SyncPlan{Download: []string{"changed.txt", "nested/remote-only.txt", "remote-only.txt"}, DeleteLocal: []string{"local-only.txt"}}},
+	}
+	for _, plan := range plans {
+		syncCmd := newSyncCommand(plan.direction, plan.deleteExtraneous).SetPlanOnly(true)
+		assert.NoError(t, syncCmd.Run())
+		assert.Equal(t, plan.expected, *syncCmd.Plan(), plan.direction)
+	}
+	assert.Empty(t, mock.requests, "Planning should not change anything")
+
+	assert.Error(t, newSyncCommand(SyncBoth, true).Run(), "Deleting extraneous files should require a direction")
+
+	syncCmd := newSyncCommand(SyncUpload, true)
+	assert.NoError(t, syncCmd.Run())
+	assert.Equal(t, []string{"DELETE /repo/dir/nested/remote-only.txt", "DELETE /repo/dir/remote-only.txt",
+		"PUT /repo/dir/changed.txt", "PUT /repo/dir/local-only.txt"}, trimRequestsProps(mock.sortedRequests()))
+	assert.Equal(t, 4, syncCmd.Result().SuccessCount())
+	assert.Equal(t, 0, syncCmd.Result().FailCount())
+}
+
+func trimRequestsProps(requests []string) (trimmed []string) {
+	for _, request := range requests {
+		trimmed = append(trimmed, strings.Split(request, ";")[0])
+	}
+	return
+}
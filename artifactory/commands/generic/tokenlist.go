@@ -0,0 +1,47 @@
+package generic
+
+import (
+	"encoding/json"
+
+	rtUtils "github.com/jfrog/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Lists the access tokens created by the authenticated user, or all access tokens if the user is an administrator.
+type TokenListCommand struct {
+	rtDetails *config.ArtifactoryDetails
+	response  *services.GetTokensResponseData
+}
+
+func NewTokenListCommand() *TokenListCommand {
+	return &TokenListCommand{response: new(services.GetTokensResponseData)}
+}
+
+func (tlc *TokenListCommand) SetRtDetails(rtDetails *config.ArtifactoryDetails) *TokenListCommand {
+	tlc.rtDetails = rtDetails
+	return tlc
+}
+
+func (tlc *TokenListCommand) Response() ([]byte, error) {
+	content, err := json.Marshal(tlc.response.Tokens)
+	return content, errorutils.CheckError(err)
+}
+
+func (tlc *TokenListCommand) RtDetails() (*config.ArtifactoryDetails, error) {
+	return tlc.rtDetails, nil
+}
+
+func (tlc *TokenListCommand) CommandName() string {
+	return "rt_token_list"
+}
+
+func (tlc *TokenListCommand) Run() error {
+	servicesManager, err := rtUtils.CreateServiceManager(tlc.rtDetails, false)
+	if err != nil {
+		return err
+	}
+	*tlc.response, err = servicesManager.GetTokens()
+	return err
+}
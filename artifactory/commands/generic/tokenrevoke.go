@@ -0,0 +1,89 @@
+package generic
+
+import (
+	"errors"
+
+	rtUtils "github.com/jfrog/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Revokes an access token by its ID, or the token of a token profile.
+// The revoked token profile is removed from the configuration of the server.
+type TokenRevokeCommand struct {
+	rtDetails *config.ArtifactoryDetails
+	tokenId   string
+	profile   string
+}
+
+func NewTokenRevokeCommand() *TokenRevokeCommand {
+	return &TokenRevokeCommand{}
+}
+
+func (trc *TokenRevokeCommand) SetRtDetails(rtDetails *config.ArtifactoryDetails) *TokenRevokeCommand {
+	trc.rtDetails = rtDetails
+	return trc
+}
+
+func (trc *TokenRevokeCommand) SetTokenId(tokenId string) *TokenRevokeCommand {
+	trc.tokenId = tokenId
+	return trc
+}
+
+func (trc *TokenRevokeCommand) SetProfile(profile string) *TokenRevokeCommand {
+	trc.profile = profile
+	return trc
+}
+
+func (trc *TokenRevokeCommand) RtDetails() (*config.ArtifactoryDetails, error) {
+	return trc.rtDetails, nil
+}
+
+func (trc *TokenRevokeCommand) CommandName() string {
+	return "rt_token_revoke"
+}
+
+func (trc *TokenRevokeCommand) Run() error {
+	revokeParams := services.NewRevokeTokenParams()
+	revokeParams.TokenId = trc.tokenId
+	if trc.profile != "" {
+		serverConfiguration, err := config.GetArtifactorySpecificConfig(trc.rtDetails.ServerId, true, false)
+		if err != nil {
+			return err
+		}
+		profile := serverConfiguration.GetTokenProfile(trc.profile)
+		if profile == nil {
+			return errorutils.CheckError(errors.New("the server '" + serverConfiguration.ServerId + "' has no token profile named '" + trc.profile + "'"))
+		}
+		// An expired token can't be revoked, so the profile is only removed.
+		if timeLeft, err := auth.GetTokenMinutesLeft(profile.AccessToken); err == nil && timeLeft <= 0 {
+			log.Info("The token of the token profile '" + trc.profile + "' expired.")
+			return trc.removeProfile()
+		}
+		revokeParams.Token = profile.AccessToken
+	}
+	servicesManager, err := rtUtils.CreateServiceManager(trc.rtDetails, false)
+	if err != nil {
+		return err
+	}
+	response, err := servicesManager.RevokeToken(revokeParams)
+	if err != nil {
+		return err
+	}
+	log.Info(response)
+	if trc.profile != "" {
+		return trc.removeProfile()
+	}
+	return nil
+}
+
+func (trc *TokenRevokeCommand) removeProfile() error {
+	if _, err := config.RemoveTokenProfile(trc.rtDetails.ServerId, trc.profile); err != nil {
+		return err
+	}
+	log.Info("The token profile '" + trc.profile + "' was removed.")
+	return nil
+}
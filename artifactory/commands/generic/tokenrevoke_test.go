@@ -0,0 +1,36 @@
+package generic
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenRevokeProfile(t *testing.T) {
+	log.SetDefaultLogger()
+	defer setJfrogHomeDir(t)()
+	mock := newArtifactoryMock("")
+	defer mock.server.Close()
+
+	rtDetails := mock.rtDetails()
+	rtDetails.ServerId = "server"
+	rtDetails.TokenProfiles = []*config.TokenProfile{
+		{Name: "deploy", Scope: "member-of-groups:deployers", AccessToken: "deployAccessToken", RefreshToken: "deployRefreshToken"},
+		{Name: "read", Scope: "member-of-groups:readers", AccessToken: "readAccessToken", RefreshToken: "readRefreshToken"},
+	}
+	assert.NoError(t, config.SaveArtifactoryConf([]*config.ArtifactoryDetails{rtDetails}))
+
+	// Revoke a missing profile.
+	assert.Error(t, NewTokenRevokeCommand().SetRtDetails(rtDetails).SetProfile("missing").Run())
+	assert.Empty(t, mock.requests)
+
+	assert.NoError(t, NewTokenRevokeCommand().SetRtDetails(rtDetails).SetProfile("deploy").Run())
+	assert.Equal(t, []string{"POST /api/security/token/revoke"}, mock.sortedRequests())
+
+	savedDetails, err := config.GetArtifactorySpecificConfig("server", false, false)
+	assert.NoError(t, err)
+	assert.Nil(t, savedDetails.GetTokenProfile("deploy"))
+	assert.NotNil(t, savedDetails.GetTokenProfile("read"))
+}
@@ -0,0 +1,114 @@
+package generic
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+)
+
+// Collects the transferred bytes and the upload attempts of the files uploaded or downloaded by jfrog-client-go.
+// The collector wraps the progress bar, which jfrog-client-go notifies whenever it starts sending or receiving the content of a file.
+// An upload notifies it in every attempt, so the attempts of each uploaded file are counted.
+type transferCollector struct {
+	// Updated atomically. Kept first in the struct for 64-bit alignment.
+	transferredBytes int64
+	progress         ioUtils.Progress
+	// The number of times the content of each file started to be transferred, by the path reported to the progress bar.
+	attempts map[string]int
+	// The paths in the order they were first reported.
+	paths []string
+	mutex sync.Mutex
+}
+
+// The progress bar may be nil.
+func newTransferCollector(progress ioUtils.Progress) *transferCollector {
+	return &transferCollector{progress: progress, attempts: make(map[string]int)}
+}
+
+func (tc *transferCollector) bytes() int64 {
+	return atomic.LoadInt64(&tc.transferredBytes)
+}
+
+// Returns the total number of retried uploads.
+func (tc *transferCollector) retries() (retries int) {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+	for _, path := range tc.paths {
+		retries += tc.attempts[path] - 1
+	}
+	return
+}
+
+// Returns the failures of the files whose content was sent, but which are missing in the uploaded files returned by jfrog-client-go.
+// jfrog-client-go doesn't return the responses of the failed uploads, so their reasons are reported in the logs only.
+func (tc *transferCollector) uploadFailures(uploaded []clientutils.FileInfo) (failures []summary.FileFailure) {
+	uploadedPaths := make(map[string]bool, len(uploaded))
+	for _, fileInfo := range uploaded {
+		uploadedPaths[fileInfo.LocalPath] = true
+	}
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+	for _, path := range tc.paths {
+		if !uploadedPaths[path] {
+			failures = append(failures, summary.FileFailure{Path: path, Error: "The file was not uploaded, please review the logs.", Attempts: tc.attempts[path]})
+		}
+	}
+	return
+}
+
+func (tc *transferCollector) New(total int64, prefix, path string) (id int) {
+	tc.mutex.Lock()
+	if tc.attempts[path] == 0 {
+		tc.paths = append(tc.paths, path)
+	}
+	tc.attempts[path]++
+	tc.mutex.Unlock()
+	if tc.progress == nil {
+		return 0
+	}
+	return tc.progress.New(total, prefix, path)
+}
+
+func (tc *transferCollector) NewReplacement(replaceId int, prefix, path string) (id int) {
+	if tc.progress == nil {
+		return 0
+	}
+	return tc.progress.NewReplacement(replaceId, prefix, path)
+}
+
+func (tc *transferCollector) ReadWithProgress(id int, reader io.Reader) io.Reader {
+	if reader == nil {
+		return nil
+	}
+	if tc.progress != nil {
+		reader = tc.progress.ReadWithProgress(id, reader)
+	}
+	return &bytesCountingReader{Reader: reader, collector: tc}
+}
+
+func (tc *transferCollector) Abort(id int) {
+	if tc.progress != nil {
+		tc.progress.Abort(id)
+	}
+}
+
+func (tc *transferCollector) Quit() {
+	if tc.progress != nil {
+		tc.progress.Quit()
+	}
+}
+
+type bytesCountingReader struct {
+	io.Reader
+	collector *transferCollector
+}
+
+func (reader *bytesCountingReader) Read(p []byte) (n int, err error) {
+	n, err = reader.Reader.Read(p)
+	atomic.AddInt64(&reader.collector.transferredBytes, int64(n))
+	return
+}
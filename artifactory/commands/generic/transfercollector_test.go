@@ -0,0 +1,97 @@
+package generic
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/artifactory/spec"
+	"github.com/jfrog/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/utils/log"
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferCollector(t *testing.T) {
+	collector := newTransferCollector(nil)
+	// a.txt is uploaded in its second attempt, b.txt in its first one, and c.txt fails after three attempts.
+	for _, path := range []string{"a.txt", "b.txt", "a.txt", "c.txt", "c.txt", "c.txt"} {
+		id := collector.New(int64(len("content")), "Uploading", path)
+		ioutil.ReadAll(collector.ReadWithProgress(id, strings.NewReader("content")))
+		collector.Abort(id)
+	}
+
+	assert.Equal(t, int64(6*len("content")), collector.bytes())
+	assert.Equal(t, 1+2, collector.retries())
+	failures := collector.uploadFailures([]clientutils.FileInfo{{LocalPath: "a.txt"}, {LocalPath: "b.txt"}})
+	if assert.Len(t, failures, 1) {
+		assert.Equal(t, "c.txt", failures[0].Path)
+		assert.Zero(t, failures[0].StatusCode)
+		assert.NotEmpty(t, failures[0].Error)
+		assert.Equal(t, 3, failures[0].Attempts)
+	}
+}
+
+func TestUploadFailures(t *testing.T) {
+	log.SetDefaultLogger()
+	defer setJfrogHomeDir(t)()
+	mock := newArtifactoryMock("")
+	defer mock.server.Close()
+	sourceDir, err := ioutil.TempDir("", "upload")
+	assert.NoError(t, err)
+	defer os.RemoveAll(sourceDir)
+	for _, name := range []string{"a.txt", "fail.txt", "unavailable.txt"} {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(sourceDir, name), []byte("content"), 0644))
+	}
+
+	uploadCommand := NewUploadCommand()
+	uploadCommand.SetUploadConfiguration(&utils.UploadConfiguration{Threads: 1, Retries: 1}).SetBuildConfiguration(&utils.BuildConfiguration{})
+	uploadCommand.SetSpec(spec.NewBuilder().Pattern(filepath.Join(sourceDir, "*")).Target("repo/").BuildSpec()).SetRtDetails(mock.rtDetails())
+	assert.NoError(t, uploadCommand.Run())
+	result := uploadCommand.Result()
+	assert.Equal(t, 1, result.SuccessCount())
+	assert.Equal(t, 2, result.FailCount())
+	// The unavailable file is uploaded twice.
+	assert.Equal(t, int64(4*len("content")), result.TransferredBytes())
+	assert.Equal(t, 1, result.Retries())
+	assert.NotZero(t, result.Duration())
+
+	failures := result.Failures()
+	if !assert.Len(t, failures, 2) {
+		return
+	}
+	failuresByPath := map[string]int{failures[0].Path: 0, failures[1].Path: 1}
+	notFound := failures[failuresByPath[filepath.Join(sourceDir, "fail.txt")]]
+	assert.Equal(t, 1, notFound.Attempts)
+	unavailable := failures[failuresByPath[filepath.Join(sourceDir, "unavailable.txt")]]
+	assert.Equal(t, 2, unavailable.Attempts)
+}
+
+func TestDownloadFailures(t *testing.T) {
+	log.SetDefaultLogger()
+	defer setJfrogHomeDir(t)()
+	mock := newArtifactoryMock(`{"results":[{"repo":"repo","path":"dir","name":"a.txt","type":"file","size":7},{"repo":"repo","path":"dir","name":"fail.txt","type":"file","size":7}]}`)
+	defer mock.server.Close()
+	targetDir, err := ioutil.TempDir("", "download")
+	assert.NoError(t, err)
+	defer os.RemoveAll(targetDir)
+
+	downloadCommand := NewDownloadCommand()
+	downloadCommand.SetConfiguration(&utils.DownloadConfiguration{Threads: 1, SplitCount: 0, MinSplitSize: 5120}).SetBuildConfiguration(&utils.BuildConfiguration{})
+	downloadCommand.SetSpec(spec.NewBuilder().Pattern("repo/dir/*").Target(targetDir + "/").Flat(true).BuildSpec()).SetRtDetails(mock.rtDetails())
+	assert.Error(t, downloadCommand.Run())
+	result := downloadCommand.Result()
+	assert.Equal(t, 1, result.SuccessCount())
+	assert.Equal(t, 1, result.FailCount())
+	assert.Equal(t, int64(len("content")), result.TransferredBytes())
+	// The reader of the results is kept only for a detailed summary.
+	assert.Nil(t, result.Reader())
+
+	failures := result.Failures()
+	if assert.Len(t, failures, 1) {
+		assert.Equal(t, "repo/dir/fail.txt", failures[0].Path)
+		assert.NotEmpty(t, failures[0].Error)
+	}
+}
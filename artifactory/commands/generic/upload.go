@@ -69,6 +69,7 @@ func (uc *UploadCommand) upload() error {
 		timestamp := strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10)
 		syncDeletesProp = ";sync.deletes.timestamp=" + timestamp
 	}
+	defer uc.recordDuration(time.Now())
 	// Initialize Progress bar, set logger to a log file
 	var err error
 	var progressBar ioUtils.Progress
@@ -90,7 +91,16 @@ func (uc *UploadCommand) upload() error {
 	if errorutils.CheckError(err) != nil {
 		return err
 	}
-	servicesManager, err := utils.CreateUploadServiceManager(rtDetails, uc.uploadConfiguration.Threads, uc.DryRun(), progressBar)
+	var journal *uploadJournal
+	if uc.uploadConfiguration.Resume {
+		// The journal is identified by the spec, so it is opened before the spec is modified.
+		if journal, err = openUploadJournal(rtDetails.Url, uc.Spec(), uc.DryRun()); err != nil {
+			return err
+		}
+		defer journal.close()
+	}
+	collector := newTransferCollector(progressBar)
+	servicesManager, err := utils.CreateUploadServiceManager(rtDetails, uc.uploadConfiguration.Threads, uc.DryRun(), collector)
 	if err != nil {
 		return err
 	}
@@ -123,14 +133,28 @@ func (uc *UploadCommand) upload() error {
 	}
 
 	// Perform upload.
-	filesInfo, successCount, failCount, err := servicesManager.UploadFiles(uploadParamsArray...)
+	var filesInfo []clientutils.FileInfo
+	var successCount, failCount int
+	var uploadedEntries []*journalEntry
+	if journal != nil {
+		uploadParamsArray, uploadedEntries, err = resumeUpload(journal, uploadParamsArray)
+		if err != nil {
+			return err
+		}
+		filesInfo, successCount, failCount, err = uploadWithJournal(servicesManager, journal, uploadParamsArray)
+	} else {
+		filesInfo, successCount, failCount, err = servicesManager.UploadFiles(uploadParamsArray...)
+	}
 	if err != nil {
 		errorOccurred = true
 		log.Error(err)
 	}
 	result := uc.Result()
-	result.SetSuccessCount(successCount)
+	result.SetSuccessCount(successCount + len(uploadedEntries))
 	result.SetFailCount(failCount)
+	result.SetTransferredBytes(collector.bytes())
+	result.SetRetries(collector.retries())
+	result.AddFailures(collector.uploadFailures(filesInfo)...)
 	if errorOccurred {
 		err = errors.New("Upload finished with errors, Please review the logs.")
 		return err
@@ -138,6 +162,11 @@ func (uc *UploadCommand) upload() error {
 	if failCount > 0 {
 		return err
 	}
+	if journal != nil {
+		if err = journal.remove(); err != nil {
+			return err
+		}
+	}
 
 	if !uc.DryRun() {
 		// Handle sync-deletes
@@ -149,7 +178,7 @@ func (uc *UploadCommand) upload() error {
 		}
 		// Build Info
 		if isCollectBuildInfo {
-			buildArtifacts := convertFileInfoToBuildArtifacts(filesInfo)
+			buildArtifacts := convertFileInfoToBuildArtifacts(append(filesInfo, convertJournalEntriesToFilesInfo(uploadedEntries)...))
 			populateFunc := func(partial *buildinfo.Partial) {
 				partial.Artifacts = buildArtifacts
 				partial.ModuleId = uc.buildConfiguration.Module
@@ -0,0 +1,342 @@
+package generic
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli/artifactory/spec"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/fspatterns"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The transfer journals are saved next to the builds temp dir.
+const transfersTempPath = "jfrog/transfers/"
+
+// The number of files uploaded before the uploaded files are recorded in the journal.
+// The files of a batch which was interrupted are uploaded again by the next run.
+const journalBatchSize = 100
+
+// Records the files uploaded by an upload command, so that rerunning the same command with --resume skips them.
+// The journal is identified by the Artifactory URL and the upload spec, and is removed once the upload completes successfully.
+type uploadJournal struct {
+	path string
+	// The files uploaded by the previous runs, by their local paths.
+	entries map[string]*journalEntry
+	// Nil if the journal is opened for reading only.
+	file *os.File
+}
+
+type journalEntry struct {
+	LocalPath       string `json:"localPath"`
+	ArtifactoryPath string `json:"artifactoryPath"`
+	Size            int64  `json:"size"`
+	ModTime         int64  `json:"modTime"`
+	Sha1            string `json:"sha1"`
+	Md5             string `json:"md5"`
+}
+
+// Opens the journal of the upload, and reads the files recorded by the previous runs.
+// If readOnly, the journal isn't created and nothing is recorded in it, as in dry runs.
+func openUploadJournal(rtUrl string, uploadSpec *spec.SpecFiles, readOnly bool) (*uploadJournal, error) {
+	journalsDir := filepath.Join(cliutils.GetCliPersistentTempDirPath(), transfersTempPath)
+	if err := os.MkdirAll(journalsDir, 0777); errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	content, err := json.Marshal(uploadSpec)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	id := sha256.Sum256(append([]byte(rtUrl+"\n"), content...))
+	journal := &uploadJournal{path: filepath.Join(journalsDir, "upload-"+hex.EncodeToString(id[:])+".journal"), entries: make(map[string]*journalEntry)}
+	if err = journal.read(); err != nil || readOnly {
+		return journal, err
+	}
+	journal.file, err = os.OpenFile(journal.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	return journal, nil
+}
+
+// The journal is written a line per uploaded file.
+// A line which could not be parsed, such as a line partially written when the upload was interrupted, is ignored.
+func (uj *uploadJournal) read() error {
+	file, err := os.Open(uj.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		entry := new(journalEntry)
+		if json.Unmarshal(scanner.Bytes(), entry) == nil {
+			uj.entries[entry.LocalPath] = entry
+		}
+	}
+	return errorutils.CheckError(scanner.Err())
+}
+
+// Returns the journal entry of the file, if the file was uploaded to the same path in Artifactory and wasn't changed since.
+func (uj *uploadJournal) getUploaded(artifact clientutils.Artifact) *journalEntry {
+	entry := uj.entries[artifact.LocalPath]
+	if entry == nil || entry.ArtifactoryPath != artifact.TargetPath {
+		return nil
+	}
+	fileInfo, err := os.Stat(artifact.LocalPath)
+	if err != nil || fileInfo.Size() != entry.Size {
+		return nil
+	}
+	if fileInfo.ModTime().UnixNano() == entry.ModTime {
+		return entry
+	}
+	// The file was touched, compare its content.
+	details, err := fileutils.GetFileDetails(artifact.LocalPath)
+	if err != nil || details.Checksum.Sha1 != entry.Sha1 {
+		return nil
+	}
+	return entry
+}
+
+// Records the files uploaded successfully, as returned by the upload service.
+func (uj *uploadJournal) recordUploaded(filesInfo []serviceutils.FileInfo) error {
+	if uj.file == nil {
+		return nil
+	}
+	var content []byte
+	for _, uploaded := range filesInfo {
+		fileInfo, err := os.Stat(uploaded.LocalPath)
+		if errorutils.CheckError(err) != nil {
+			return err
+		}
+		entry := &journalEntry{LocalPath: uploaded.LocalPath, ArtifactoryPath: uploaded.InternalArtifactoryPath, Size: fileInfo.Size(), ModTime: fileInfo.ModTime().UnixNano()}
+		if uploaded.FileHashes != nil {
+			entry.Sha1, entry.Md5 = uploaded.Sha1, uploaded.Md5
+		}
+		line, err := json.Marshal(entry)
+		if errorutils.CheckError(err) != nil {
+			return err
+		}
+		content = append(append(content, line...), '\n')
+	}
+	_, err := uj.file.Write(content)
+	return errorutils.CheckError(err)
+}
+
+func (uj *uploadJournal) close() error {
+	if uj.file == nil {
+		return nil
+	}
+	return errorutils.CheckError(uj.file.Close())
+}
+
+// Removes the journal, after the upload completed successfully.
+func (uj *uploadJournal) remove() error {
+	if uj.file == nil {
+		return nil
+	}
+	if err := uj.close(); err != nil {
+		return err
+	}
+	return errorutils.CheckError(os.Remove(uj.path))
+}
+
+// Resolves the files the upload params would upload, and splits them into the files recorded by the journal and the files left to upload.
+// The files left to upload are returned as upload params of single files, so that jfrog-client-go doesn't collect the recorded files again.
+// Upload params which include directories are returned as is, since directories aren't recorded.
+// So are upload params with a file which can't be uploaded by its exact path, in which case all their files are uploaded again.
+func (uj *uploadJournal) filterUploaded(uploadParams services.UploadParams) (remaining []services.UploadParams, uploaded []*journalEntry, err error) {
+	if uploadParams.IsIncludeDirs() {
+		return []services.UploadParams{uploadParams}, nil, nil
+	}
+	artifacts, err := collectArtifactsForUpload(uploadParams)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, artifact := range artifacts {
+		if entry := uj.getUploaded(artifact); entry != nil {
+			uploaded = append(uploaded, entry)
+			continue
+		}
+		fileParams, ok := createFileUploadParams(uploadParams, artifact)
+		if !ok {
+			log.Warn("The upload of", uploadParams.GetPattern(), "can't be resumed, since the path of", artifact.LocalPath,
+				"contains characters which can't be escaped. All the files of the pattern are uploaded again.")
+			return []services.UploadParams{uploadParams}, nil, nil
+		}
+		remaining = append(remaining, fileParams)
+	}
+	return
+}
+
+// Matches the placeholders of the target path, which jfrog-client-go replaces with the parentheses groups of the pattern.
+var placeholderRegexp = regexp.MustCompile(`{\d+}`)
+
+// Creates the upload params which upload the file only, to its target path.
+// jfrog-client-go uploads a wildcard pattern without asterisks and placeholders in the target as is, as the path of a single file.
+// Otherwise, the file name is matched by an escaped regular expression, which requires a directory path without regular expressions special characters.
+// Returns false if the file can't be matched by its exact path.
+func createFileUploadParams(uploadParams services.UploadParams, artifact clientutils.Artifact) (services.UploadParams, bool) {
+	commonParams := *uploadParams.ArtifactoryCommonParams
+	commonParams.Target = artifact.TargetPath
+	commonParams.Recursive = false
+	dir, fileName := filepath.Split(artifact.LocalPath)
+	switch {
+	case !strings.Contains(artifact.LocalPath, "*") && !placeholderRegexp.MatchString(artifact.TargetPath):
+		commonParams.Pattern = artifact.LocalPath
+		commonParams.Regexp = false
+	case dir != "" && regexp.QuoteMeta(dir) == dir:
+		// The parentheses of the group start the pattern collected from the directory, and the group doesn't capture, so no placeholder is replaced.
+		commonParams.Pattern = dir + "(?:" + regexp.QuoteMeta(fileName) + ")$"
+		commonParams.Regexp = true
+	default:
+		return uploadParams, false
+	}
+	uploadParams.ArtifactoryCommonParams = &commonParams
+	return uploadParams, true
+}
+
+// Collects the files to upload and their target paths, the same way the upload service of jfrog-client-go does.
+func collectArtifactsForUpload(uploadParams services.UploadParams) ([]clientutils.Artifact, error) {
+	// The pattern is modified below, so the params are copied.
+	commonParams := *uploadParams.ArtifactoryCommonParams
+	uploadParams.ArtifactoryCommonParams = &commonParams
+	target := uploadParams.GetTarget()
+	if !strings.Contains(target, "/") {
+		target += "/"
+	}
+	pattern := clientutils.ReplaceTildeWithUserHome(uploadParams.GetPattern())
+	rootPath, err := fspatterns.GetRootPath(pattern, target, uploadParams.IsRegexp(), uploadParams.IsSymlink())
+	if err != nil {
+		return nil, err
+	}
+	isDir, err := fileutils.IsDirExists(rootPath, uploadParams.IsSymlink())
+	if err != nil {
+		return nil, err
+	}
+	if !isDir || (fileutils.IsPathSymlink(rootPath) && uploadParams.IsSymlink()) {
+		artifact, err := fspatterns.GetSingleFileToUpload(rootPath, target, uploadParams.IsFlat(), uploadParams.IsSymlink())
+		if err != nil {
+			return nil, err
+		}
+		return []clientutils.Artifact{artifact}, nil
+	}
+
+	uploadParams.SetPattern(clientutils.PrepareLocalPathForUpload(pattern, uploadParams.IsRegexp()))
+	excludePathPattern := fspatterns.PrepareExcludePathPattern(uploadParams)
+	patternRegex, err := regexp.Compile(uploadParams.GetPattern())
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	paths, err := fspatterns.GetPaths(rootPath, uploadParams.IsRecursive(), false, uploadParams.IsSymlink())
+	if err != nil {
+		return nil, err
+	}
+	var artifacts []clientutils.Artifact
+	for _, path := range paths {
+		matches, _, _, err := fspatterns.PrepareAndFilterPaths(path, excludePathPattern, uploadParams.IsSymlink(), false, patternRegex)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			continue
+		}
+		fileTarget := target
+		for i := 1; i < len(matches); i++ {
+			group := strings.Replace(matches[i], "\\", "/", -1)
+			fileTarget = strings.Replace(fileTarget, "{"+strconv.Itoa(i)+"}", group, -1)
+		}
+		symlinkPath, err := fspatterns.GetFileSymlinkPath(path)
+		if err != nil {
+			return nil, err
+		}
+		if uploadParams.IsSymlink() || symlinkPath == "" {
+			fileTarget = getUploadTarget(path, fileTarget, uploadParams.IsFlat())
+		} else {
+			fileTarget = getUploadTarget(symlinkPath, fileTarget, uploadParams.IsFlat())
+		}
+		artifacts = append(artifacts, clientutils.Artifact{LocalPath: path, TargetPath: fileTarget, Symlink: symlinkPath})
+	}
+	return artifacts, nil
+}
+
+func getUploadTarget(rootPath, target string, isFlat bool) string {
+	if strings.HasSuffix(target, "/") {
+		if isFlat {
+			fileName, _ := fileutils.GetFileAndDirFromPath(rootPath)
+			target += fileName
+		} else {
+			target += clientutils.TrimPath(rootPath)
+		}
+	}
+	return target
+}
+
+// Converts the files uploaded by the previous runs to the files info returned by jfrog-client-go, for the build-info.
+func convertJournalEntriesToFilesInfo(entries []*journalEntry) []serviceutils.FileInfo {
+	filesInfo := make([]serviceutils.FileInfo, len(entries))
+	for i, entry := range entries {
+		filesInfo[i] = serviceutils.FileInfo{LocalPath: entry.LocalPath, ArtifactoryPath: entry.ArtifactoryPath, InternalArtifactoryPath: entry.ArtifactoryPath,
+			FileHashes: &serviceutils.FileHashes{Sha1: entry.Sha1, Md5: entry.Md5}}
+	}
+	return filesInfo
+}
+
+// Filters out the files uploaded by the previous runs from the upload params.
+// Returns the upload params of the files left to upload, and the files uploaded by the previous runs.
+func resumeUpload(journal *uploadJournal, uploadParamsArray []services.UploadParams) ([]services.UploadParams, []*journalEntry, error) {
+	var remaining []services.UploadParams
+	var uploaded []*journalEntry
+	for _, uploadParams := range uploadParamsArray {
+		remainingParams, uploadedEntries, err := journal.filterUploaded(uploadParams)
+		if err != nil {
+			return nil, nil, err
+		}
+		remaining = append(remaining, remainingParams...)
+		uploaded = append(uploaded, uploadedEntries...)
+	}
+	if len(uploaded) > 0 {
+		log.Info("Skipping", len(uploaded), "files uploaded by a previous run.")
+	}
+	return remaining, uploaded, nil
+}
+
+// Uploads the files in batches, and records the files uploaded by each batch in the journal.
+// The upload service returns the uploaded files only when it completes, so an interrupted run loses the records of the current batch only.
+func uploadWithJournal(servicesManager *artifactory.ArtifactoryServicesManager, journal *uploadJournal, uploadParamsArray []services.UploadParams) (
+	filesInfo []serviceutils.FileInfo, successCount, failCount int, err error) {
+	for start := 0; start < len(uploadParamsArray); start += journalBatchSize {
+		end := start + journalBatchSize
+		if end > len(uploadParamsArray) {
+			end = len(uploadParamsArray)
+		}
+		batchFilesInfo, batchSuccess, batchFailed, batchErr := servicesManager.UploadFiles(uploadParamsArray[start:end]...)
+		filesInfo = append(filesInfo, batchFilesInfo...)
+		successCount += batchSuccess
+		failCount += batchFailed
+		if batchErr != nil && err == nil {
+			err = batchErr
+		}
+		if recordErr := journal.recordUploaded(batchFilesInfo); recordErr != nil {
+			log.Warn("Failed recording the uploaded files in the transfer journal:", recordErr.Error())
+		}
+	}
+	return
+}
@@ -0,0 +1,130 @@
+package generic
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/artifactory/spec"
+	"github.com/jfrog/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResumeUpload(t *testing.T) {
+	log.SetDefaultLogger()
+	defer setJfrogHomeDir(t)()
+	mock := newArtifactoryMock("")
+	defer mock.server.Close()
+	sourceDir, err := ioutil.TempDir("", "upload")
+	assert.NoError(t, err)
+	defer os.RemoveAll(sourceDir)
+	for _, name := range []string{"a.txt", "b.txt", "fail.txt"} {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(sourceDir, name), []byte("content"), 0644))
+	}
+	uploadSpec := spec.NewBuilder().Pattern(filepath.Join(sourceDir, "*")).Target("repo/").Flat(true).BuildSpec()
+	journal, err := openUploadJournal(mock.rtDetails().Url, uploadSpec, false)
+	assert.NoError(t, err)
+	defer os.Remove(journal.path)
+	assert.NoError(t, journal.close())
+
+	upload := func() (uploadedFiles []string) {
+		mock.requests = nil
+		uploadCommand := NewUploadCommand()
+		uploadCommand.SetUploadConfiguration(&utils.UploadConfiguration{Threads: 2, Resume: true}).SetBuildConfiguration(&utils.BuildConfiguration{})
+		uploadCommand.SetSpec(uploadSpec).SetRtDetails(mock.rtDetails())
+		assert.NoError(t, uploadCommand.Run())
+		assert.Equal(t, 2, uploadCommand.Result().SuccessCount())
+		assert.Equal(t, 1, uploadCommand.Result().FailCount())
+		for _, request := range mock.sortedRequests() {
+			uploadedFiles = append(uploadedFiles, strings.TrimPrefix(strings.Split(request, ";")[0], "PUT /repo/"))
+		}
+		return
+	}
+
+	assert.Equal(t, []string{"a.txt", "b.txt", "fail.txt"}, upload())
+	// The files uploaded by the first run are skipped.
+	assert.Equal(t, []string{"fail.txt"}, upload())
+	// Modified files are uploaded again.
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(sourceDir, "a.txt"), []byte("modified content"), 0644))
+	assert.Equal(t, []string{"a.txt", "fail.txt"}, upload())
+	assert.Equal(t, []string{"fail.txt"}, upload())
+
+	// The journal is removed once the upload completes successfully.
+	assert.NoError(t, os.Remove(filepath.Join(sourceDir, "fail.txt")))
+	uploadCommand := NewUploadCommand()
+	uploadCommand.SetUploadConfiguration(&utils.UploadConfiguration{Threads: 2, Resume: true}).SetBuildConfiguration(&utils.BuildConfiguration{})
+	uploadCommand.SetSpec(uploadSpec).SetRtDetails(mock.rtDetails())
+	assert.NoError(t, uploadCommand.Run())
+	assert.Equal(t, 2, uploadCommand.Result().SuccessCount())
+	_, err = os.Stat(journal.path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestResumeUploadDryRun(t *testing.T) {
+	log.SetDefaultLogger()
+	defer setJfrogHomeDir(t)()
+	mock := newArtifactoryMock("")
+	defer mock.server.Close()
+	sourceDir, err := ioutil.TempDir("", "upload")
+	assert.NoError(t, err)
+	defer os.RemoveAll(sourceDir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(sourceDir, "a.txt"), []byte("content"), 0644))
+	uploadSpec := spec.NewBuilder().Pattern(filepath.Join(sourceDir, "*")).Target("repo/").Flat(true).BuildSpec()
+
+	uploadCommand := NewUploadCommand()
+	uploadCommand.SetUploadConfiguration(&utils.UploadConfiguration{Threads: 1, Resume: true}).SetBuildConfiguration(&utils.BuildConfiguration{})
+	uploadCommand.SetSpec(uploadSpec).SetRtDetails(mock.rtDetails()).SetDryRun(true)
+	assert.NoError(t, uploadCommand.Run())
+	assert.Empty(t, mock.requests)
+
+	// A dry run doesn't leave a journal behind.
+	journal, err := openUploadJournal(mock.rtDetails().Url, uploadSpec, true)
+	assert.NoError(t, err)
+	_, err = os.Stat(journal.path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestResumeUploadSpecialCharacters(t *testing.T) {
+	log.SetDefaultLogger()
+	defer setJfrogHomeDir(t)()
+	mock := newArtifactoryMock("")
+	defer mock.server.Close()
+	sourceDir, err := ioutil.TempDir("", "upload")
+	assert.NoError(t, err)
+	defer os.RemoveAll(sourceDir)
+	// The names of the files left to upload are special characters of the upload patterns, so they must be escaped.
+	failingFiles := []string{"fail*.txt", "fail(1).txt", "fail?.txt", "fail{1}.txt"}
+	for _, name := range append([]string{"a.txt"}, failingFiles...) {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(sourceDir, name), []byte("content"), 0644))
+	}
+	uploadSpec := spec.NewBuilder().Pattern(filepath.Join(sourceDir, "*")).Target("repo/").Flat(true).BuildSpec()
+	journal, err := openUploadJournal(mock.rtDetails().Url, uploadSpec, true)
+	assert.NoError(t, err)
+	defer os.Remove(journal.path)
+
+	upload := func() *UploadCommand {
+		mock.requests = nil
+		uploadCommand := NewUploadCommand()
+		uploadCommand.SetUploadConfiguration(&utils.UploadConfiguration{Threads: 1, Resume: true}).SetBuildConfiguration(&utils.BuildConfiguration{})
+		uploadCommand.SetSpec(uploadSpec).SetRtDetails(mock.rtDetails())
+		assert.NoError(t, uploadCommand.Run())
+		assert.Equal(t, 1, uploadCommand.Result().SuccessCount())
+		assert.Equal(t, 4, uploadCommand.Result().FailCount())
+		return uploadCommand
+	}
+
+	upload()
+	assert.Len(t, mock.requests, 5)
+	// Each of the files left to upload is uploaded once, by its exact path.
+	uploadCommand := upload()
+	assert.Len(t, mock.requests, 4)
+	var failedFiles []string
+	for _, failure := range uploadCommand.Result().Failures() {
+		failedFiles = append(failedFiles, filepath.Base(failure.Path))
+		assert.Equal(t, 1, failure.Attempts)
+	}
+	assert.ElementsMatch(t, failingFiles, failedFiles)
+}
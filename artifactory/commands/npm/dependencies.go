@@ -0,0 +1,148 @@
+package npm
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-cli/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/auth"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The plumbing shared by the commands of npm and of the package managers which resolve their dependencies from npm repositories, yarn and pnpm.
+
+// A dependency of a project, with the file name and the checksums of its package in Artifactory.
+type Dependency struct {
+	Name    string
+	Version string
+	Scopes  []string
+	// The file name of the package in Artifactory, or empty if the package could not be found.
+	ArtifactName string
+	Checksum     *buildinfo.Checksum
+}
+
+func (dep *Dependency) ToBuildInfoDependency() buildinfo.Dependency {
+	fileType := ""
+	if i := strings.LastIndex(dep.ArtifactName, "."); i != -1 {
+		fileType = dep.ArtifactName[i+1:]
+	}
+	return buildinfo.Dependency{Id: dep.ArtifactName, Type: fileType, Scopes: dep.Scopes, Checksum: dep.Checksum}
+}
+
+// Sets the file name and the checksums of a dependency without querying Artifactory, for example from a local cache.
+// Returns false if they can't be found locally.
+type LocalDependencyResolver func(dep *Dependency, logMsgPrefix string) (bool, error)
+
+// Returns the URL of the npm repository in Artifactory, and the authentication details of the server in the .npmrc format.
+func GetRegistryDetails(rtDetails *config.ArtifactoryDetails, repo string) (registry, npmAuth string, err error) {
+	artDetails, err := createArtAuthDetails(rtDetails)
+	if err != nil {
+		return "", "", err
+	}
+	return getRegistryDetails(artDetails, repo)
+}
+
+func createArtAuthDetails(rtDetails *config.ArtifactoryDetails) (auth.ServiceDetails, error) {
+	artDetails, err := rtDetails.CreateArtAuthConfig()
+	if err != nil {
+		return nil, err
+	}
+	if artDetails.GetSshAuthHeaders() != nil {
+		return nil, errorutils.CheckError(errors.New("SSH authentication is not supported in this command"))
+	}
+	return artDetails, nil
+}
+
+func getRegistryDetails(artDetails auth.ServiceDetails, repo string) (registry, npmAuth string, err error) {
+	if npmAuth, err = GetArtifactoryNpmAuth(artDetails); err != nil {
+		return "", "", err
+	}
+	if err = utils.CheckIfRepoExists(repo, artDetails); err != nil {
+		return "", "", err
+	}
+	return GetNpmRepositoryUrl(repo, artDetails.GetUrl()), npmAuth, nil
+}
+
+// Sets the file names and the checksums of the dependencies, querying Artifactory with the given number of threads.
+// If localResolver isn't nil, the dependencies it resolves aren't queried.
+func CollectDependenciesChecksums(rtDetails *config.ArtifactoryDetails, threads int, dependencies []*Dependency, localResolver LocalDependencyResolver) error {
+	log.Info("Collecting dependencies information... This may take a few minutes...")
+	servicesManager, err := utils.CreateServiceManager(rtDetails, false)
+	if err != nil {
+		return err
+	}
+
+	producerConsumer := parallel.NewBounedRunner(threads, false)
+	errorsQueue := clientutils.NewErrorsQueue(1)
+	go func() {
+		defer producerConsumer.Done()
+		for _, dep := range dependencies {
+			dep := dep
+			producerConsumer.AddTaskWithError(func(threadId int) error {
+				logMsgPrefix := clientutils.GetLogMsgPrefix(threadId, false)
+				if localResolver != nil {
+					if resolved, err := localResolver(dep, logMsgPrefix); err != nil || resolved {
+						return err
+					}
+				}
+				log.Debug(logMsgPrefix, "Fetching checksums for", dep.Name, "-", dep.Version)
+				artifactName, checksum, err := GetDependencyInfo(servicesManager, dep.Name, dep.Version)
+				if err != nil {
+					return err
+				}
+				if artifactName == "" {
+					log.Debug(logMsgPrefix, dep.Name, "-", dep.Version, "could not be found in Artifactory.")
+					return nil
+				}
+				dep.ArtifactName = artifactName
+				dep.Checksum = checksum
+				log.Debug(logMsgPrefix, "Found", artifactName, "sha1:", checksum.Sha1, "md5", checksum.Md5)
+				return nil
+			}, errorsQueue.AddError)
+		}
+	}()
+	producerConsumer.Run()
+	return errorsQueue.GetError()
+}
+
+// Transforms the dependencies to buildinfo.Dependencies list, and returns the dependencies missing in Artifactory separately.
+func TransformDependencies(dependencies []*Dependency) (buildInfoDependencies []buildinfo.Dependency, missingDependencies []*Dependency) {
+	for _, dep := range dependencies {
+		if dep.ArtifactName != "" {
+			buildInfoDependencies = append(buildInfoDependencies, dep.ToBuildInfoDependency())
+		} else {
+			missingDependencies = append(missingDependencies, dep)
+		}
+	}
+	return
+}
+
+// Saves the dependencies as a build-info module.
+func SaveModuleDependencies(buildConfiguration *utils.BuildConfiguration, moduleId string, dependencies []buildinfo.Dependency) error {
+	populateFunc := func(partial *buildinfo.Partial) {
+		partial.Dependencies = dependencies
+		partial.ModuleId = moduleId
+	}
+	return utils.SavePartialBuildInfo(buildConfiguration.BuildName, buildConfiguration.BuildNumber, populateFunc)
+}
+
+// Warns that the dependencies are missing in the build-info.
+// populateCacheAction is the action which makes the package manager download the dependencies through Artifactory.
+func WarnMissingDependencies(packageManager, populateCacheAction string, missingDependencies []*Dependency) {
+	if len(missingDependencies) == 0 {
+		return
+	}
+	var missingDependenciesText []string
+	for _, dep := range missingDependencies {
+		missingDependenciesText = append(missingDependenciesText, dep.Name+"-"+dep.Version)
+	}
+	log.Warn(strings.Join(missingDependenciesText, "\n"))
+	log.Warn("The " + packageManager + " dependencies above could not be found in Artifactory and therefore are not included in the build-info.\n" +
+		"Make sure the dependencies are available in Artifactory for this build.\n" +
+		populateCacheAction + " will force populating Artifactory with these dependencies.")
+}
@@ -15,7 +15,6 @@ import (
 
 	"github.com/buger/jsonparser"
 	gofrogcmd "github.com/jfrog/gofrog/io"
-	"github.com/jfrog/gofrog/parallel"
 	"github.com/jfrog/jfrog-cli/artifactory/utils"
 	"github.com/jfrog/jfrog-cli/artifactory/utils/npm"
 	"github.com/jfrog/jfrog-cli/utils/config"
@@ -25,9 +24,9 @@ import (
 	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/jfrog/jfrog-client-go/auth"
 	"github.com/jfrog/jfrog-client-go/httpclient"
-	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	cliutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/jfrog/jfrog-client-go/utils/version"
 )
@@ -43,6 +42,7 @@ type NpmCommandArgs struct {
 	jsonOutput       bool
 	executablePath   string
 	npmrcFileMode    os.FileMode
+	npmCacheDir      string
 	workingDirectory string
 	registry         string
 	npmAuth          string
@@ -51,6 +51,13 @@ type NpmCommandArgs struct {
 	typeRestriction  string
 	artDetails       auth.ServiceDetails
 	packageInfo      *npm.PackageInfo
+	// The workspace packages of the project, if it has any. Each of them is saved as a build-info module of its own.
+	workspaces []*npm.Workspace
+	// The names of the packages the project requires, if it has workspaces.
+	rootDependencies []string
+	// The dependencies each package in the npm list output requires, by the name of the required package.
+	// The dependencies of the project itself are under the empty key.
+	dependenciesGraph map[string]map[string]string
 	NpmCommand
 }
 
@@ -191,18 +198,8 @@ func (nca *NpmCommandArgs) preparePrerequisites(repo string) error {
 }
 
 func (nca *NpmCommandArgs) prepareArtifactoryPrerequisites(repo string) (err error) {
-	npmAuth, err := getArtifactoryDetails(nca.artDetails)
-	if err != nil {
-		return err
-	}
-	nca.npmAuth = npmAuth
-
-	if err = utils.CheckIfRepoExists(repo, nca.artDetails); err != nil {
-		return err
-	}
-
-	nca.registry = getNpmRepositoryUrl(repo, nca.artDetails.GetUrl())
-	return nil
+	nca.registry, nca.npmAuth, err = getRegistryDetails(nca.artDetails, repo)
+	return err
 }
 
 func (nca *NpmCommandArgs) prepareBuildInfo() error {
@@ -216,10 +213,28 @@ func (nca *NpmCommandArgs) prepareBuildInfo() error {
 		if nca.packageInfo, err = npm.ReadPackageInfoFromPackageJson(nca.workingDirectory); err != nil {
 			return err
 		}
+		return nca.setWorkspaces()
 	}
 	return err
 }
 
+func (nca *NpmCommandArgs) setWorkspaces() error {
+	workspaces, err := npm.ReadWorkspaces(nca.workingDirectory)
+	if err != nil || len(workspaces) == 0 {
+		return err
+	}
+	if nca.buildConfiguration.Module != "" {
+		log.Warn("The --module option applies only to the project root. Each workspace package is saved as a module, named after its package.json.")
+	}
+	root, err := npm.ReadWorkspace(nca.workingDirectory)
+	if err != nil {
+		return err
+	}
+	nca.workspaces = workspaces
+	nca.rootDependencies = root.Dependencies
+	return nil
+}
+
 func (nca *NpmCommandArgs) setWorkingDirectory() error {
 	currentDir, err := os.Getwd()
 	if err != nil {
@@ -315,63 +330,152 @@ func (nca *NpmCommandArgs) runInstallOrCi() error {
 
 func (nca *NpmCommandArgs) setDependenciesList() (err error) {
 	nca.dependencies = make(map[string]*dependency)
-	// nca.scope can be empty, "production" or "development" in case of empty both of the functions should run
-	if nca.typeRestriction != "production" {
-		if err = nca.prepareDependencies("development"); err != nil {
-			return
+	nca.dependenciesGraph = nil
+	lockfile, err := npm.ReadLockfile(nca.workingDirectory)
+	if err != nil {
+		return err
+	}
+	if lockfile != nil {
+		nca.setDependenciesFromLockfile(lockfile)
+	} else {
+		log.Debug("The project doesn't have a lockfile. Collecting the dependencies with npm list.")
+		// nca.scope can be empty, "production" or "development" in case of empty both of the functions should run
+		if nca.typeRestriction != "production" {
+			if err = nca.prepareDependencies("development"); err != nil {
+				return
+			}
+		}
+		if nca.typeRestriction != "development" {
+			if err = nca.prepareDependencies("production"); err != nil {
+				return
+			}
 		}
 	}
-	if nca.typeRestriction != "development" {
-		err = nca.prepareDependencies("production")
+	// The workspace packages are linked from the project, rather than downloaded from Artifactory.
+	for _, workspace := range nca.workspaces {
+		delete(nca.dependencies, getWorkspaceKey(workspace))
 	}
 	return
 }
 
+// Adds the packages npm installed according to the lockfile to nca.dependencies, and their relations to nca.dependenciesGraph.
+func (nca *NpmCommandArgs) setDependenciesFromLockfile(lockfile *npm.Lockfile) {
+	// The keys of the packages by their paths. The project itself is under the empty key, like in nca.dependenciesGraph.
+	keys := make(map[string]string)
+	for packagePath, lockedPackage := range lockfile.Packages {
+		switch {
+		case packagePath == "":
+			keys[packagePath] = ""
+		case !lockedPackage.IsInstalled():
+			// The workspace packages are kept in the graph, for collecting their dependencies. The links to them aren't.
+			if lockedPackage.Link == "" {
+				keys[packagePath] = lockedPackage.Name + "-" + lockedPackage.Version
+			}
+		case nca.isLockedPackageInstalled(lockedPackage):
+			keys[packagePath] = nca.appendLockedDependency(lockedPackage)
+		}
+	}
+	for packagePath, parentKey := range keys {
+		for _, name := range lockfile.Packages[packagePath].Requires {
+			requiredPackage := lockfile.Resolve(packagePath, name)
+			if requiredPackage == nil {
+				continue
+			}
+			if requiredKey, exists := keys[requiredPackage.Path]; exists && requiredKey != "" {
+				nca.appendDependencyEdge(parentKey, name, requiredKey)
+			}
+		}
+	}
+}
+
+// Returns false for the packages in the lockfile which npm didn't download: bundled packages, packages excluded by the type restriction,
+// and optional packages which don't apply to this platform.
+func (nca *NpmCommandArgs) isLockedPackageInstalled(lockedPackage *npm.LockedPackage) bool {
+	if lockedPackage.Bundled {
+		return false
+	}
+	if (nca.typeRestriction == "production" && lockedPackage.Dev) || (nca.typeRestriction == "development" && !lockedPackage.Dev) {
+		return false
+	}
+	if lockedPackage.Optional {
+		installed, err := fileutils.IsDirExists(filepath.Join(nca.workingDirectory, filepath.FromSlash(lockedPackage.Path)), false)
+		return err == nil && installed
+	}
+	return true
+}
+
+func (nca *NpmCommandArgs) appendLockedDependency(lockedPackage *npm.LockedPackage) string {
+	scope := "production"
+	if lockedPackage.Dev {
+		scope = "development"
+	}
+	dependencyKey := nca.appendDependency([]byte(lockedPackage.Name), []byte(lockedPackage.Version), scope)
+	if dep := nca.dependencies[dependencyKey]; dep.integrity == "" && nca.isResolvedFromRegistry(lockedPackage) {
+		dep.integrity = lockedPackage.Integrity
+		dep.tarballName = lockedPackage.GetTarballName()
+	}
+	return dependencyKey
+}
+
+// Returns true if npm downloaded the package from the Artifactory registry.
+// The tarballs npm downloaded from other registries, or from git and tarball URLs, may differ from the packages in Artifactory.
+func (nca *NpmCommandArgs) isResolvedFromRegistry(lockedPackage *npm.LockedPackage) bool {
+	return nca.registry != "" && strings.HasPrefix(lockedPackage.Resolved, strings.TrimSuffix(nca.registry, "/")+"/")
+}
+
 func (nca *NpmCommandArgs) collectDependenciesChecksums() error {
-	log.Info("Collecting dependencies information... This may take a few minutes...")
-	servicesManager, err := utils.CreateServiceManager(nca.rtDetails, false)
+	dependenciesCache, err := npm.GetProjectDependenciesCache(nca.workingDirectory)
 	if err != nil {
 		return err
 	}
+	if err = CollectDependenciesChecksums(nca.rtDetails, nca.threads, nca.dependenciesList(), nca.createLocalDependencyResolver(dependenciesCache)); err != nil {
+		return err
+	}
+	nca.updateDependenciesCache()
+	return nil
+}
 
-	producerConsumer := parallel.NewBounedRunner(nca.threads, false)
-	errorsQueue := clientutils.NewErrorsQueue(1)
-	handlerFunc := nca.createGetDependencyInfoFunc(servicesManager)
-	go func() {
-		defer producerConsumer.Done()
-		for i := range nca.dependencies {
-			producerConsumer.AddTaskWithError(handlerFunc(i), errorsQueue.AddError)
+func (nca *NpmCommandArgs) dependenciesList() (dependencies []*Dependency) {
+	for _, dep := range nca.dependencies {
+		dependencies = append(dependencies, &dep.Dependency)
+	}
+	return
+}
+
+// Writes the dependencies found in this build to the project's dependencies cache, so that the next builds don't fetch them again.
+// Failing to write the cache doesn't fail the build.
+func (nca *NpmCommandArgs) updateDependenciesCache() {
+	cacheMap := make(map[string]*buildinfo.Dependency)
+	for _, dep := range nca.dependencies {
+		if dep.ArtifactName != "" {
+			cacheMap[npm.GetDependencyCacheKey(dep.Name, dep.Version)] = &buildinfo.Dependency{Id: dep.ArtifactName, Checksum: dep.Checksum}
 		}
-	}()
-	producerConsumer.Run()
-	return errorsQueue.GetError()
+	}
+	if err := npm.UpdateDependenciesCache(nca.workingDirectory, cacheMap); err != nil {
+		log.Warn("Failed to update the npm dependencies cache:", err.Error())
+	}
 }
 
 func (nca *NpmCommandArgs) saveDependenciesData() error {
 	log.Debug("Saving data.")
-	dependencies, missingDependencies := nca.transformDependencies()
-	populateFunc := func(partial *buildinfo.Partial) {
-		partial.Dependencies = dependencies
-		if nca.buildConfiguration.Module == "" {
-			nca.buildConfiguration.Module = nca.packageInfo.BuildInfoModuleId()
-		}
-		partial.ModuleId = nca.buildConfiguration.Module
+	dependencies, missingDependencies := TransformDependencies(nca.dependenciesList())
+	if nca.buildConfiguration.Module == "" {
+		nca.buildConfiguration.Module = nca.packageInfo.BuildInfoModuleId()
 	}
-
-	if err := utils.SavePartialBuildInfo(nca.buildConfiguration.BuildName, nca.buildConfiguration.BuildNumber, populateFunc); err != nil {
+	if len(nca.workspaces) > 0 {
+		// Only the dependencies the project itself requires are saved in its module.
+		dependencies = nca.transformRequiredDependencies("", nca.rootDependencies)
+	}
+	if err := SaveModuleDependencies(nca.buildConfiguration, nca.buildConfiguration.Module, dependencies); err != nil {
 		return err
 	}
-
-	if len(missingDependencies) > 0 {
-		var missingDependenciesText []string
-		for _, dependency := range missingDependencies {
-			missingDependenciesText = append(missingDependenciesText, dependency.name+"-"+dependency.version)
+	for _, workspace := range nca.workspaces {
+		workspaceDependencies := nca.transformRequiredDependencies(getWorkspaceKey(workspace), workspace.Dependencies)
+		if err := SaveModuleDependencies(nca.buildConfiguration, workspace.PackageInfo.BuildInfoModuleId(), workspaceDependencies); err != nil {
+			return err
 		}
-		log.Warn(strings.Join(missingDependenciesText, "\n"))
-		log.Warn("The npm dependencies above could not be found in Artifactory and therefore are not included in the build-info.\n" +
-			"Make sure the dependencies are available in Artifactory for this build.\n" +
-			"Deleting the local cache will force populating Artifactory with these dependencies.")
 	}
+	WarnMissingDependencies("npm", "Deleting the local cache", missingDependencies)
 	return nil
 }
 
@@ -427,6 +531,9 @@ func (nca *NpmCommandArgs) prepareConfigData(data []byte) ([]byte, error) {
 			filteredConf = append(filteredConf, i, " = ", nca.registry, "\n")
 		}
 		nca.setTypeRestriction(i, collectedConfig[i])
+		if i == "cache" && collectedConfig[i] != nil {
+			nca.npmCacheDir = fmt.Sprint(collectedConfig[i])
+		}
 	}
 	filteredConf = append(filteredConf, "json = ", strconv.FormatBool(nca.jsonOutput), "\n")
 	filteredConf = append(filteredConf, "registry = ", nca.registry, "\n")
@@ -449,8 +556,13 @@ func (nca *NpmCommandArgs) setTypeRestriction(key string, val interface{}) {
 
 // Run npm list and parse the returned json
 func (nca *NpmCommandArgs) prepareDependencies(typeRestriction string) error {
+	listFlags := append(nca.npmArgs, " -only="+typeRestriction)
+	if len(nca.workspaces) > 0 {
+		// Workspaces require npm 7 or above, which lists only the top level dependencies by default.
+		listFlags = append(listFlags, "--all")
+	}
 	// Run npm list
-	data, errData, err := npm.RunList(strings.Join(append(nca.npmArgs, " -only="+typeRestriction), " "), nca.executablePath)
+	data, errData, err := npm.RunList(strings.Join(listFlags, " "), nca.executablePath)
 	if err != nil {
 		log.Warn("npm list command failed with error:", err.Error())
 	}
@@ -461,7 +573,7 @@ func (nca *NpmCommandArgs) prepareDependencies(typeRestriction string) error {
 	// Parse the dependencies json object
 	return jsonparser.ObjectEach(data, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
 		if string(key) == "dependencies" {
-			err := nca.parseDependencies(value, typeRestriction)
+			err := nca.parseDependencies(value, typeRestriction, "")
 			if err != nil {
 				return err
 			}
@@ -470,17 +582,21 @@ func (nca *NpmCommandArgs) prepareDependencies(typeRestriction string) error {
 	})
 }
 
-// Parses npm dependencies recursively and adds the collected dependencies to nca.dependencies
-func (nca *NpmCommandArgs) parseDependencies(data []byte, scope string) error {
-	var transitiveDependencies [][]byte
+// Parses npm dependencies recursively and adds the collected dependencies to nca.dependencies, and their relations to nca.dependenciesGraph.
+// parentKey is the key of the package which requires the dependencies in data.
+func (nca *NpmCommandArgs) parseDependencies(data []byte, scope, parentKey string) error {
+	var transitiveDependencies []nestedDependencies
 	err := jsonparser.ObjectEach(data, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+		// Packages without version information are kept out of the dependencies graph, under their names only.
+		dependencyKey := string(key)
 		ver, _, _, err := jsonparser.Get(data, string(key), "version")
 		if err != nil && err != jsonparser.KeyPathNotFoundError {
 			return errorutils.CheckError(err)
 		} else if err == jsonparser.KeyPathNotFoundError {
 			log.Warn(fmt.Sprintf("npm dependencies list contains the package '%s' without version information. The dependency will not be added to build-info.", string(key)))
 		} else {
-			nca.appendDependency(key, ver, scope)
+			dependencyKey = nca.appendDependency(key, ver, scope)
+			nca.appendDependencyEdge(parentKey, string(key), dependencyKey)
 		}
 		transitive, _, _, err := jsonparser.Get(data, string(key), "dependencies")
 		if err != nil && err.Error() != "Key path not found" {
@@ -488,7 +604,7 @@ func (nca *NpmCommandArgs) parseDependencies(data []byte, scope string) error {
 		}
 
 		if len(transitive) > 0 {
-			transitiveDependencies = append(transitiveDependencies, transitive)
+			transitiveDependencies = append(transitiveDependencies, nestedDependencies{parentKey: dependencyKey, data: transitive})
 		}
 		return nil
 	})
@@ -498,7 +614,7 @@ func (nca *NpmCommandArgs) parseDependencies(data []byte, scope string) error {
 	}
 
 	for _, element := range transitiveDependencies {
-		err := nca.parseDependencies(element, scope)
+		err := nca.parseDependencies(element.data, scope, element.parentKey)
 		if err != nil {
 			return err
 		}
@@ -506,58 +622,113 @@ func (nca *NpmCommandArgs) parseDependencies(data []byte, scope string) error {
 	return nil
 }
 
-func (nca *NpmCommandArgs) appendDependency(key []byte, ver []byte, scope string) {
+func (nca *NpmCommandArgs) appendDependency(key []byte, ver []byte, scope string) string {
 	dependencyKey := string(key) + "-" + string(ver)
 	if nca.dependencies[dependencyKey] == nil {
-		nca.dependencies[dependencyKey] = &dependency{name: string(key), version: string(ver), scopes: []string{scope}}
-	} else if !scopeAlreadyExists(scope, nca.dependencies[dependencyKey].scopes) {
-		nca.dependencies[dependencyKey].scopes = append(nca.dependencies[dependencyKey].scopes, scope)
+		nca.dependencies[dependencyKey] = &dependency{Dependency: Dependency{Name: string(key), Version: string(ver), Scopes: []string{scope}}}
+	} else if !scopeAlreadyExists(scope, nca.dependencies[dependencyKey].Scopes) {
+		nca.dependencies[dependencyKey].Scopes = append(nca.dependencies[dependencyKey].Scopes, scope)
 	}
+	return dependencyKey
 }
 
-// Creates a function that fetches dependency data from Artifactory. Can be applied from a producer-consumer mechanism
-func (nca *NpmCommandArgs) createGetDependencyInfoFunc(servicesManager *artifactory.ArtifactoryServicesManager) getDependencyInfoFunc {
-	return func(dependencyIndex string) parallel.TaskFunc {
-		return func(threadId int) error {
-			name := nca.dependencies[dependencyIndex].name
-			ver := nca.dependencies[dependencyIndex].version
-			log.Debug(cliutils.GetLogMsgPrefix(threadId, false), "Fetching checksums for", name, "-", ver)
-			result, err := servicesManager.Aql(serviceutils.CreateAqlQueryForNpm(name, ver))
-			if err != nil {
-				return err
-			}
+func (nca *NpmCommandArgs) appendDependencyEdge(parentKey, name, dependencyKey string) {
+	if nca.dependenciesGraph == nil {
+		nca.dependenciesGraph = make(map[string]map[string]string)
+	}
+	if nca.dependenciesGraph[parentKey] == nil {
+		nca.dependenciesGraph[parentKey] = make(map[string]string)
+	}
+	nca.dependenciesGraph[parentKey][name] = dependencyKey
+}
 
-			parsedResult := new(aqlResult)
-			if err = json.Unmarshal(result, parsedResult); err != nil {
-				return errorutils.CheckError(err)
-			}
-			if len(parsedResult.Results) == 0 {
-				log.Debug(cliutils.GetLogMsgPrefix(threadId, false), name, "-", ver, "could not be found in Artifactory.")
-				return nil
-			}
-			nca.dependencies[dependencyIndex].artifactName = parsedResult.Results[0].Name
-			nca.dependencies[dependencyIndex].checksum =
-				&buildinfo.Checksum{Sha1: parsedResult.Results[0].Actual_sha1, Md5: parsedResult.Results[0].Actual_md5}
-			log.Debug(cliutils.GetLogMsgPrefix(threadId, false), "Found", parsedResult.Results[0].Name,
-				"sha1:", parsedResult.Results[0].Actual_sha1,
-				"md5", parsedResult.Results[0].Actual_md5)
-			return nil
+// Returns the keys of the dependencies the package requires, directly or transitively.
+// packageKey is the key of the package in nca.dependenciesGraph, and dependencyNames are the names of the packages it requires in its package.json.
+// The dependencies of other workspace packages are not collected, since they are saved in the modules of these packages.
+func (nca *NpmCommandArgs) collectRequiredDependencies(packageKey string, dependencyNames []string) []string {
+	workspacesKeys := make(map[string]bool)
+	for _, workspace := range nca.workspaces {
+		workspacesKeys[getWorkspaceKey(workspace)] = true
+	}
+	var requiredKeys []string
+	visited := make(map[string]bool)
+	visit := func(dependencyKey string) {
+		if dependencyKey != "" && !visited[dependencyKey] && !workspacesKeys[dependencyKey] {
+			visited[dependencyKey] = true
+			requiredKeys = append(requiredKeys, dependencyKey)
+		}
+	}
+	for _, name := range dependencyNames {
+		// npm hoists the dependencies of the workspace packages to the root of the project, unless their versions conflict.
+		dependencyKey, exists := nca.dependenciesGraph[packageKey][name]
+		if !exists {
+			dependencyKey = nca.dependenciesGraph[""][name]
 		}
+		visit(dependencyKey)
 	}
+	for i := 0; i < len(requiredKeys); i++ {
+		for _, dependencyKey := range nca.dependenciesGraph[requiredKeys[i]] {
+			visit(dependencyKey)
+		}
+	}
+	return requiredKeys
 }
 
-// Transforms the list of dependencies to buildinfo.Dependencies list and creates a list of dependencies that are missing in Artifactory.
-func (nca *NpmCommandArgs) transformDependencies() (dependencies []buildinfo.Dependency, missingDependencies []dependency) {
-	for _, dependency := range nca.dependencies {
-		if dependency.artifactName != "" {
-			fileType := ""
-			if i := strings.LastIndex(dependency.artifactName, "."); i != -1 {
-				fileType = dependency.artifactName[i+1:]
-			}
-			dependencies = append(dependencies,
-				buildinfo.Dependency{Id: dependency.artifactName, Type: fileType, Scopes: dependency.scopes, Checksum: dependency.checksum})
-		} else {
-			missingDependencies = append(missingDependencies, *dependency)
+// The key of a workspace package, as it appears in the npm list output.
+func getWorkspaceKey(workspace *npm.Workspace) string {
+	return workspace.PackageInfo.FullName() + "-" + workspace.PackageInfo.Version
+}
+
+// Creates a resolver which finds the dependencies in the project's dependencies cache,
+// and the dependencies npm downloaded from the Artifactory registry, according to the lockfile, in the npm cache.
+func (nca *NpmCommandArgs) createLocalDependencyResolver(dependenciesCache *npm.DependenciesCache) LocalDependencyResolver {
+	return func(dep *Dependency, logMsgPrefix string) (bool, error) {
+		if cachedDependency := dependenciesCache.GetDependency(npm.GetDependencyCacheKey(dep.Name, dep.Version)); cachedDependency != nil {
+			dep.ArtifactName = cachedDependency.Id
+			dep.Checksum = cachedDependency.Checksum
+			log.Debug(logMsgPrefix, "Found", dep.Name, "-", dep.Version, "in the dependencies cache.")
+			return true, nil
+		}
+		// The checksums of the dependencies in the lockfile are calculated from their tarballs in the npm cache.
+		// Artifactory is queried for the dependencies without integrity, and for the ones which aren't in the cache.
+		lockedDependency := nca.dependencies[dep.Name+"-"+dep.Version]
+		if lockedDependency == nil || lockedDependency.integrity == "" {
+			return false, nil
+		}
+		checksum, err := npm.GetCachedTarballChecksum(nca.npmCacheDir, lockedDependency.integrity)
+		if err != nil || checksum == nil {
+			return false, err
+		}
+		dep.ArtifactName = lockedDependency.tarballName
+		dep.Checksum = checksum
+		log.Debug(logMsgPrefix, "Found", dep.Name, "-", dep.Version, "in the npm cache.")
+		return true, nil
+	}
+}
+
+// Fetches the file name and the checksums of an npm package from Artifactory.
+// Returns an empty file name if the package could not be found in Artifactory.
+func GetDependencyInfo(servicesManager *artifactory.ArtifactoryServicesManager, name, ver string) (artifactName string, checksum *buildinfo.Checksum, err error) {
+	result, err := servicesManager.Aql(serviceutils.CreateAqlQueryForNpm(name, ver))
+	if err != nil {
+		return "", nil, err
+	}
+
+	parsedResult := new(aqlResult)
+	if err = json.Unmarshal(result, parsedResult); err != nil {
+		return "", nil, errorutils.CheckError(err)
+	}
+	if len(parsedResult.Results) == 0 {
+		return "", nil, nil
+	}
+	return parsedResult.Results[0].Name, &buildinfo.Checksum{Sha1: parsedResult.Results[0].Actual_sha1, Md5: parsedResult.Results[0].Actual_md5}, nil
+}
+
+// Transforms the dependencies a package requires to buildinfo.Dependencies list, without the dependencies missing in Artifactory.
+func (nca *NpmCommandArgs) transformRequiredDependencies(packageKey string, dependencyNames []string) (dependencies []buildinfo.Dependency) {
+	for _, dependencyKey := range nca.collectRequiredDependencies(packageKey, dependencyNames) {
+		if dependency := nca.dependencies[dependencyKey]; dependency != nil && dependency.ArtifactName != "" {
+			dependencies = append(dependencies, dependency.ToBuildInfoDependency())
 		}
 	}
 	return
@@ -570,16 +741,9 @@ func (nca *NpmCommandArgs) restoreNpmrcAndError(err error) error {
 	return err
 }
 
-func (nca *NpmCommandArgs) setArtifactoryAuth() error {
-	authArtDetails, err := nca.rtDetails.CreateArtAuthConfig()
-	if err != nil {
-		return err
-	}
-	if authArtDetails.GetSshAuthHeaders() != nil {
-		return errorutils.CheckError(errors.New("SSH authentication is not supported in this command"))
-	}
-	nca.artDetails = authArtDetails
-	return nil
+func (nca *NpmCommandArgs) setArtifactoryAuth() (err error) {
+	nca.artDetails, err = createArtAuthDetails(nca.rtDetails)
+	return err
 }
 
 func removeNpmrcIfExists(workingDirectory string) error {
@@ -608,7 +772,8 @@ func (nca *NpmCommandArgs) setNpmExecutable() error {
 	return nil
 }
 
-func getArtifactoryDetails(artDetails auth.ServiceDetails) (npmAuth string, err error) {
+// Returns the authentication details of Artifactory in the .npmrc format.
+func GetArtifactoryNpmAuth(artDetails auth.ServiceDetails) (npmAuth string, err error) {
 	// Check Artifactory version.
 	err = validateArtifactoryVersion(artDetails)
 	if err != nil {
@@ -672,7 +837,7 @@ func getDetailsUsingBasicAuth(artDetails auth.ServiceDetails) (npmAuth string, e
 	return string(body), nil
 }
 
-func getNpmRepositoryUrl(repo, url string) string {
+func GetNpmRepositoryUrl(repo, url string) string {
 	if !strings.HasSuffix(url, "/") {
 		url += "/"
 	}
@@ -711,14 +876,17 @@ func filterFlags(splitArgs []string) []string {
 	return filteredArgs
 }
 
-type getDependencyInfoFunc func(string) parallel.TaskFunc
-
 type dependency struct {
-	name         string
-	version      string
-	scopes       []string
-	artifactName string
-	checksum     *buildinfo.Checksum
+	Dependency
+	// The integrity and the tarball name, for the dependencies collected from the lockfile which npm downloaded from the Artifactory registry.
+	integrity   string
+	tarballName string
+}
+
+// The dependencies of a package in the npm list output.
+type nestedDependencies struct {
+	parentKey string
+	data      []byte
 }
 
 type aqlResult struct {
@@ -2,8 +2,13 @@ package npm
 
 import (
 	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
 	"strings"
 	"testing"
+
+	"github.com/jfrog/jfrog-cli/artifactory/utils/npm"
 )
 
 func TestPrepareConfigData(t *testing.T) {
@@ -92,7 +97,7 @@ func TestParseDependencies(t *testing.T) {
 	}
 	npmi := NpmCommandArgs{}
 	npmi.dependencies = make(map[string]*dependency)
-	err = npmi.parseDependencies([]byte(dependenciesJsonList), "myScope")
+	err = npmi.parseDependencies([]byte(dependenciesJsonList), "myScope", "")
 	if err != nil {
 		t.Error(err)
 	}
@@ -116,6 +121,125 @@ func TestParseDependencies(t *testing.T) {
 	}
 }
 
+const workspacesDependenciesList = `{
+  "app": {
+    "version": "1.0.0",
+    "resolved": "file:../packages/app",
+    "dependencies": {
+      "debug": {"version": "2.6.9", "dependencies": {"ms": {"version": "2.0.0"}}},
+      "lodash": {"version": "4.17.20"},
+      "utils": {"version": "1.0.0"}
+    }
+  },
+  "utils": {
+    "version": "1.0.0",
+    "resolved": "file:../packages/utils",
+    "dependencies": {"ms": {"version": "2.1.2"}}
+  },
+  "lodash": {"version": "4.17.20"},
+  "ms": {"version": "2.1.2"},
+  "typescript": {"version": "4.0.5"}
+}`
+
+func TestCollectRequiredDependencies(t *testing.T) {
+	app := &npm.Workspace{PackageInfo: &npm.PackageInfo{Name: "app", Version: "1.0.0"}, Dependencies: []string{"debug", "lodash", "utils"}}
+	utils := &npm.Workspace{PackageInfo: &npm.PackageInfo{Name: "utils", Version: "1.0.0"}, Dependencies: []string{"ms"}}
+	npmi := NpmCommandArgs{workspaces: []*npm.Workspace{app, utils}}
+	npmi.dependencies = make(map[string]*dependency)
+	if err := npmi.parseDependencies([]byte(workspacesDependenciesList), "production", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		packageKey      string
+		dependencyNames []string
+		expected        []string
+	}{
+		{"", []string{"typescript"}, []string{"typescript-4.0.5"}},
+		{getWorkspaceKey(app), app.Dependencies, []string{"debug-2.6.9", "lodash-4.17.20", "ms-2.0.0"}},
+		{getWorkspaceKey(utils), utils.Dependencies, []string{"ms-2.1.2"}},
+	}
+	for _, test := range tests {
+		actual := npmi.collectRequiredDependencies(test.packageKey, test.dependencyNames)
+		sort.Strings(actual)
+		if !reflect.DeepEqual(test.expected, actual) {
+			t.Errorf("Expected the dependencies of '%s': %v, got: %v", test.packageKey, test.expected, actual)
+		}
+	}
+}
+
+const workspacesLockfile = `{
+  "lockfileVersion": 2,
+  "packages": {
+    "": {"name": "project", "workspaces": ["packages/*"], "devDependencies": {"typescript": "^4.0.0"}},
+    "node_modules/app": {"resolved": "packages/app", "link": true},
+    "node_modules/fsevents": {"version": "2.1.3", "resolved": "http://localhost:8081/artifactory/api/npm/npm-remote/fsevents/-/fsevents-2.1.3.tgz", "integrity": "sha512-...", "optional": true},
+    "node_modules/lodash": {"version": "4.17.20", "resolved": "http://localhost:8081/artifactory/api/npm/npm-remote/lodash/-/lodash-4.17.20.tgz", "integrity": "sha512-..."},
+    "node_modules/typescript": {"version": "4.0.5", "resolved": "http://localhost:8081/artifactory/api/npm/npm-remote-other/typescript/-/typescript-4.0.5.tgz", "integrity": "sha512-...", "dev": true},
+    "packages/app": {"name": "app", "version": "1.0.0", "dependencies": {"lodash": "^4.17.0", "ms": "^2.1.0"}, "optionalDependencies": {"fsevents": "^2.1.0"}},
+    "packages/app/node_modules/ms": {"version": "2.1.2", "resolved": "http://localhost:8081/artifactory/api/npm/npm-remote/ms/-/ms-2.1.2.tgz", "integrity": "sha512-..."},
+    "packages/utils": {"name": "utils", "version": "1.0.0", "dependencies": {"app": "1.0.0"}}
+  }
+}`
+
+func TestSetDependenciesFromLockfile(t *testing.T) {
+	lockfile, err := npm.ParseLockfile([]byte(workspacesLockfile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := &npm.Workspace{PackageInfo: &npm.PackageInfo{Name: "app", Version: "1.0.0"}, Dependencies: []string{"fsevents", "lodash", "ms"}}
+	utils := &npm.Workspace{PackageInfo: &npm.PackageInfo{Name: "utils", Version: "1.0.0"}, Dependencies: []string{"app"}}
+	workingDirectory, err := ioutil.TempDir("", "npm-lockfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(workingDirectory)
+
+	tests := []struct {
+		typeRestriction      string
+		expectedDependencies []string
+		expectedRoot         []string
+	}{
+		// fsevents is an optional package which isn't installed in the working directory.
+		{"", []string{"lodash-4.17.20", "ms-2.1.2", "typescript-4.0.5"}, []string{"typescript-4.0.5"}},
+		{"production", []string{"lodash-4.17.20", "ms-2.1.2"}, nil},
+	}
+	for _, test := range tests {
+		npmi := NpmCommandArgs{workingDirectory: workingDirectory, typeRestriction: test.typeRestriction, workspaces: []*npm.Workspace{app, utils},
+			registry: "http://localhost:8081/artifactory/api/npm/npm-remote"}
+		npmi.dependencies = make(map[string]*dependency)
+		npmi.setDependenciesFromLockfile(lockfile)
+
+		var actualDependencies []string
+		for key, dep := range npmi.dependencies {
+			actualDependencies = append(actualDependencies, key)
+			// typescript is resolved from another repository, so it is looked up in Artifactory rather than in the npm cache.
+			if key == "typescript-4.0.5" {
+				if dep.integrity != "" || dep.tarballName != "" {
+					t.Errorf("Unexpected lockfile details of %s, which isn't resolved from the registry: %s, %s", key, dep.integrity, dep.tarballName)
+				}
+			} else if dep.integrity != "sha512-..." || dep.tarballName != dep.Name+"-"+dep.Version+".tgz" {
+				t.Errorf("Unexpected lockfile details of %s: %s, %s", key, dep.integrity, dep.tarballName)
+			}
+		}
+		sort.Strings(actualDependencies)
+		if !reflect.DeepEqual(test.expectedDependencies, actualDependencies) {
+			t.Errorf("Expected the dependencies: %v, got: %v", test.expectedDependencies, actualDependencies)
+		}
+		if root := npmi.collectRequiredDependencies("", []string{"typescript"}); !reflect.DeepEqual(test.expectedRoot, root) {
+			t.Errorf("Expected the dependencies of the project: %v, got: %v", test.expectedRoot, root)
+		}
+		appDependencies := npmi.collectRequiredDependencies(getWorkspaceKey(app), app.Dependencies)
+		sort.Strings(appDependencies)
+		if expected := []string{"lodash-4.17.20", "ms-2.1.2"}; !reflect.DeepEqual(expected, appDependencies) {
+			t.Errorf("Expected the dependencies of app: %v, got: %v", expected, appDependencies)
+		}
+		if utilsDependencies := npmi.collectRequiredDependencies(getWorkspaceKey(utils), utils.Dependencies); len(utilsDependencies) != 0 {
+			t.Errorf("Expected no dependencies for utils, got: %v", utilsDependencies)
+		}
+	}
+}
+
 func TestGetRegistry(t *testing.T) {
 	var getRegistryTest = []struct {
 		repo     string
@@ -129,8 +253,8 @@ func TestGetRegistry(t *testing.T) {
 	}
 
 	for _, testCase := range getRegistryTest {
-		if getNpmRepositoryUrl(testCase.repo, testCase.url) != testCase.expected {
-			t.Errorf("The expected output of getRegistry(\"%s\", \"%s\") is %s. But the actual result is:%s", testCase.repo, testCase.url, testCase.expected, getNpmRepositoryUrl(testCase.repo, testCase.url))
+		if GetNpmRepositoryUrl(testCase.repo, testCase.url) != testCase.expected {
+			t.Errorf("The expected output of getRegistry(\"%s\", \"%s\") is %s. But the actual result is:%s", testCase.repo, testCase.url, testCase.expected, GetNpmRepositoryUrl(testCase.repo, testCase.url))
 		}
 	}
 }
@@ -33,6 +33,8 @@ type NpmPublishCommandArgs struct {
 	publishPath      string
 	tarballProvided  bool
 	artifactData     []specutils.FileInfo
+	// The workspace packages to publish, when running with the --workspaces option.
+	workspaces []*npm.Workspace
 }
 
 type NpmPublishCommand struct {
@@ -88,12 +90,42 @@ func (npc *NpmPublishCommand) Run() error {
 	return npc.run()
 }
 
-func (npc *NpmPublishCommand) run() error {
+func (npc *NpmPublishCommand) run() (err error) {
 	log.Info("Running npm Publish")
-	if err := npc.preparePrerequisites(); err != nil {
+	if err = npc.preparePrerequisites(); err != nil {
 		return err
 	}
 
+	if len(npc.workspaces) > 0 {
+		err = npc.publishWorkspaces()
+	} else {
+		err = npc.publish()
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Info("npm publish finished successfully.")
+	return nil
+}
+
+// Packs and deploys each of the workspace packages, and saves each of them as a build-info module of its own.
+func (npc *NpmPublishCommand) publishWorkspaces() error {
+	npmArgs := npc.npmArgs
+	for _, workspace := range npc.workspaces {
+		log.Info("Publishing", workspace.PackageInfo.BuildInfoModuleId())
+		npc.packageInfo = workspace.PackageInfo
+		// npm packs the package in the path sent as an argument to the working directory.
+		npc.npmArgs = append([]string{workspace.Dir}, npmArgs...)
+		npc.buildConfiguration.Module = ""
+		if err := npc.publish(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (npc *NpmPublishCommand) publish() error {
 	if !npc.tarballProvided {
 		if err := npc.pack(); err != nil {
 			return err
@@ -115,16 +147,9 @@ func (npc *NpmPublishCommand) run() error {
 	}
 
 	if !npc.collectBuildInfo {
-		log.Info("npm publish finished successfully.")
 		return nil
 	}
-
-	if err := npc.saveArtifactData(); err != nil {
-		return err
-	}
-
-	log.Info("npm publish finished successfully.")
-	return nil
+	return npc.saveArtifactData()
 }
 
 func (npc *NpmPublishCommand) CommandName() string {
@@ -157,6 +182,9 @@ func (npc *NpmPublishCommand) preparePrerequisites() error {
 	npc.workingDirectory = currentDir
 	log.Debug("Working directory set to:", npc.workingDirectory)
 	npc.collectBuildInfo = len(npc.buildConfiguration.BuildName) > 0 && len(npc.buildConfiguration.BuildNumber) > 0
+	if err = npc.setWorkspaces(); err != nil {
+		return err
+	}
 	if err = npc.setPublishPath(); err != nil {
 		return err
 	}
@@ -170,9 +198,48 @@ func (npc *NpmPublishCommand) preparePrerequisites() error {
 		return err
 	}
 
+	if len(npc.workspaces) > 0 {
+		return nil
+	}
 	return npc.setPackageInfo()
 }
 
+// With the --workspaces option, sets all the workspace packages of the project which aren't private.
+func (npc *NpmPublishCommand) setWorkspaces() error {
+	workspaces := false
+	for _, flagName := range []string{"--workspaces", "-ws"} {
+		flagIndex, flagValue, err := utils.FindBooleanFlag(flagName, npc.npmArgs)
+		if err != nil {
+			return err
+		}
+		// Since boolean flag might appear as --flag or --flag=value, the value index is the same as the flag index.
+		utils.RemoveFlagFromCommand(&npc.npmArgs, flagIndex, flagIndex)
+		workspaces = workspaces || flagValue
+	}
+	if !workspaces {
+		return nil
+	}
+
+	allWorkspaces, err := npm.ReadWorkspaces(npc.workingDirectory)
+	if err != nil {
+		return err
+	}
+	for _, workspace := range allWorkspaces {
+		if workspace.Private {
+			log.Debug("Skipping the private package in", workspace.Dir)
+			continue
+		}
+		npc.workspaces = append(npc.workspaces, workspace)
+	}
+	if len(npc.workspaces) == 0 {
+		return errorutils.CheckError(errors.New("no workspace packages to publish were found. Private packages are not published"))
+	}
+	if npc.buildConfiguration.Module != "" {
+		log.Warn("The --module option is ignored with the --workspaces option. Each workspace package is saved as a module, named after its package.json.")
+	}
+	return nil
+}
+
 func (npc *NpmPublishCommand) pack() error {
 	log.Debug("Creating npm package.")
 	if err := npm.Pack(npc.npmArgs, npc.executablePath); err != nil {
@@ -0,0 +1,260 @@
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+)
+
+// Step conditions, which determine whether a step runs, according to the results of the steps before it.
+const (
+	// Run the step only if all previous steps succeeded. This is the default.
+	OnSuccess = "on-success"
+	// Run the step only if one of the previous steps failed.
+	OnFailure = "on-failure"
+	// Always run the step.
+	Always = "always"
+)
+
+// The results of the pipeline steps.
+const (
+	StepSucceeded = "succeeded"
+	StepFailed    = "failed"
+	StepSkipped   = "skipped"
+)
+
+type PipelineFile struct {
+	Version  int       `yaml:"version,omitempty"`
+	Pipeline *Pipeline `yaml:"pipeline,omitempty"`
+}
+
+// A list of jfrog commands, which share one build name, build number and server ID.
+type Pipeline struct {
+	BuildName   string `yaml:"build-name,omitempty"`
+	BuildNumber string `yaml:"build-number,omitempty"`
+	ServerId    string `yaml:"server-id,omitempty"`
+	Steps       []Step `yaml:"steps,omitempty"`
+}
+
+type Step struct {
+	Name string `yaml:"name,omitempty"`
+	// The name or alias of the 'jfrog rt' command to run, for example 'upload' or 'bp'.
+	Command string   `yaml:"command,omitempty"`
+	Args    []string `yaml:"args,omitempty"`
+	// The command options. Each option is passed to the command as --<key>=<value>.
+	Flags           map[string]interface{} `yaml:"flags,omitempty"`
+	Condition       string                 `yaml:"condition,omitempty"`
+	ContinueOnError bool                   `yaml:"continue-on-error,omitempty"`
+}
+
+func (step *Step) GetName() string {
+	if step.Name != "" {
+		return step.Name
+	}
+	return step.Command
+}
+
+// Returns the command options as command line flags, sorted by name.
+func (step *Step) GetFlags() []string {
+	var flags []string
+	for key, value := range step.Flags {
+		flags = append(flags, "--"+key+"="+fmt.Sprint(value))
+	}
+	sort.Strings(flags)
+	return flags
+}
+
+func (step *Step) HasFlag(name string) bool {
+	_, ok := step.Flags[name]
+	return ok
+}
+
+type StepResult struct {
+	Name   string
+	Status string
+	Error  error
+}
+
+// Runs a single pipeline step.
+type StepExecutor func(pipeline *Pipeline, step *Step) error
+
+type RunPipelineCommand struct {
+	pipelineFilePath string
+	executor         StepExecutor
+	results          []StepResult
+}
+
+func NewRunPipelineCommand() *RunPipelineCommand {
+	return &RunPipelineCommand{}
+}
+
+func (rpc *RunPipelineCommand) SetPipelineFilePath(pipelineFilePath string) *RunPipelineCommand {
+	rpc.pipelineFilePath = pipelineFilePath
+	return rpc
+}
+
+func (rpc *RunPipelineCommand) SetStepExecutor(executor StepExecutor) *RunPipelineCommand {
+	rpc.executor = executor
+	return rpc
+}
+
+// The results of the pipeline steps, in the order of the steps.
+func (rpc *RunPipelineCommand) Results() []StepResult {
+	return rpc.results
+}
+
+func (rpc *RunPipelineCommand) CommandName() string {
+	return "rt_run_pipeline"
+}
+
+func (rpc *RunPipelineCommand) RtDetails() (*config.ArtifactoryDetails, error) {
+	pipeline, err := ReadPipelineFile(rpc.pipelineFilePath)
+	if err != nil {
+		return nil, err
+	}
+	return config.GetArtifactorySpecificConfig(pipeline.ServerId, true, false)
+}
+
+func (rpc *RunPipelineCommand) Run() error {
+	pipeline, err := ReadPipelineFile(rpc.pipelineFilePath)
+	if err != nil {
+		return err
+	}
+	restoreEnv, err := setBuildEnv(pipeline)
+	if err != nil {
+		return err
+	}
+	defer restoreEnv()
+	rpc.results, err = RunPipeline(pipeline, rpc.executor)
+	logResults(rpc.results)
+	return err
+}
+
+// The build name and number of the pipeline are shared with its steps through the build environment variables,
+// which all build related commands read, unless the build is sent as command arguments or options.
+// Returns a function which restores the previous values of the environment variables.
+func setBuildEnv(pipeline *Pipeline) (func(), error) {
+	if pipeline.BuildName == "" {
+		return func() {}, nil
+	}
+	var restoreFuncs []func()
+	restoreEnv := func() {
+		for _, restore := range restoreFuncs {
+			restore()
+		}
+	}
+	for key, value := range map[string]string{cliutils.BuildName: pipeline.BuildName, cliutils.BuildNumber: pipeline.BuildNumber} {
+		key := key
+		oldValue, exists := os.LookupEnv(key)
+		if err := os.Setenv(key, value); err != nil {
+			restoreEnv()
+			return nil, errorutils.CheckError(err)
+		}
+		restoreFuncs = append(restoreFuncs, func() {
+			if exists {
+				os.Setenv(key, oldValue)
+			} else {
+				os.Unsetenv(key)
+			}
+		})
+	}
+	return restoreEnv, nil
+}
+
+// Runs the pipeline steps in order.
+// A failing step fails the pipeline, unless it is marked with continue-on-error.
+// After the pipeline fails, only steps with the on-failure or always conditions run.
+func RunPipeline(pipeline *Pipeline, executor StepExecutor) ([]StepResult, error) {
+	var results []StepResult
+	var failedSteps []string
+	for i := range pipeline.Steps {
+		step := &pipeline.Steps[i]
+		if !shouldRun(step, len(failedSteps) > 0) {
+			log.Info(fmt.Sprintf("[Pipeline] Skipping step %d/%d: %s", i+1, len(pipeline.Steps), step.GetName()))
+			results = append(results, StepResult{Name: step.GetName(), Status: StepSkipped})
+			continue
+		}
+		log.Info(fmt.Sprintf("[Pipeline] Running step %d/%d: %s", i+1, len(pipeline.Steps), step.GetName()))
+		err := executor(pipeline, step)
+		if err == nil {
+			results = append(results, StepResult{Name: step.GetName(), Status: StepSucceeded})
+			continue
+		}
+		results = append(results, StepResult{Name: step.GetName(), Status: StepFailed, Error: err})
+		if step.ContinueOnError {
+			log.Warn("[Pipeline] Step", step.GetName(), "failed, continuing since it is marked with continue-on-error:", err.Error())
+			continue
+		}
+		log.Error("[Pipeline] Step", step.GetName(), "failed:", err.Error())
+		failedSteps = append(failedSteps, step.GetName())
+	}
+	if len(failedSteps) > 0 {
+		return results, errorutils.CheckError(errors.New("The pipeline failed. Failed steps: " + strings.Join(failedSteps, ", ")))
+	}
+	return results, nil
+}
+
+func shouldRun(step *Step, pipelineFailed bool) bool {
+	switch step.Condition {
+	case Always:
+		return true
+	case OnFailure:
+		return pipelineFailed
+	default:
+		return !pipelineFailed
+	}
+}
+
+func logResults(results []StepResult) {
+	if len(results) == 0 {
+		return
+	}
+	summary := "[Pipeline] Steps summary:"
+	for i, result := range results {
+		summary += fmt.Sprintf("\n%d. %s: %s", i+1, result.Name, result.Status)
+	}
+	log.Info(summary)
+}
+
+// Reads and validates the pipeline file.
+func ReadPipelineFile(pipelineFilePath string) (*Pipeline, error) {
+	content, err := ioutil.ReadFile(pipelineFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	pipelineFile := new(PipelineFile)
+	err = yaml.Unmarshal(content, pipelineFile)
+	if err != nil {
+		return nil, errorutils.CheckError(errors.New("Failed parsing the pipeline file " + pipelineFilePath + ": " + err.Error()))
+	}
+	pipeline := pipelineFile.Pipeline
+	if pipeline == nil || len(pipeline.Steps) == 0 {
+		return nil, errorutils.CheckError(errors.New("The pipeline file " + pipelineFilePath + " must include at least one step under 'pipeline.steps'."))
+	}
+	if (pipeline.BuildName == "") != (pipeline.BuildNumber == "") {
+		return nil, errorutils.CheckError(errors.New("The pipeline file " + pipelineFilePath + " must include both 'build-name' and 'build-number', or neither of them."))
+	}
+	for i, step := range pipeline.Steps {
+		stepDescription := "Step " + strconv.Itoa(i+1) + " of the pipeline file " + pipelineFilePath
+		if step.Command == "" {
+			return nil, errorutils.CheckError(errors.New(stepDescription + " must include a command."))
+		}
+		switch step.Condition {
+		case "", OnSuccess, OnFailure, Always:
+		default:
+			return nil, errorutils.CheckError(errors.New(stepDescription + " has an unknown condition '" + step.Condition +
+				"'. Supported conditions are: " + strings.Join([]string{OnSuccess, OnFailure, Always}, ", ")))
+		}
+	}
+	return pipeline, nil
+}
@@ -0,0 +1,109 @@
+package pipeline
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+var pipelineTestdataPath = filepath.Join("..", "testdata", "pipeline")
+
+func TestReadPipelineFile(t *testing.T) {
+	pipeline, err := ReadPipelineFile(filepath.Join(pipelineTestdataPath, "pipeline_success.yaml"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "pipeline-build", pipeline.BuildName)
+	assert.Equal(t, "3", pipeline.BuildNumber)
+	assert.Equal(t, "local", pipeline.ServerId)
+	if assert.Len(t, pipeline.Steps, 4) {
+		assert.Equal(t, "Upload files", pipeline.Steps[0].GetName())
+		assert.Equal(t, []string{"out/*.zip", "generic-local/"}, pipeline.Steps[0].Args)
+		assert.Equal(t, []string{"--flat=true", "--threads=4"}, pipeline.Steps[0].GetFlags())
+		assert.True(t, pipeline.Steps[0].HasFlag("threads"))
+		assert.False(t, pipeline.Steps[0].HasFlag("server-id"))
+		assert.Equal(t, "bce", pipeline.Steps[1].GetName())
+		assert.True(t, pipeline.Steps[2].ContinueOnError)
+		assert.Equal(t, OnFailure, pipeline.Steps[3].Condition)
+	}
+
+	failures := []string{"pipeline_fail_no_steps.yaml", "pipeline_fail_no_build_number.yaml", "pipeline_fail_no_command.yaml", "pipeline_fail_unknown_condition.yaml"}
+	for _, file := range failures {
+		t.Run(file, func(t *testing.T) {
+			_, err := ReadPipelineFile(filepath.Join(pipelineTestdataPath, file))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRunPipeline(t *testing.T) {
+	log.SetLogger(log.NewLogger(log.INFO, nil))
+	tests := []struct {
+		name           string
+		failingSteps   []string
+		expectedStatus []string
+		expectError    bool
+	}{
+		{"success", nil, []string{StepSucceeded, StepSucceeded, StepSucceeded, StepSkipped, StepSucceeded}, false},
+		{"continueOnError", []string{"b"}, []string{StepSucceeded, StepFailed, StepSucceeded, StepSkipped, StepSucceeded}, false},
+		{"failure", []string{"a"}, []string{StepFailed, StepSkipped, StepSkipped, StepSucceeded, StepSucceeded}, true},
+		{"cleanupFailure", []string{"c", "e"}, []string{StepSucceeded, StepSucceeded, StepFailed, StepSucceeded, StepFailed}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pipeline := &Pipeline{Steps: []Step{
+				{Command: "a"},
+				{Command: "b", ContinueOnError: true},
+				{Command: "c"},
+				{Command: "d", Condition: OnFailure},
+				{Command: "e", Condition: Always},
+			}}
+			executor := func(pipeline *Pipeline, step *Step) error {
+				for _, failingStep := range test.failingSteps {
+					if step.Command == failingStep {
+						return errors.New("step failed")
+					}
+				}
+				return nil
+			}
+			results, err := RunPipeline(pipeline, executor)
+			assert.Equal(t, test.expectError, err != nil)
+			var statuses []string
+			for _, result := range results {
+				statuses = append(statuses, result.Status)
+			}
+			assert.Equal(t, test.expectedStatus, statuses)
+		})
+	}
+}
+
+func TestRunPipelineCommandBuildEnv(t *testing.T) {
+	log.SetLogger(log.NewLogger(log.INFO, nil))
+	oldBuildName, buildNameExists := os.LookupEnv(cliutils.BuildName)
+	assert.NoError(t, os.Setenv(cliutils.BuildName, "outer-build"))
+	defer func() {
+		if buildNameExists {
+			os.Setenv(cliutils.BuildName, oldBuildName)
+		} else {
+			os.Unsetenv(cliutils.BuildName)
+		}
+	}()
+
+	var buildName, buildNumber string
+	executor := func(pipeline *Pipeline, step *Step) error {
+		buildName, buildNumber = os.Getenv(cliutils.BuildName), os.Getenv(cliutils.BuildNumber)
+		return nil
+	}
+	runPipelineCommand := NewRunPipelineCommand().SetPipelineFilePath(filepath.Join(pipelineTestdataPath, "pipeline_success.yaml")).SetStepExecutor(executor)
+	assert.NoError(t, runPipelineCommand.Run())
+	assert.Equal(t, "pipeline-build", buildName)
+	assert.Equal(t, "3", buildNumber)
+	assert.Len(t, runPipelineCommand.Results(), 4)
+	// The environment is restored after the pipeline ends.
+	assert.Equal(t, "outer-build", os.Getenv(cliutils.BuildName))
+}
@@ -0,0 +1,166 @@
+package pnpm
+
+import (
+	"path/filepath"
+
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/npm"
+	"github.com/jfrog/jfrog-cli/artifactory/utils"
+	npmutils "github.com/jfrog/jfrog-cli/artifactory/utils/npm"
+	pnpmutils "github.com/jfrog/jfrog-cli/artifactory/utils/pnpm"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type PnpmInstallCommand struct {
+	*PnpmCommand
+	// The root of the project or of the workspace, where pnpm-lock.yaml is.
+	workspaceRoot string
+	// The dependencies of each workspace package, by the directory of the package relative to the workspace root.
+	modulesDependencies map[string]map[string]*npmutils.ResolvedDependency
+	// The dependencies of all the workspace packages, with their information from Artifactory.
+	dependencies map[string]*npm.Dependency
+}
+
+func NewPnpmInstallCommand() *PnpmInstallCommand {
+	return &PnpmInstallCommand{PnpmCommand: &PnpmCommand{}}
+}
+
+func (pic *PnpmInstallCommand) CommandName() string {
+	return "rt_pnpm_install"
+}
+
+func (pic *PnpmInstallCommand) Run() error {
+	log.Info("Running pnpm install.")
+	if err := pic.readConfig(utils.ProjectConfigResolverPrefix); err != nil {
+		return err
+	}
+	if err := pic.preparePrerequisites(); err != nil {
+		return err
+	}
+	registryEnv, err := pic.createRegistryEnv()
+	if err != nil {
+		return err
+	}
+
+	pnpmCmdConfig := &npmutils.PackageManagerConfig{Executable: pic.executablePath, Command: append([]string{"install"}, pic.args...), Env: registryEnv}
+	if err = gofrogcmd.RunCmd(pnpmCmdConfig); err != nil {
+		return errorutils.CheckError(err)
+	}
+
+	if !pic.collectBuildInfo {
+		log.Info("pnpm install finished successfully.")
+		return nil
+	}
+	if err = pic.setDependenciesList(); err != nil {
+		return err
+	}
+	if err = pic.collectDependenciesChecksums(); err != nil {
+		return err
+	}
+	if err = pic.saveDependenciesData(); err != nil {
+		return err
+	}
+	log.Info("pnpm install finished successfully.")
+	return nil
+}
+
+// pnpm resolves the dependencies through the npm repository, using the npm_config_ environment variables which override the registry and its authentication.
+func (pic *PnpmInstallCommand) createRegistryEnv() (map[string]string, error) {
+	registry, npmAuth, err := npm.GetRegistryDetails(pic.rtDetails, pic.repo)
+	if err != nil {
+		return nil, err
+	}
+	return npmutils.CreateRegistryEnv(registry, npmAuth), nil
+}
+
+func (pic *PnpmInstallCommand) setDependenciesList() (err error) {
+	if pic.workspaceRoot, err = pnpmutils.GetWorkspaceRoot(); err != nil {
+		return err
+	}
+	lockfile, err := pnpmutils.ReadLockfile(pic.workspaceRoot)
+	if err != nil {
+		return err
+	}
+	pic.modulesDependencies = make(map[string]map[string]*npmutils.ResolvedDependency)
+	pic.dependencies = make(map[string]*npm.Dependency)
+	for _, importerDir := range lockfile.ImporterDirs() {
+		moduleDependencies := lockfile.CollectDependencies(importerDir)
+		pic.modulesDependencies[importerDir] = moduleDependencies
+		for key, pnpmDependency := range moduleDependencies {
+			if pic.dependencies[key] == nil {
+				pic.dependencies[key] = &npm.Dependency{Name: pnpmDependency.Name, Version: pnpmDependency.Version}
+			}
+		}
+	}
+	return nil
+}
+
+// Collects the information of the dependencies from Artifactory. The dependencies shared by the workspace packages are queried once.
+func (pic *PnpmInstallCommand) collectDependenciesChecksums() error {
+	var dependencies []*npm.Dependency
+	for _, dep := range pic.dependencies {
+		dependencies = append(dependencies, dep)
+	}
+	return npm.CollectDependenciesChecksums(pic.rtDetails, pic.threads, dependencies, nil)
+}
+
+// Saves a build-info module for each workspace package, with the dependencies of the package.
+func (pic *PnpmInstallCommand) saveDependenciesData() error {
+	log.Debug("Saving data.")
+	if pic.buildConfiguration.Module != "" && len(pic.modulesDependencies) > 1 {
+		log.Warn("The --module option is ignored for workspaces. Each workspace package is saved as a module, named after its package.json.")
+		pic.buildConfiguration.Module = ""
+	}
+	for importerDir, moduleDependencies := range pic.modulesDependencies {
+		moduleId, err := pic.getModuleId(importerDir)
+		if err != nil {
+			return err
+		}
+		if err = npm.SaveModuleDependencies(pic.buildConfiguration, moduleId, pic.transformDependencies(moduleDependencies)); err != nil {
+			return err
+		}
+	}
+
+	var missingDependencies []*npm.Dependency
+	for _, dep := range pic.dependencies {
+		if dep.ArtifactName == "" {
+			missingDependencies = append(missingDependencies, dep)
+		}
+	}
+	// The packages in the pnpm store are not downloaded again, so the store is deleted rather than pruned, which removes only the unreferenced packages.
+	npm.WarnMissingDependencies("pnpm", "Deleting the pnpm store (its location is printed by 'pnpm store path') and rerunning the install", missingDependencies)
+	return nil
+}
+
+func (pic *PnpmInstallCommand) getModuleId(importerDir string) (string, error) {
+	if pic.buildConfiguration.Module != "" {
+		return pic.buildConfiguration.Module, nil
+	}
+	packageDir := filepath.Join(pic.workspaceRoot, importerDir)
+	packageInfo, err := npmutils.ReadPackageInfoFromPackageJson(packageDir)
+	if err != nil {
+		return "", err
+	}
+	if packageInfo.Name == "" {
+		// Workspace roots are often unnamed.
+		return filepath.Base(packageDir), nil
+	}
+	return packageInfo.BuildInfoModuleId(), nil
+}
+
+// Transforms the dependencies of a workspace package to a buildinfo.Dependencies list, without the dependencies missing in Artifactory.
+// The scopes of a dependency are the ones it has in the workspace package.
+func (pic *PnpmInstallCommand) transformDependencies(moduleDependencies map[string]*npmutils.ResolvedDependency) (dependencies []buildinfo.Dependency) {
+	for key, moduleDependency := range moduleDependencies {
+		dep := pic.dependencies[key]
+		if dep.ArtifactName == "" {
+			continue
+		}
+		buildInfoDependency := dep.ToBuildInfoDependency()
+		buildInfoDependency.Scopes = moduleDependency.Scopes
+		dependencies = append(dependencies, buildInfoDependency)
+	}
+	return
+}
@@ -0,0 +1,78 @@
+package pnpm
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-cli/artifactory/utils"
+	npmutils "github.com/jfrog/jfrog-cli/artifactory/utils/npm"
+	"github.com/jfrog/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type PnpmCommand struct {
+	configFilePath     string
+	args               []string
+	threads            int
+	repo               string
+	rtDetails          *config.ArtifactoryDetails
+	buildConfiguration *utils.BuildConfiguration
+	collectBuildInfo   bool
+	executablePath     string
+	workingDirectory   string
+}
+
+func (pc *PnpmCommand) SetConfigFilePath(configFilePath string) *PnpmCommand {
+	pc.configFilePath = configFilePath
+	return pc
+}
+
+func (pc *PnpmCommand) SetArgs(args []string) *PnpmCommand {
+	pc.args = args
+	return pc
+}
+
+func (pc *PnpmCommand) RtDetails() (*config.ArtifactoryDetails, error) {
+	return pc.rtDetails, nil
+}
+
+// Reads the repository of the resolver or the deployer from the config file, and extracts the JFrog CLI options from the args.
+func (pc *PnpmCommand) readConfig(prefix string) error {
+	log.Debug("Preparing to read the config file", pc.configFilePath)
+	vConfig, err := utils.ReadConfigFile(pc.configFilePath, utils.YAML)
+	if err != nil {
+		return err
+	}
+	repoConfig, err := utils.GetRepoConfigByPrefix(pc.configFilePath, prefix, vConfig)
+	if err != nil {
+		return err
+	}
+	pc.repo = repoConfig.TargetRepo()
+	if pc.rtDetails, err = repoConfig.RtDetails(); err != nil {
+		return err
+	}
+	pc.threads, pc.args, pc.buildConfiguration, err = npmutils.ExtractOptionsFromArgs(pc.args)
+	return err
+}
+
+func (pc *PnpmCommand) preparePrerequisites() error {
+	log.Debug("Preparing prerequisites.")
+	var err error
+	if pc.executablePath, err = npmutils.GetPackageManagerExecutablePath("pnpm"); err != nil {
+		return err
+	}
+	if pc.workingDirectory, err = os.Getwd(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if pc.workingDirectory, err = filepath.Abs(pc.workingDirectory); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Debug("Working directory set to:", pc.workingDirectory)
+
+	if len(pc.buildConfiguration.BuildName) > 0 && len(pc.buildConfiguration.BuildNumber) > 0 {
+		pc.collectBuildInfo = true
+		return utils.SaveBuildGeneralDetails(pc.buildConfiguration.BuildName, pc.buildConfiguration.BuildNumber)
+	}
+	return nil
+}
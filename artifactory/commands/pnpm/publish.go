@@ -0,0 +1,185 @@
+package pnpm
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli/artifactory/utils"
+	npmutils "github.com/jfrog/jfrog-cli/artifactory/utils/npm"
+	pnpmutils "github.com/jfrog/jfrog-cli/artifactory/utils/pnpm"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type PnpmPublishCommand struct {
+	*PnpmCommand
+	// The directories of the packages to publish.
+	packageDirs []string
+}
+
+func NewPnpmPublishCommand() *PnpmPublishCommand {
+	return &PnpmPublishCommand{PnpmCommand: &PnpmCommand{}}
+}
+
+func (ppc *PnpmPublishCommand) CommandName() string {
+	return "rt_pnpm_publish"
+}
+
+func (ppc *PnpmPublishCommand) Run() error {
+	log.Info("Running pnpm publish.")
+	if err := ppc.readConfig(utils.ProjectConfigDeployerPrefix); err != nil {
+		return err
+	}
+	if err := ppc.preparePrerequisites(); err != nil {
+		return err
+	}
+	artDetails, err := ppc.rtDetails.CreateArtAuthConfig()
+	if err != nil {
+		return err
+	}
+	if err = utils.CheckIfRepoExists(ppc.repo, artDetails); err != nil {
+		return err
+	}
+	if err = ppc.setPackageDirs(); err != nil {
+		return err
+	}
+
+	servicesManager, err := utils.CreateServiceManager(ppc.rtDetails, false)
+	if err != nil {
+		return err
+	}
+	for _, packageDir := range ppc.packageDirs {
+		if err = ppc.publish(servicesManager, packageDir); err != nil {
+			return err
+		}
+	}
+	log.Info("pnpm publish finished successfully.")
+	return nil
+}
+
+// Sets the package in the path sent as an argument, or in the working directory.
+// With the --recursive option, sets all the workspace packages which aren't private instead.
+func (ppc *PnpmPublishCommand) setPackageDirs() error {
+	recursive, err := ppc.extractRecursiveFlag()
+	if err != nil {
+		return err
+	}
+	if !recursive {
+		packageDir := ppc.workingDirectory
+		if len(ppc.args) > 0 && !strings.HasPrefix(ppc.args[0], "-") {
+			packageDir = clientutils.ReplaceTildeWithUserHome(ppc.args[0])
+			if !filepath.IsAbs(packageDir) {
+				packageDir = filepath.Join(ppc.workingDirectory, packageDir)
+			}
+			ppc.args = ppc.args[1:]
+		}
+		ppc.packageDirs = []string{packageDir}
+		return nil
+	}
+
+	workspaceRoot, err := pnpmutils.GetWorkspaceRoot()
+	if err != nil {
+		return err
+	}
+	lockfile, err := pnpmutils.ReadLockfile(workspaceRoot)
+	if err != nil {
+		return err
+	}
+	for _, importerDir := range lockfile.ImporterDirs() {
+		packageDir := filepath.Join(workspaceRoot, importerDir)
+		private, err := pnpmutils.IsPrivatePackage(packageDir)
+		if err != nil {
+			return err
+		}
+		if private {
+			log.Debug("Skipping the private package in", packageDir)
+			continue
+		}
+		ppc.packageDirs = append(ppc.packageDirs, packageDir)
+	}
+	if len(ppc.packageDirs) == 0 {
+		return errorutils.CheckError(errors.New("no workspace packages to publish were found. Private packages are not published"))
+	}
+	return nil
+}
+
+func (ppc *PnpmPublishCommand) extractRecursiveFlag() (bool, error) {
+	for _, flagName := range []string{"--recursive", "-r"} {
+		flagIndex, recursive, err := utils.FindBooleanFlag(flagName, ppc.args)
+		if err != nil {
+			return false, err
+		}
+		// Since boolean flag might appear as --flag or --flag=value, the value index is the same as the flag index.
+		utils.RemoveFlagFromCommand(&ppc.args, flagIndex, flagIndex)
+		if recursive {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Packs the package in packageDir, deploys the package to the repository, and adds it to the build-info as a module of its own.
+func (ppc *PnpmPublishCommand) publish(servicesManager *artifactory.ArtifactoryServicesManager, packageDir string) error {
+	packageInfo, err := npmutils.ReadPackageInfoFromPackageJson(packageDir)
+	if err != nil {
+		return err
+	}
+	log.Info("Publishing", packageInfo.BuildInfoModuleId())
+	pnpmCmdConfig := &npmutils.PackageManagerConfig{Executable: ppc.executablePath, Command: append([]string{"pack"}, ppc.args...), Dir: packageDir}
+	if err = gofrogcmd.RunCmd(pnpmCmdConfig); err != nil {
+		return errorutils.CheckError(err)
+	}
+	packedFilePath := filepath.Join(packageDir, packageInfo.GetExpectedPackedFileName())
+	log.Debug("Created pnpm package at", packedFilePath)
+
+	artifactsFileInfo, err := ppc.deploy(servicesManager, packedFilePath, fmt.Sprintf("%s/%s", ppc.repo, packageInfo.GetDeployPath()))
+	if removeErr := os.Remove(packedFilePath); removeErr != nil && err == nil {
+		err = errorutils.CheckError(removeErr)
+	}
+	if err != nil || !ppc.collectBuildInfo {
+		return err
+	}
+
+	var buildArtifacts []buildinfo.Artifact
+	for _, artifact := range artifactsFileInfo {
+		buildArtifacts = append(buildArtifacts, artifact.ToBuildArtifacts())
+	}
+	populateFunc := func(partial *buildinfo.Partial) {
+		partial.Artifacts = buildArtifacts
+		partial.ModuleId = packageInfo.BuildInfoModuleId()
+		if ppc.buildConfiguration.Module != "" && len(ppc.packageDirs) == 1 {
+			partial.ModuleId = ppc.buildConfiguration.Module
+		}
+	}
+	return utils.SavePartialBuildInfo(ppc.buildConfiguration.BuildName, ppc.buildConfiguration.BuildNumber, populateFunc)
+}
+
+func (ppc *PnpmPublishCommand) deploy(servicesManager *artifactory.ArtifactoryServicesManager, packedFilePath, target string) ([]specutils.FileInfo, error) {
+	log.Debug("Deploying pnpm package to", target)
+	up := services.UploadParams{}
+	up.ArtifactoryCommonParams = &specutils.ArtifactoryCommonParams{Pattern: packedFilePath, Target: target}
+	if ppc.collectBuildInfo {
+		props, err := utils.CreateBuildProperties(ppc.buildConfiguration.BuildName, ppc.buildConfiguration.BuildNumber)
+		if err != nil {
+			return nil, err
+		}
+		up.ArtifactoryCommonParams.Props = props
+	}
+	artifactsFileInfo, _, failed, err := servicesManager.UploadFiles(up)
+	if err != nil {
+		return nil, err
+	}
+	if failed > 0 {
+		return nil, errorutils.CheckError(errors.New("Failed to upload the pnpm package to Artifactory. See Artifactory logs for more details."))
+	}
+	return artifactsFileInfo, nil
+}
@@ -89,6 +89,10 @@ func CreateBuildConfig(c *cli.Context, confType utils.ProjectType) (err error) {
 			err = configFile.configPip()
 		case utils.Npm:
 			err = configFile.configNpm()
+		case utils.Yarn:
+			err = configFile.configYarn()
+		case utils.Pnpm:
+			err = configFile.configPnpm()
 		case utils.Dotnet:
 			fallthrough
 		case utils.Nuget:
@@ -203,6 +207,14 @@ func (configFile *ConfigFile) configNpm() error {
 	return configFile.setDeployerResolver()
 }
 
+func (configFile *ConfigFile) configYarn() error {
+	return configFile.setResolver()
+}
+
+func (configFile *ConfigFile) configPnpm() error {
+	return configFile.setDeployerResolver()
+}
+
 func (configFile *ConfigFile) configDotnet() error {
 	return configFile.setResolver()
 }
@@ -1,11 +1,20 @@
 package utils
 
-import "github.com/jfrog/jfrog-client-go/utils/io/content"
+import (
+	"time"
+
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+)
 
 type Result struct {
-	successCount int
-	failCount    int
-	reader       *content.ContentReader
+	successCount     int
+	failCount        int
+	reader           *content.ContentReader
+	failures         []summary.FileFailure
+	retries          int
+	transferredBytes int64
+	duration         time.Duration
 }
 
 func (r *Result) SuccessCount() int {
@@ -20,6 +29,22 @@ func (r *Result) Reader() *content.ContentReader {
 	return r.reader
 }
 
+func (r *Result) Failures() []summary.FileFailure {
+	return r.failures
+}
+
+func (r *Result) Retries() int {
+	return r.retries
+}
+
+func (r *Result) TransferredBytes() int64 {
+	return r.transferredBytes
+}
+
+func (r *Result) Duration() time.Duration {
+	return r.duration
+}
+
 func (r *Result) SetSuccessCount(successCount int) {
 	r.successCount = successCount
 }
@@ -31,3 +56,19 @@ func (r *Result) SetFailCount(failCount int) {
 func (r *Result) SetReader(reader *content.ContentReader) {
 	r.reader = reader
 }
+
+func (r *Result) AddFailures(failures ...summary.FileFailure) {
+	r.failures = append(r.failures, failures...)
+}
+
+func (r *Result) SetRetries(retries int) {
+	r.retries = retries
+}
+
+func (r *Result) SetTransferredBytes(transferredBytes int64) {
+	r.transferredBytes = transferredBytes
+}
+
+func (r *Result) SetDuration(duration time.Duration) {
+	r.duration = duration
+}
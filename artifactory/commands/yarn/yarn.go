@@ -0,0 +1,179 @@
+package yarn
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/npm"
+	"github.com/jfrog/jfrog-cli/artifactory/utils"
+	npmutils "github.com/jfrog/jfrog-cli/artifactory/utils/npm"
+	yarnutils "github.com/jfrog/jfrog-cli/artifactory/utils/yarn"
+	"github.com/jfrog/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const minSupportedYarnVersion = "1.0.0"
+
+type YarnCommand struct {
+	configFilePath     string
+	args               []string
+	threads            int
+	repo               string
+	rtDetails          *config.ArtifactoryDetails
+	buildConfiguration *utils.BuildConfiguration
+	collectBuildInfo   bool
+	executablePath     string
+	workingDirectory   string
+	registryEnv        map[string]string
+	dependencies       []*npm.Dependency
+}
+
+func NewYarnCommand() *YarnCommand {
+	return &YarnCommand{}
+}
+
+func (yc *YarnCommand) SetConfigFilePath(configFilePath string) *YarnCommand {
+	yc.configFilePath = configFilePath
+	return yc
+}
+
+func (yc *YarnCommand) SetArgs(args []string) *YarnCommand {
+	yc.args = args
+	return yc
+}
+
+func (yc *YarnCommand) RtDetails() (*config.ArtifactoryDetails, error) {
+	return yc.rtDetails, nil
+}
+
+func (yc *YarnCommand) CommandName() string {
+	return "rt_yarn"
+}
+
+func (yc *YarnCommand) Run() error {
+	log.Info("Running yarn.")
+	if err := yc.readConfig(); err != nil {
+		return err
+	}
+	if err := yc.preparePrerequisites(); err != nil {
+		return err
+	}
+
+	yarnCmdConfig := &npmutils.PackageManagerConfig{Executable: yc.executablePath, Command: yc.args, Env: yc.registryEnv}
+	if err := gofrogcmd.RunCmd(yarnCmdConfig); err != nil {
+		return errorutils.CheckError(err)
+	}
+
+	if !yc.collectBuildInfo {
+		log.Info("yarn finished successfully.")
+		return nil
+	}
+	if err := yc.setDependenciesList(); err != nil {
+		return err
+	}
+	if err := npm.CollectDependenciesChecksums(yc.rtDetails, yc.threads, yc.dependencies, nil); err != nil {
+		return err
+	}
+	if err := yc.saveDependenciesData(); err != nil {
+		return err
+	}
+	log.Info("yarn finished successfully.")
+	return nil
+}
+
+func (yc *YarnCommand) readConfig() error {
+	log.Debug("Preparing to read the config file", yc.configFilePath)
+	vConfig, err := utils.ReadConfigFile(yc.configFilePath, utils.YAML)
+	if err != nil {
+		return err
+	}
+	resolverParams, err := utils.GetRepoConfigByPrefix(yc.configFilePath, utils.ProjectConfigResolverPrefix, vConfig)
+	if err != nil {
+		return err
+	}
+	yc.repo = resolverParams.TargetRepo()
+	if yc.rtDetails, err = resolverParams.RtDetails(); err != nil {
+		return err
+	}
+	yc.threads, yc.args, yc.buildConfiguration, err = npmutils.ExtractOptionsFromArgs(yc.args)
+	return err
+}
+
+func (yc *YarnCommand) preparePrerequisites() error {
+	log.Debug("Preparing prerequisites.")
+	var err error
+	if yc.executablePath, err = npmutils.GetPackageManagerExecutablePath("yarn"); err != nil {
+		return err
+	}
+	yarnVersion, err := yarnutils.Version(yc.executablePath)
+	if err != nil {
+		return err
+	}
+	if !yarnVersion.AtLeast(minSupportedYarnVersion) {
+		return errorutils.CheckError(errors.New("JFrog CLI yarn command requires yarn client version " + minSupportedYarnVersion + " or higher"))
+	}
+
+	if yc.workingDirectory, err = os.Getwd(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if yc.workingDirectory, err = filepath.Abs(yc.workingDirectory); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Debug("Working directory set to:", yc.workingDirectory)
+
+	if err = yc.prepareRegistry(yarnutils.IsBerry(yarnVersion)); err != nil {
+		return err
+	}
+
+	if len(yc.buildConfiguration.BuildName) > 0 && len(yc.buildConfiguration.BuildNumber) > 0 {
+		yc.collectBuildInfo = true
+		return utils.SaveBuildGeneralDetails(yc.buildConfiguration.BuildName, yc.buildConfiguration.BuildNumber)
+	}
+	return nil
+}
+
+// Yarn resolves the dependencies through the npm repository, using environment variables which override the registry and its authentication.
+func (yc *YarnCommand) prepareRegistry(berry bool) error {
+	registry, npmAuth, err := npm.GetRegistryDetails(yc.rtDetails, yc.repo)
+	if err != nil {
+		return err
+	}
+	yc.registryEnv = yarnutils.CreateRegistryEnv(registry, npmAuth, berry)
+	return nil
+}
+
+func (yc *YarnCommand) setDependenciesList() error {
+	packageJson, err := yarnutils.ReadPackageJson(yc.workingDirectory)
+	if err != nil {
+		return err
+	}
+	lockfile, err := yarnutils.ReadLockfile(yc.workingDirectory)
+	if err != nil {
+		return err
+	}
+	yc.dependencies = nil
+	for _, yarnDependency := range lockfile.CollectDependencies(packageJson) {
+		yc.dependencies = append(yc.dependencies, &npm.Dependency{Name: yarnDependency.Name, Version: yarnDependency.Version, Scopes: yarnDependency.Scopes})
+	}
+	return nil
+}
+
+func (yc *YarnCommand) saveDependenciesData() error {
+	log.Debug("Saving data.")
+	dependencies, missingDependencies := npm.TransformDependencies(yc.dependencies)
+	if yc.buildConfiguration.Module == "" {
+		packageInfo, err := npmutils.ReadPackageInfoFromPackageJson(yc.workingDirectory)
+		if err != nil {
+			return err
+		}
+		yc.buildConfiguration.Module = packageInfo.BuildInfoModuleId()
+	}
+	if err := npm.SaveModuleDependencies(yc.buildConfiguration, yc.buildConfiguration.Module, dependencies); err != nil {
+		return err
+	}
+	npm.WarnMissingDependencies("yarn", "Running 'yarn cache clean'", missingDependencies)
+	return nil
+}
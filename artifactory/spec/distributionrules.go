@@ -42,6 +42,12 @@ func CreateDistributionRulesFromFile(distributionSpecPath string) (*Distribution
 	if err != nil {
 		return nil, errorutils.CheckError(err)
 	}
+	if isYamlSpec(distributionSpecPath, content) {
+		content, err = convertYamlToJson(content, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
 	distributionRules := new(DistributionRules)
 	err = json.Unmarshal(content, distributionRules)
 	if err != nil {
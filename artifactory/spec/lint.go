@@ -0,0 +1,208 @@
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+type IssueSeverity string
+
+const (
+	IssueError   IssueSeverity = "error"
+	IssueWarning IssueSeverity = "warning"
+)
+
+// A problem found in a File Spec by LintSpecFile.
+type SpecIssue struct {
+	// The index of the file group the issue was found in, or -1 if the issue isn't related to a specific file group.
+	FileGroup int
+	Severity  IssueSeverity
+	Message   string
+}
+
+func (issue SpecIssue) String() string {
+	if issue.FileGroup < 0 {
+		return issue.Message
+	}
+	return fmt.Sprintf("File group %d: %s", issue.FileGroup+1, issue.Message)
+}
+
+// The keys of the spec root and of the AQL, by their lowercase names.
+var (
+	specKeys = map[string]string{"files": "files"}
+	aqlKeys  = map[string]string{"items.find": "items.find"}
+)
+
+// Reads the spec file and checks it for problems which are not detected when the spec is used.
+// json.Unmarshal ignores unknown keys, so a typo in a key silently changes the behaviour of the spec.
+// In addition to the validations of ValidateSpec, which also warns about deprecated keys,
+// unknown keys and invalid boolean values are reported.
+// An error is returned if the spec can't be parsed.
+func LintSpecFile(specFilePath string, specVars map[string]string) (*SpecFiles, []SpecIssue, error) {
+	content, err := readSpecFile(specFilePath, specVars)
+	if err != nil {
+		return nil, nil, err
+	}
+	spec := new(SpecFiles)
+	if err = json.Unmarshal(content, spec); errorutils.CheckError(err) != nil {
+		return nil, nil, err
+	}
+	var issues []SpecIssue
+	var rawSpec map[string]json.RawMessage
+	if err = json.Unmarshal(content, &rawSpec); errorutils.CheckError(err) != nil {
+		return nil, nil, err
+	}
+	for _, key := range unknownKeys(rawSpec, specKeys) {
+		// Keys which are not part of the spec are allowed at the root, to define YAML anchors.
+		issues = append(issues, SpecIssue{FileGroup: -1, Severity: IssueWarning, Message: unknownKeyMessage("", key, specKeys)})
+	}
+	var rawFiles []map[string]json.RawMessage
+	for key, value := range rawSpec {
+		if strings.ToLower(key) == "files" {
+			if err = json.Unmarshal(value, &rawFiles); errorutils.CheckError(err) != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if len(spec.Files) == 0 {
+		issues = append(issues, SpecIssue{FileGroup: -1, Severity: IssueError, Message: "Spec must include at least one file group"})
+	}
+	for i, file := range spec.Files {
+		if i < len(rawFiles) {
+			issues = append(issues, lintRawFileGroup(i, rawFiles[i])...)
+		}
+		issues = append(issues, lintFileGroup(i, file)...)
+	}
+	return spec, issues, nil
+}
+
+func lintRawFileGroup(index int, rawFile map[string]json.RawMessage) (issues []SpecIssue) {
+	fileKeys := getFileKeys()
+	for _, key := range unknownKeys(rawFile, fileKeys) {
+		issues = append(issues, SpecIssue{FileGroup: index, Severity: IssueError, Message: unknownKeyMessage("", key, fileKeys)})
+	}
+	for key, value := range rawFile {
+		if strings.ToLower(key) != "aql" {
+			continue
+		}
+		var rawAql map[string]json.RawMessage
+		if json.Unmarshal(value, &rawAql) != nil {
+			issues = append(issues, SpecIssue{FileGroup: index, Severity: IssueError, Message: "The value of 'aql' must be an object, such as {\"items.find\": {...}}."})
+			continue
+		}
+		for _, aqlKey := range unknownKeys(rawAql, aqlKeys) {
+			issues = append(issues, SpecIssue{FileGroup: index, Severity: IssueError, Message: unknownKeyMessage("aql.", aqlKey, aqlKeys)})
+		}
+	}
+	return
+}
+
+func lintFileGroup(index int, file File) (issues []SpecIssue) {
+	if err := ValidateSpec([]File{file}, false, true); err != nil {
+		issues = append(issues, SpecIssue{FileGroup: index, Severity: IssueError, Message: err.Error()})
+	}
+	booleans := []struct {
+		key   string
+		value string
+	}{
+		{"explode", file.Explode},
+		{"recursive", file.Recursive},
+		{"flat", file.Flat},
+		{"regexp", file.Regexp},
+		{"includeDirs", file.IncludeDirs},
+		{"archiveEntries", file.ArchiveEntries},
+		{"validateSymlinks", file.ValidateSymlinks},
+	}
+	for _, boolean := range booleans {
+		if _, err := clientutils.StringToBool(boolean.value, false); err != nil {
+			issues = append(issues, SpecIssue{FileGroup: index, Severity: IssueError,
+				Message: fmt.Sprintf("The value of '%s' must be \"true\" or \"false\", but it is \"%s\".", boolean.key, boolean.value)})
+		}
+	}
+	return
+}
+
+// Returns the keys of the File struct, as json.Unmarshal matches them, by their lowercase names.
+func getFileKeys() map[string]string {
+	fileType := reflect.TypeOf(File{})
+	keys := make(map[string]string, fileType.NumField())
+	for i := 0; i < fileType.NumField(); i++ {
+		name := fileType.Field(i).Name
+		keys[strings.ToLower(name)] = strings.ToLower(name[:1]) + name[1:]
+	}
+	return keys
+}
+
+// Returns the sorted keys of the raw object which are not known keys.
+// Like json.Unmarshal, the keys are matched case-insensitively.
+func unknownKeys(rawObject map[string]json.RawMessage, knownKeys map[string]string) (unknown []string) {
+	for key := range rawObject {
+		if _, ok := knownKeys[strings.ToLower(key)]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return
+}
+
+func unknownKeyMessage(prefix, key string, knownKeys map[string]string) string {
+	message := fmt.Sprintf("Unknown key '%s' is ignored.", prefix+key)
+	if suggestion := suggestKey(key, knownKeys); suggestion != "" {
+		message += fmt.Sprintf(" Did you mean '%s'?", prefix+suggestion)
+	}
+	return message
+}
+
+// Returns the known key closest to the unknown key, if it's close enough to be a typo.
+func suggestKey(key string, knownKeys map[string]string) (suggestion string) {
+	var lowerKeys []string
+	for lowerKey := range knownKeys {
+		lowerKeys = append(lowerKeys, lowerKey)
+	}
+	sort.Strings(lowerKeys)
+	bestDistance := 3
+	for _, lowerKey := range lowerKeys {
+		if distance := editDistance(strings.ToLower(key), lowerKey); distance < bestDistance {
+			bestDistance = distance
+			suggestion = knownKeys[lowerKey]
+		}
+	}
+	return
+}
+
+// Returns the Levenshtein distance between the strings.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+func minInt(values ...int) int {
+	min := values[0]
+	for _, value := range values[1:] {
+		if value < min {
+			min = value
+		}
+	}
+	return min
+}
@@ -0,0 +1,39 @@
+package spec
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintSpecFile(t *testing.T) {
+	log.SetDefaultLogger()
+	tempDir, err := ioutil.TempDir("", "spec")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	specFilePath := filepath.Join(tempDir, "spec.json")
+	content := `{
+  "comment": "Unknown keys at the root are allowed",
+  "files": [
+    {"pattern": "repo/*.zip", "Flat": "true", "recursiv": "false"},
+    {"aql": {"items.finds": {"repo": "repo"}}},
+    {"aql": {"items.find": {"repo": "repo"}}, "pattern": "repo/*", "regexp": "maybe"}
+  ]
+}`
+	assert.NoError(t, ioutil.WriteFile(specFilePath, []byte(content), 0644))
+	spec, issues, err := LintSpecFile(specFilePath, nil)
+	assert.NoError(t, err)
+	assert.Len(t, spec.Files, 3)
+	assert.Equal(t, []SpecIssue{
+		{FileGroup: -1, Severity: IssueWarning, Message: "Unknown key 'comment' is ignored."},
+		{FileGroup: 0, Severity: IssueError, Message: "Unknown key 'recursiv' is ignored. Did you mean 'recursive'?"},
+		{FileGroup: 1, Severity: IssueError, Message: "Unknown key 'aql.items.finds' is ignored. Did you mean 'aql.items.find'?"},
+		{FileGroup: 2, Severity: IssueError, Message: "Spec cannot include both 'aql' and 'pattern.'"},
+		{FileGroup: 2, Severity: IssueError, Message: "The value of 'regexp' must be \"true\" or \"false\", but it is \"maybe\"."},
+	}, issues)
+	assert.Equal(t, "File group 3: The value of 'regexp' must be \"true\" or \"false\", but it is \"maybe\".", issues[4].String())
+}
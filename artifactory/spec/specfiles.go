@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"github.com/jfrog/jfrog-cli/utils/cliutils"
 	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -25,15 +24,11 @@ func (spec *SpecFiles) Get(index int) *File {
 
 func CreateSpecFromFile(specFilePath string, specVars map[string]string) (spec *SpecFiles, err error) {
 	spec = new(SpecFiles)
-	content, err := fileutils.ReadFile(specFilePath)
-	if errorutils.CheckError(err) != nil {
+	content, err := readSpecFile(specFilePath, specVars)
+	if err != nil {
 		return
 	}
 
-	if len(specVars) > 0 {
-		content = cliutils.ReplaceVars(content, specVars)
-	}
-
 	err = json.Unmarshal(content, spec)
 	if errorutils.CheckError(err) != nil {
 		return
@@ -41,6 +36,22 @@ func CreateSpecFromFile(specFilePath string, specVars map[string]string) (spec *
 	return
 }
 
+// Reads the spec file, renders its template and returns its content as JSON.
+func readSpecFile(specFilePath string, specVars map[string]string) ([]byte, error) {
+	content, err := fileutils.ReadFile(specFilePath)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	content, err = RenderSpecTemplate(content, specVars)
+	if err != nil {
+		return nil, err
+	}
+	if isYamlSpec(specFilePath, content) {
+		return convertYamlToJson(content, convertFileGroupsBooleans)
+	}
+	return content, nil
+}
+
 type File struct {
 	Aql     utils.Aql
 	Pattern string
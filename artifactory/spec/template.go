@@ -0,0 +1,88 @@
+package spec
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Matches a variable in a File Spec. For example: "${version}" or "${version:-1.0}".
+// A variable prefixed with an additional '$', such as "$${version}", is escaped and kept as "${version}".
+var specVarRegexp = regexp.MustCompile(`\$?\$\{([^{}]+?)(:-([^{}]*))?\}`)
+
+// Renders the File Spec template with the provided variables.
+// The spec is first executed as a Go template, which may use conditionals and loops to generate file groups.
+// The template can access the variables as {{.key}}, and use the following functions:
+// env - returns the value of an environment variable, for example: {{env "BRANCH"}}.
+// split - splits a string by a separator, for example: {{range split .modules ","}}.
+// Then, every ${key} is replaced with the value of the variable, or the environment variable with the same name.
+// If neither is defined, the default value provided as ${key:-default} is used.
+// Undefined variables without a default value fail the rendering.
+func RenderSpecTemplate(content []byte, specVars map[string]string) ([]byte, error) {
+	content, err := executeSpecTemplate(content, specVars)
+	if err != nil {
+		return nil, err
+	}
+	return replaceSpecVars(content, specVars)
+}
+
+func executeSpecTemplate(content []byte, specVars map[string]string) ([]byte, error) {
+	if !bytes.Contains(content, []byte("{{")) {
+		return content, nil
+	}
+	specTemplate, err := template.New("spec").Option("missingkey=error").Funcs(template.FuncMap{
+		"env":   os.Getenv,
+		"split": strings.Split,
+	}).Parse(string(content))
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	if specVars == nil {
+		specVars = make(map[string]string)
+	}
+	var rendered bytes.Buffer
+	if err = specTemplate.Execute(&rendered, specVars); errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	log.Debug("The File Spec after executing the template: \n" + rendered.String())
+	return rendered.Bytes(), nil
+}
+
+func replaceSpecVars(content []byte, specVars map[string]string) ([]byte, error) {
+	undefined := make(map[string]bool)
+	content = specVarRegexp.ReplaceAllFunc(content, func(match []byte) []byte {
+		if bytes.HasPrefix(match, []byte("$$")) {
+			return match[1:]
+		}
+		groups := specVarRegexp.FindSubmatch(match)
+		key := string(groups[1])
+		if value, ok := specVars[key]; ok {
+			return []byte(value)
+		}
+		if value, ok := os.LookupEnv(key); ok {
+			return []byte(value)
+		}
+		if len(groups[2]) > 0 {
+			return groups[3]
+		}
+		undefined[key] = true
+		return match
+	})
+	if len(undefined) > 0 {
+		var keys []string
+		for key := range undefined {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return nil, errorutils.CheckError(errors.New("the File Spec uses undefined variables: " + strings.Join(keys, ", ") +
+			". Define them using the --spec-vars option or as environment variables, or provide default values as ${key:-default}"))
+	}
+	return content, nil
+}
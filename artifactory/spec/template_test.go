@@ -0,0 +1,50 @@
+package spec
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderSpecTemplate(t *testing.T) {
+	log.SetDefaultLogger()
+	os.Setenv("JFROG_CLI_TEST_SPEC_REPO", "env-repo")
+	defer os.Unsetenv("JFROG_CLI_TEST_SPEC_REPO")
+	specVars := map[string]string{"branch": "master", "modules": "a,b", "empty": ""}
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"noVars", `{"pattern": "repo/*"}`, `{"pattern": "repo/*"}`},
+		{"specVar", `${branch}/${branch}`, `master/master`},
+		{"emptySpecVar", `a${empty}a`, `aa`},
+		{"envVar", `${JFROG_CLI_TEST_SPEC_REPO}/*`, `env-repo/*`},
+		{"specVarOverDefault", `${branch:-dev}`, `master`},
+		{"default", `${version:-1.0}`, `1.0`},
+		{"emptyDefault", `a${version:-}a`, `aa`},
+		{"escaped", `$${version}`, `${version}`},
+		{"conditional", `{{if eq .branch "master"}}release{{else}}snapshot{{end}}`, `release`},
+		{"env", `{{env "JFROG_CLI_TEST_SPEC_REPO"}}`, `env-repo`},
+		{"loop", `[{{range $i, $module := split .modules ","}}{{if $i}},{{end}}{"pattern": "${branch}/{{$module}}/*"}{{end}}]`,
+			`[{"pattern": "master/a/*"},{"pattern": "master/b/*"}]`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := RenderSpecTemplate([]byte(test.template), specVars)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, string(actual))
+		})
+	}
+}
+
+func TestRenderSpecTemplateUndefinedVars(t *testing.T) {
+	log.SetDefaultLogger()
+	_, err := RenderSpecTemplate([]byte(`${repo}/${version}/${repo}`), nil)
+	assert.EqualError(t, err, "the File Spec uses undefined variables: repo, version. "+
+		"Define them using the --spec-vars option or as environment variables, or provide default values as ${key:-default}")
+	_, err = RenderSpecTemplate([]byte(`{{.repo}}`), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,99 @@
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v2"
+)
+
+// Returns true if the spec should be parsed as YAML.
+// Files with a .yaml or .yml extension are YAML. Other files are YAML, unless their content looks like JSON.
+func isYamlSpec(specFilePath string, content []byte) bool {
+	switch strings.ToLower(filepath.Ext(specFilePath)) {
+	case ".yaml", ".yml":
+		return true
+	case ".json":
+		return false
+	}
+	content = bytes.TrimSpace(content)
+	return len(content) > 0 && content[0] != '{' && content[0] != '['
+}
+
+// Converts a YAML spec to JSON, so that YAML specs are parsed with the same schema as JSON specs.
+// Comments, anchors and aliases, including merge keys ("<<: *anchor"), are resolved by the YAML parser.
+func convertYamlToJson(content []byte, convert func(document interface{})) ([]byte, error) {
+	var document interface{}
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	document, err := toJsonValue(document)
+	if err != nil {
+		return nil, err
+	}
+	if convert != nil {
+		convert(document)
+	}
+	content, err = json.Marshal(document)
+	return content, errorutils.CheckError(err)
+}
+
+// The YAML parser returns maps with keys of any type, which can't be marshalled to JSON.
+func toJsonValue(value interface{}) (interface{}, error) {
+	switch typedValue := value.(type) {
+	case map[interface{}]interface{}:
+		jsonMap := make(map[string]interface{}, len(typedValue))
+		for key, mapValue := range typedValue {
+			jsonValue, err := toJsonValue(mapValue)
+			if err != nil {
+				return nil, err
+			}
+			jsonMap[fmt.Sprint(key)] = jsonValue
+		}
+		return jsonMap, nil
+	case []interface{}:
+		for i, sliceValue := range typedValue {
+			jsonValue, err := toJsonValue(sliceValue)
+			if err != nil {
+				return nil, err
+			}
+			typedValue[i] = jsonValue
+		}
+		return typedValue, nil
+	}
+	return value, nil
+}
+
+// The boolean options of the file groups, such as 'flat' and 'recursive', are strings in the spec schema.
+// In YAML they are naturally written without quotes, so they are converted to strings.
+func convertFileGroupsBooleans(document interface{}) {
+	spec, ok := document.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for key, files := range spec {
+		if !strings.EqualFold(key, "files") {
+			continue
+		}
+		fileGroups, ok := files.([]interface{})
+		if !ok {
+			continue
+		}
+		for _, fileGroup := range fileGroups {
+			fileGroupMap, ok := fileGroup.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for option, value := range fileGroupMap {
+				if boolValue, ok := value.(bool); ok {
+					fileGroupMap[option] = strconv.FormatBool(boolValue)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package spec
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+const yamlSpec = `
+# Options shared by the file groups.
+defaults: &defaults
+  target: ${repo}/
+  flat: true
+  recursive: false
+files:
+  - pattern: a/*.zip
+    <<: *defaults
+  - pattern: b/*.zip
+    <<: *defaults
+    flat: false
+    props: type=b
+  - aql:
+      items.find:
+        repo: ${repo}
+        size:
+          $gt: 100
+    offset: 5
+`
+
+func TestCreateSpecFromYamlFile(t *testing.T) {
+	log.SetDefaultLogger()
+	tempDir, err := ioutil.TempDir("", "spec")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	// YAML specs are detected by their extension, or by their content.
+	for _, specFileName := range []string{"spec.yaml", "spec.yml", "spec"} {
+		specFilePath := filepath.Join(tempDir, specFileName)
+		assert.NoError(t, ioutil.WriteFile(specFilePath, []byte(yamlSpec), 0644))
+		spec, err := CreateSpecFromFile(specFilePath, map[string]string{"repo": "generic-local"})
+		if !assert.NoError(t, err) {
+			continue
+		}
+		assert.Len(t, spec.Files, 3)
+		assert.Equal(t, File{Pattern: "a/*.zip", Target: "generic-local/", Flat: "true", Recursive: "false"}, spec.Files[0])
+		assert.Equal(t, File{Pattern: "b/*.zip", Target: "generic-local/", Flat: "false", Recursive: "false", Props: "type=b"}, spec.Files[1])
+		assert.Equal(t, `{"repo":"generic-local","size":{"$gt":100}}`, spec.Files[2].Aql.ItemsFind)
+		assert.Equal(t, 5, spec.Files[2].Offset)
+		assert.NoError(t, ValidateSpec(spec.Files, false, true))
+	}
+}
+
+func TestCreateDistributionRulesFromYamlFile(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "spec")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	rulesPath := filepath.Join(tempDir, "rules.yml")
+	assert.NoError(t, ioutil.WriteFile(rulesPath, []byte("distribution_rules:\n  - site_name: site*\n    country_codes: [US, IL]\n"), 0644))
+	rules, err := CreateDistributionRulesFromFile(rulesPath)
+	assert.NoError(t, err)
+	assert.Equal(t, []DistributionRule{{SiteName: "site*", CountryCodes: []string{"US", "IL"}}}, rules.DistributionRules)
+}
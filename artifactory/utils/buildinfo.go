@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+)
+
+// The structs in this file extend the partial build-info structs of jfrog-client-go with details which the client does not support.
+// The details are kept in the partials, and are converted to the build-info structs of jfrog-client-go when the build-info is published.
+
+// Partial build-info, in which every affected issue is tagged with the issue tracker it was found by,
+// and which may include the details of multiple VCS repositories.
+type Partial struct {
+	buildinfo.Partial
+	Issues  *Issues `json:"Issues,omitempty"`
+	VcsList []Vcs   `json:"VcsList,omitempty"`
+}
+
+type Partials []*Partial
+
+func (partials Partials) Len() int {
+	return len(partials)
+}
+
+func (partials Partials) Less(i, j int) bool {
+	return partials[i].Timestamp < partials[j].Timestamp
+}
+
+func (partials Partials) Swap(i, j int) {
+	partials[i], partials[j] = partials[j], partials[i]
+}
+
+type Issues struct {
+	Tracker                *buildinfo.Tracker `json:"tracker,omitempty"`
+	AggregateBuildIssues   bool               `json:"aggregateBuildIssues,omitempty"`
+	AggregationBuildStatus string             `json:"aggregationBuildStatus,omitempty"`
+	AffectedIssues         []AffectedIssue    `json:"affectedIssues,omitempty"`
+}
+
+type Vcs struct {
+	Url      string `json:"url,omitempty"`
+	Revision string `json:"revision,omitempty"`
+	Branch   string `json:"branch,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+type AffectedIssue struct {
+	buildinfo.AffectedIssue
+	// The name of the issue tracker the issue belongs to.
+	Tracker string `json:"tracker,omitempty"`
+}
@@ -147,6 +147,14 @@ func SavePartialBuildInfo(buildName, buildNumber string, populatePartialBuildInf
 	return saveBuildData(partialBuildInfo, buildName, buildNumber)
 }
 
+// Saves a partial build-info, which may include the details which the partials of jfrog-client-go don't support.
+func SaveExtendedPartialBuildInfo(buildName, buildNumber string, populatePartialBuildInfoFunc func(partial *Partial)) error {
+	partialBuildInfo := new(Partial)
+	partialBuildInfo.Timestamp = time.Now().UnixNano() / int64(time.Millisecond)
+	populatePartialBuildInfoFunc(partialBuildInfo)
+	return saveBuildData(partialBuildInfo, buildName, buildNumber)
+}
+
 func GetGeneratedBuildsInfo(buildName, buildNumber string) ([]*buildinfo.BuildInfo, error) {
 	buildDir, err := GetBuildDir(buildName, buildNumber)
 	if err != nil {
@@ -177,8 +185,8 @@ func GetGeneratedBuildsInfo(buildName, buildNumber string) ([]*buildinfo.BuildIn
 	return generatedBuildsInfo, nil
 }
 
-func ReadPartialBuildInfoFiles(buildName, buildNumber string) (buildinfo.Partials, error) {
-	var partials buildinfo.Partials
+func ReadPartialBuildInfoFiles(buildName, buildNumber string) (Partials, error) {
+	var partials Partials
 	partialsBuildDir, err := getPartialsBuildDir(buildName, buildNumber)
 	if err != nil {
 		return nil, err
@@ -202,7 +210,7 @@ func ReadPartialBuildInfoFiles(buildName, buildNumber string) (buildinfo.Partial
 		if err != nil {
 			return nil, err
 		}
-		partial := new(buildinfo.Partial)
+		partial := new(Partial)
 		json.Unmarshal(content, &partial)
 		partials = append(partials, partial)
 	}
@@ -17,4 +17,6 @@ type DownloadConfiguration struct {
 	Symlink         bool
 	ValidateSymlink bool
 	Retries         int
+	// If true, the downloaded files are served from and added to the download cache.
+	Cache bool
 }
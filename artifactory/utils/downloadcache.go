@@ -0,0 +1,199 @@
+package utils
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	downloadCacheDirName = "downloads"
+	// The default maximum size of the download cache, in MB.
+	defaultDownloadCacheMaxSizeMb = 10240
+	// The prefix of the files being added to the cache.
+	downloadCacheTempPrefix = ".tmp-"
+)
+
+// A content-addressable cache of downloaded files, shared by all the download commands running with the cache enabled.
+// The files are stored under the JFrog home dir by their SHA-1 checksum.
+// Once the cache exceeds its maximum size, the least recently used files are evicted.
+type DownloadCache struct {
+	dir     string
+	maxSize int64
+}
+
+// Creates the download cache in the JFrog home dir.
+// The maximum size of the cache is read from the JFROG_CLI_DOWNLOAD_CACHE_MAX_SIZE_MB environment variable.
+func NewDownloadCache() (*DownloadCache, error) {
+	maxSize, err := getDownloadCacheMaxSize()
+	if err != nil {
+		return nil, err
+	}
+	dir, err := cliutils.CreateDirInJfrogHome(filepath.Join(cliutils.JfrogCacheDirName, downloadCacheDirName))
+	if err != nil {
+		return nil, err
+	}
+	return NewDownloadCacheInDir(dir, maxSize), nil
+}
+
+func NewDownloadCacheInDir(dir string, maxSize int64) *DownloadCache {
+	return &DownloadCache{dir: dir, maxSize: maxSize}
+}
+
+func getDownloadCacheMaxSize() (int64, error) {
+	maxSize := os.Getenv(cliutils.DownloadCacheMaxSize)
+	if maxSize == "" {
+		return defaultDownloadCacheMaxSizeMb * 1024 * 1024, nil
+	}
+	maxSizeMb, err := strconv.ParseInt(maxSize, 10, 64)
+	if errorutils.CheckError(err) != nil {
+		return 0, err
+	}
+	return maxSizeMb * 1024 * 1024, nil
+}
+
+func (dc *DownloadCache) entryPath(sha1 string) string {
+	return filepath.Join(dc.dir, sha1[:2], sha1)
+}
+
+// Places the cached file with the given SHA-1 checksum and size in the target path.
+// The file is copied, so that changes to the target don't affect the cache, and its SHA-1 checksum is verified while copying.
+// Returns false if the file isn't cached, or if it can't be placed in the target path, for example if it's evicted meanwhile by another process.
+func (dc *DownloadCache) Extract(sha1 string, size int64, targetPath string) bool {
+	if len(sha1) < 2 {
+		return false
+	}
+	entryPath := dc.entryPath(sha1)
+	fileInfo, err := os.Stat(entryPath)
+	if err != nil || fileInfo.Size() != size {
+		return false
+	}
+	if err = os.MkdirAll(filepath.Dir(targetPath), 0777); err != nil {
+		log.Debug("Couldn't place", targetPath, "from the download cache:", err.Error())
+		return false
+	}
+	if err = copyToPath(entryPath, targetPath, sha1); err != nil {
+		log.Debug("Couldn't place", targetPath, "from the download cache:", err.Error())
+		if err == errChecksumMismatch {
+			// The cached file is corrupted.
+			os.Remove(entryPath)
+		}
+		return false
+	}
+	// The modification time of the cached files determines which files are evicted first.
+	dc.touch(entryPath)
+	return true
+}
+
+// Adds the file in the source path to the cache, under the given SHA-1 checksum.
+func (dc *DownloadCache) Add(sha1, sourcePath string) error {
+	if len(sha1) < 2 {
+		return nil
+	}
+	entryPath := dc.entryPath(sha1)
+	if _, err := os.Stat(entryPath); err == nil {
+		dc.touch(entryPath)
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(entryPath), 0777); errorutils.CheckError(err) != nil {
+		return err
+	}
+	return copyToPath(sourcePath, entryPath, sha1)
+}
+
+func (dc *DownloadCache) touch(entryPath string) {
+	now := time.Now()
+	if err := os.Chtimes(entryPath, now, now); err != nil {
+		log.Debug("Couldn't update the modification time of", entryPath+":", err.Error())
+	}
+}
+
+// Removes the least recently used files, until the size of the cache doesn't exceed its maximum size.
+// The cache may be used by other processes meanwhile, so files which are already removed, or can't be removed, are skipped.
+func (dc *DownloadCache) Evict() error {
+	var files []os.FileInfo
+	var paths []string
+	var totalSize int64
+	err := filepath.Walk(dc.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() || strings.HasPrefix(info.Name(), downloadCacheTempPrefix) {
+			return nil
+		}
+		files = append(files, info)
+		paths = append(paths, path)
+		totalSize += info.Size()
+		return nil
+	})
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	if totalSize <= dc.maxSize {
+		return nil
+	}
+	indexes := make([]int, len(files))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return files[indexes[i]].ModTime().Before(files[indexes[j]].ModTime())
+	})
+	for _, i := range indexes {
+		if totalSize <= dc.maxSize {
+			break
+		}
+		log.Debug("Evicting", paths[i], "from the download cache.")
+		if err = os.Remove(paths[i]); err != nil && !os.IsNotExist(err) {
+			log.Debug("Couldn't evict", paths[i], "from the download cache:", err.Error())
+			continue
+		}
+		totalSize -= files[i].Size()
+	}
+	return nil
+}
+
+var errChecksumMismatch = errors.New("the SHA-1 checksum of the file doesn't match its expected checksum")
+
+// Copies the source path to the target path, replacing the target if it exists, and verifies the SHA-1 checksum of the copied content.
+// The file is first copied to a temporary path next to the target, so that the target is never partially written.
+func copyToPath(sourcePath, targetPath, expectedSha1 string) error {
+	source, err := os.Open(sourcePath)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	defer source.Close()
+	tempFile, err := ioutil.TempFile(filepath.Dir(targetPath), downloadCacheTempPrefix)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	tempPath := tempFile.Name()
+	defer os.Remove(tempPath)
+	hash := sha1.New()
+	if _, err = io.Copy(io.MultiWriter(tempFile, hash), source); errorutils.CheckError(err) != nil {
+		tempFile.Close()
+		return err
+	}
+	if err = tempFile.Close(); errorutils.CheckError(err) != nil {
+		return err
+	}
+	if hex.EncodeToString(hash.Sum(nil)) != expectedSha1 {
+		return errChecksumMismatch
+	}
+	return errorutils.CheckError(os.Rename(tempPath, targetPath))
+}
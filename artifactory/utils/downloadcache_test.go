@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadCacheEviction(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "download-cache")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	cache := NewDownloadCacheInDir(filepath.Join(tempDir, "cache"), 10)
+	var checksums []string
+	for i, content := range []string{"11111", "22222", "33333"} {
+		checksum := addToCache(t, cache, tempDir, content)
+		modTime := time.Now().Add(time.Duration(i-3) * time.Hour)
+		assert.NoError(t, os.Chtimes(cache.entryPath(checksum), modTime, modTime))
+		checksums = append(checksums, checksum)
+	}
+	// Using a cached file makes it the most recently used.
+	assert.True(t, cache.Extract(checksums[0], 5, filepath.Join(tempDir, "workspace", "file")))
+	assert.False(t, cache.Extract(checksums[1], 4, filepath.Join(tempDir, "workspace", "file")), "A cached file of a different size should not be used")
+
+	assert.NoError(t, cache.Evict())
+	for i, expected := range []bool{true, false, true} {
+		_, err = os.Stat(cache.entryPath(checksums[i]))
+		assert.Equal(t, expected, err == nil, checksums[i])
+	}
+}
+
+func TestDownloadCacheExtract(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "download-cache")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	cache := NewDownloadCacheInDir(filepath.Join(tempDir, "cache"), 1024)
+	checksum := addToCache(t, cache, tempDir, "content")
+
+	// Changing an extracted file doesn't change the cached file.
+	targetPath := filepath.Join(tempDir, "workspace", "file")
+	assert.True(t, cache.Extract(checksum, 7, targetPath))
+	assert.NoError(t, ioutil.WriteFile(targetPath, []byte("changed"), 0644))
+	cached, err := ioutil.ReadFile(cache.entryPath(checksum))
+	assert.NoError(t, err)
+	assert.Equal(t, "content", string(cached))
+
+	// A corrupted cached file isn't used, and is removed from the cache.
+	assert.NoError(t, ioutil.WriteFile(cache.entryPath(checksum), []byte("corrupt"), 0644))
+	assert.False(t, cache.Extract(checksum, 7, targetPath))
+	_, err = os.Stat(cache.entryPath(checksum))
+	assert.True(t, os.IsNotExist(err))
+	content, err := ioutil.ReadFile(targetPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "changed", string(content), "The target should not be replaced by a corrupted cached file")
+
+	// A file removed from the cache, for example by another process, is a cache miss.
+	assert.False(t, cache.Extract(checksum, 7, filepath.Join(tempDir, "workspace", "other")))
+	assert.NoError(t, os.RemoveAll(filepath.Join(tempDir, "cache")))
+	assert.NoError(t, cache.Evict())
+}
+
+func addToCache(t *testing.T, cache *DownloadCache, tempDir, content string) string {
+	sum := sha1.Sum([]byte(content))
+	checksum := hex.EncodeToString(sum[:])
+	source := filepath.Join(tempDir, checksum)
+	assert.NoError(t, ioutil.WriteFile(source, []byte(content), 0644))
+	assert.NoError(t, cache.Add(checksum, source))
+	return checksum
+}
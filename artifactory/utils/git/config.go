@@ -0,0 +1,93 @@
+package git
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const GitModules = ".gitmodules"
+
+// Maps a section name, such as 'remote "origin"', to the keys and values in it.
+// Section names without a subsection and keys are lower-cased, since they are case insensitive in git.
+type configSections map[string]map[string]string
+
+// Reads a file in the git configuration format, such as .git/config or .gitmodules.
+// Returns an empty configuration if the file does not exist.
+func readConfigFile(path string) (configSections, error) {
+	sections := make(configSections)
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return sections, nil
+	}
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer file.Close()
+
+	var currentSection map[string]string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := normalizeSectionName(line[1 : len(line)-1])
+			if sections[name] == nil {
+				sections[name] = make(map[string]string)
+			}
+			currentSection = sections[name]
+			continue
+		}
+		if currentSection == nil {
+			continue
+		}
+		key, value := line, "true"
+		if separator := strings.IndexByte(line, '='); separator >= 0 {
+			key, value = strings.TrimSpace(line[:separator]), strings.TrimSpace(line[separator+1:])
+		}
+		currentSection[strings.ToLower(key)] = strings.Trim(value, `"`)
+	}
+	return sections, errorutils.CheckError(scanner.Err())
+}
+
+// Lower-cases the section name, but keeps the case of the quoted subsection: [Remote "Origin"] -> remote "Origin".
+func normalizeSectionName(name string) string {
+	name = strings.TrimSpace(name)
+	if quote := strings.IndexByte(name, '"'); quote >= 0 {
+		return strings.ToLower(strings.TrimSpace(name[:quote])) + ` "` + strings.Trim(name[quote:], `"`) + `"`
+	}
+	return strings.ToLower(name)
+}
+
+// Returns the URL of the remote, or an empty string if the remote is not configured.
+func (repo *Repository) GetRemoteUrl(remote string) (string, error) {
+	sections, err := readConfigFile(filepath.Join(repo.dotGitPath, "config"))
+	if err != nil {
+		return "", err
+	}
+	return sections[`remote "`+remote+`"`]["url"], nil
+}
+
+// Returns the paths of the submodules of the repository, as configured in its .gitmodules file.
+// The paths are relative to the work tree of the repository.
+func (repo *Repository) GetSubmodulesPaths() ([]string, error) {
+	sections, err := readConfigFile(filepath.Join(repo.workTreePath, GitModules))
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for name, section := range sections {
+		if !strings.HasPrefix(name, `submodule "`) || section["path"] == "" {
+			continue
+		}
+		paths = append(paths, filepath.FromSlash(section["path"]))
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
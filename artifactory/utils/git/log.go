@@ -0,0 +1,252 @@
+package git
+
+import (
+	"container/heap"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+type Commit struct {
+	Hash      string
+	Tree      string
+	Parents   []string
+	Author    string
+	Committer string
+	// The commit time, in seconds since the epoch.
+	CommitTime int64
+	Message    string
+}
+
+// Reads and parses the commit with the provided hash.
+func (repo *Repository) ReadCommit(hash string) (*Commit, error) {
+	objType, content, err := repo.ReadObject(hash)
+	if err != nil {
+		return nil, err
+	}
+	if objType != CommitObject {
+		return nil, errorutils.CheckError(errors.New("Git object " + hash + " is a " + string(objType) + ", not a commit"))
+	}
+	return parseCommit(hash, string(content))
+}
+
+// A commit object starts with header lines, such as 'tree', 'parent' and 'committer', followed by an empty line and the message.
+// Multi-line header values, such as 'gpgsig', are continued in lines starting with a space.
+func parseCommit(hash, content string) (*Commit, error) {
+	commit := &Commit{Hash: hash}
+	headers := content
+	if headersEnd := strings.Index(content, "\n\n"); headersEnd >= 0 {
+		headers = content[:headersEnd]
+		commit.Message = content[headersEnd+2:]
+	}
+	for _, line := range strings.Split(headers, "\n") {
+		if strings.HasPrefix(line, " ") {
+			continue
+		}
+		separator := strings.IndexByte(line, ' ')
+		if separator < 0 {
+			continue
+		}
+		key, value := line[:separator], line[separator+1:]
+		switch key {
+		case "tree":
+			commit.Tree = value
+		case "parent":
+			commit.Parents = append(commit.Parents, value)
+		case "author":
+			commit.Author = value
+		case "committer":
+			commit.Committer = value
+			commit.CommitTime = parseSignatureTime(value)
+		}
+	}
+	if commit.Tree == "" {
+		return nil, errorutils.CheckError(errors.New("Failed parsing git commit " + hash))
+	}
+	return commit, nil
+}
+
+// Reads the 'shallow' file of a shallow clone, which lists the commits whose parents were not fetched.
+func (repo *Repository) loadShallowCommits() error {
+	if repo.shallowCommits != nil {
+		return nil
+	}
+	repo.shallowCommits = make(map[string]bool)
+	// The 'shallow' file is shared by the linked worktrees, in the directory of the objects.
+	content, err := ioutil.ReadFile(filepath.Join(filepath.Dir(repo.objectsPath), "shallow"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if hash := strings.TrimSpace(line); isHash(hash) {
+			repo.shallowCommits[hash] = true
+		}
+	}
+	return nil
+}
+
+// A signature has the 'Name <email> <seconds since epoch> <timezone>' format.
+func parseSignatureTime(signature string) int64 {
+	fields := strings.Fields(signature[strings.LastIndexByte(signature, '>')+1:])
+	if len(fields) == 0 {
+		return 0
+	}
+	commitTime, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return commitTime
+}
+
+// Returns up to 'limit' commits reachable from the 'from' revision, ordered from the newest commit time to the oldest.
+// If 'exclude' is not empty, commits reachable from it are not returned, the same as 'git log -<limit> <exclude>..<from>'.
+func (repo *Repository) Log(from, exclude string, limit int) ([]*Commit, error) {
+	fromHash, err := repo.ResolveRevision(from)
+	if err != nil {
+		return nil, err
+	}
+	if err = repo.loadShallowCommits(); err != nil {
+		return nil, err
+	}
+	walker := &logWalker{repo: repo, visited: make(map[string]*walkedCommit)}
+	if exclude != "" {
+		excludeHash, err := repo.ResolveRevision(exclude)
+		if err != nil {
+			return nil, err
+		}
+		if err = walker.push(excludeHash, true); err != nil {
+			return nil, err
+		}
+	}
+	if err = walker.push(fromHash, false); err != nil {
+		return nil, err
+	}
+
+	var commits []*Commit
+	for (limit <= 0 || len(commits) < limit) && walker.hasInteresting() {
+		current := heap.Pop(&walker.queue).(*walkedCommit)
+		if current.uninteresting {
+			walker.uninterestingInQueue--
+		} else {
+			walker.interestingInQueue--
+		}
+		for _, parent := range walker.parents(current.commit) {
+			if err = walker.push(parent, current.uninteresting); err != nil {
+				return nil, err
+			}
+		}
+		if !current.uninteresting {
+			commits = append(commits, current.commit)
+		}
+	}
+	return commits, nil
+}
+
+type walkedCommit struct {
+	commit        *Commit
+	uninteresting bool
+	queued        bool
+}
+
+// Walks the commits graph by commit time. Commits reachable from an excluded revision are marked as uninteresting,
+// and the walk stops when no interesting commits are left to walk.
+type logWalker struct {
+	repo                 *Repository
+	queue                commitsQueue
+	visited              map[string]*walkedCommit
+	interestingInQueue   int
+	uninterestingInQueue int
+}
+
+func (walker *logWalker) push(hash string, uninteresting bool) error {
+	if walked, ok := walker.visited[hash]; ok {
+		if uninteresting && !walked.uninteresting {
+			walker.markUninteresting(walked)
+		}
+		return nil
+	}
+	commit, err := walker.repo.ReadCommit(hash)
+	if err != nil {
+		return err
+	}
+	walked := &walkedCommit{commit: commit, uninteresting: uninteresting, queued: true}
+	walker.visited[hash] = walked
+	heap.Push(&walker.queue, walked)
+	if uninteresting {
+		walker.uninterestingInQueue++
+	} else {
+		walker.interestingInQueue++
+	}
+	return nil
+}
+
+// Marks an already visited commit and its visited ancestors as uninteresting.
+func (walker *logWalker) markUninteresting(walked *walkedCommit) {
+	toMark := []*walkedCommit{walked}
+	for len(toMark) > 0 {
+		current := toMark[len(toMark)-1]
+		toMark = toMark[:len(toMark)-1]
+		if current.uninteresting {
+			continue
+		}
+		current.uninteresting = true
+		if current.queued {
+			walker.interestingInQueue--
+			walker.uninterestingInQueue++
+		}
+		for _, parent := range walker.parents(current.commit) {
+			if visitedParent, ok := walker.visited[parent]; ok {
+				toMark = append(toMark, visitedParent)
+			}
+		}
+	}
+}
+
+// Returns the parents of the commit to walk to.
+// The commits at the boundary of a shallow clone are walked as root commits, since their parents are not in the repository.
+func (walker *logWalker) parents(commit *Commit) []string {
+	if walker.repo.shallowCommits[commit.Hash] {
+		return nil
+	}
+	return commit.Parents
+}
+
+func (walker *logWalker) hasInteresting() bool {
+	return walker.interestingInQueue > 0
+}
+
+// A priority queue of commits, ordered from the newest commit time to the oldest.
+type commitsQueue []*walkedCommit
+
+func (queue commitsQueue) Len() int {
+	return len(queue)
+}
+
+func (queue commitsQueue) Less(i, j int) bool {
+	return queue[i].commit.CommitTime > queue[j].commit.CommitTime
+}
+
+func (queue commitsQueue) Swap(i, j int) {
+	queue[i], queue[j] = queue[j], queue[i]
+}
+
+func (queue *commitsQueue) Push(item interface{}) {
+	*queue = append(*queue, item.(*walkedCommit))
+}
+
+func (queue *commitsQueue) Pop() interface{} {
+	old := *queue
+	item := old[len(old)-1]
+	old[len(old)-1] = nil
+	*queue = old[:len(old)-1]
+	item.queued = false
+	return item
+}
@@ -0,0 +1,133 @@
+package git
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testsdataPath = filepath.Join("..", "..", "..", "testsdata")
+
+func TestLogLooseObjects(t *testing.T) {
+	repo, err := OpenRepository(filepath.Join(testsdataPath, "vcs", "gitdata"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	commits, err := repo.Log("HEAD", "", 100)
+	assert.NoError(t, err)
+	if assert.Len(t, commits, 1) {
+		assert.Equal(t, "d63c5957ad6819f4c02a817abe757f210d35ff92", commits[0].Hash)
+		assert.Equal(t, "main\n", commits[0].Message)
+		assert.Empty(t, commits[0].Parents)
+		assert.Equal(t, int64(1576423571), commits[0].CommitTime)
+	}
+
+	// HEAD points to a branch other than master.
+	repo, err = OpenRepository(filepath.Join(testsdataPath, "vcs", "OtherGit", "gitdata"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	revision, err := repo.ResolveRevision("HEAD")
+	assert.NoError(t, err)
+	assert.Equal(t, "ad99b6c068283878fde4d49423728f0bdc00544a", revision)
+	branch, err := repo.GetBranch()
+	assert.NoError(t, err)
+	assert.Equal(t, "InnerGit", branch)
+}
+
+func TestLogPackedObjects(t *testing.T) {
+	repo, err := OpenRepository(filepath.Join(testsdataPath, "packedvcs", "gitdata"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	tests := []struct {
+		name     string
+		exclude  string
+		limit    int
+		expected []string
+	}{
+		{"all", "", 100, []string{"81b5274b4549697168ffcc315d5e8b957c52bae1", "6bf254374cbab4dd8d8469af8883af9dbf1f7424", "bc642658f27a6a742fd75d8320e68a5ddb4b364e",
+			"089ddbcbd9565533ee5bec9455d7a04a3ab82cbf", "c9cef11a98be34e309f0f43ae3014a06d29082aa", "db055de0a180e237f9065615f14f976e6a9f1470"}},
+		{"limit", "", 2, []string{"81b5274b4549697168ffcc315d5e8b957c52bae1", "6bf254374cbab4dd8d8469af8883af9dbf1f7424"}},
+		{"excludeAnnotatedTag", "v1.0", 100, []string{"81b5274b4549697168ffcc315d5e8b957c52bae1", "6bf254374cbab4dd8d8469af8883af9dbf1f7424",
+			"bc642658f27a6a742fd75d8320e68a5ddb4b364e", "089ddbcbd9565533ee5bec9455d7a04a3ab82cbf"}},
+		{"excludeBranch", "feature", 100, []string{"81b5274b4549697168ffcc315d5e8b957c52bae1", "6bf254374cbab4dd8d8469af8883af9dbf1f7424", "bc642658f27a6a742fd75d8320e68a5ddb4b364e"}},
+		{"excludeHash", "c9cef11a98be34e309f0f43ae3014a06d29082aa", 3, []string{"81b5274b4549697168ffcc315d5e8b957c52bae1", "6bf254374cbab4dd8d8469af8883af9dbf1f7424", "bc642658f27a6a742fd75d8320e68a5ddb4b364e"}},
+		{"excludeHead", "HEAD", 100, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			commits, err := repo.Log("HEAD", test.exclude, test.limit)
+			assert.NoError(t, err)
+			var hashes []string
+			for _, commit := range commits {
+				hashes = append(hashes, commit.Hash)
+			}
+			assert.Equal(t, test.expected, hashes)
+		})
+	}
+
+	commit, err := repo.ReadCommit("c9cef11a98be34e309f0f43ae3014a06d29082aa")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "JIRA-2 - Extending file.txt\n\nFixes: #7\n", commit.Message)
+		assert.Equal(t, []string{"db055de0a180e237f9065615f14f976e6a9f1470"}, commit.Parents)
+	}
+
+	_, err = repo.Log("HEAD", "0000000000000000000000000000000000000000", 100)
+	assert.Error(t, err)
+	_, err = repo.Log("HEAD", "no-such-branch", 100)
+	assert.Error(t, err)
+}
+
+func TestReadDeltifiedObjects(t *testing.T) {
+	repo, err := OpenRepository(filepath.Join(testsdataPath, "packedvcs", "gitdata"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	// The first blob is stored whole, the second is a delta of the first, and the third is a delta of the second.
+	blobs := []string{"0de58b8f08e066f45799596f9a08c70bd1c37bf0", "075180d90c0b00e73b1a3d776d73eaef45f5094c", "e073069c5bb83ff0be2ae74602305acb3467624c"}
+	for _, hash := range blobs {
+		objType, content, err := repo.ReadObject(hash)
+		if assert.NoError(t, err) {
+			assert.Equal(t, BlobObject, objType)
+			assert.Equal(t, hash, objectHash(objType, content))
+		}
+	}
+}
+
+// Calculates the hash of an object, the same as 'git hash-object'.
+func objectHash(objType ObjectType, content []byte) string {
+	hash := sha1.New()
+	hash.Write([]byte(string(objType) + " " + strconv.Itoa(len(content)) + "\x00"))
+	hash.Write(content)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func TestLogShallowClone(t *testing.T) {
+	// A clone of packedvcs with depth 2, whose merge commit is the boundary of the clone.
+	repo, err := OpenRepository(filepath.Join(testsdataPath, "shallowvcs", "gitdata"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	commits, err := repo.Log("HEAD", "", 100)
+	assert.NoError(t, err)
+	var hashes []string
+	for _, commit := range commits {
+		hashes = append(hashes, commit.Hash)
+	}
+	assert.Equal(t, []string{"81b5274b4549697168ffcc315d5e8b957c52bae1", "6bf254374cbab4dd8d8469af8883af9dbf1f7424"}, hashes)
+	if len(commits) == 2 {
+		// The parents are kept as recorded in the commit, although they are not in the clone.
+		assert.Len(t, commits[1].Parents, 2)
+	}
+
+	commits, err = repo.Log("HEAD", "6bf254374cbab4dd8d8469af8883af9dbf1f7424", 100)
+	assert.NoError(t, err)
+	if assert.Len(t, commits, 1) {
+		assert.Equal(t, "81b5274b4549697168ffcc315d5e8b957c52bae1", commits[0].Hash)
+	}
+}
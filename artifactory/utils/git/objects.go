@@ -0,0 +1,101 @@
+package git
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+type ObjectType string
+
+const (
+	CommitObject ObjectType = "commit"
+	TreeObject   ObjectType = "tree"
+	BlobObject   ObjectType = "blob"
+	TagObject    ObjectType = "tag"
+)
+
+// Returns the type and content of the object with the provided hash.
+// The object is searched in the loose objects first, and then in the packfiles.
+func (repo *Repository) ReadObject(hash string) (ObjectType, []byte, error) {
+	if !isHash(hash) {
+		return "", nil, errorutils.CheckError(errors.New("Invalid git object hash: " + hash))
+	}
+	objType, content, found, err := repo.readLooseObject(hash)
+	if err != nil || found {
+		return objType, content, err
+	}
+	if err = repo.loadPacks(); err != nil {
+		return "", nil, err
+	}
+	for _, pack := range repo.packs {
+		offset, found := pack.findOffset(hash)
+		if !found {
+			continue
+		}
+		return pack.readObject(offset, repo)
+	}
+	return "", nil, errorutils.CheckError(errors.New("Git object " + hash + " was not found in " + repo.dotGitPath))
+}
+
+// Loose objects are stored zlib-compressed under objects/<first 2 hash chars>/<remaining 38 chars>.
+// The decompressed content starts with a '<type> <size>\0' header.
+func (repo *Repository) readLooseObject(hash string) (objType ObjectType, content []byte, found bool, err error) {
+	file, err := os.Open(filepath.Join(repo.objectsPath, hash[:2], hash[2:]))
+	if os.IsNotExist(err) {
+		return "", nil, false, nil
+	}
+	if err != nil {
+		return "", nil, false, errorutils.CheckError(err)
+	}
+	defer file.Close()
+	reader, err := zlib.NewReader(file)
+	if err != nil {
+		return "", nil, false, errorutils.CheckError(err)
+	}
+	defer reader.Close()
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return "", nil, false, errorutils.CheckError(err)
+	}
+	headerEnd := bytes.IndexByte(data, 0)
+	if headerEnd < 0 {
+		return "", nil, false, errorutils.CheckError(errors.New("Invalid header of git object " + hash))
+	}
+	header := strings.Fields(string(data[:headerEnd]))
+	if len(header) != 2 {
+		return "", nil, false, errorutils.CheckError(errors.New("Invalid header of git object " + hash))
+	}
+	size, err := strconv.Atoi(header[1])
+	if err != nil || size != len(data)-headerEnd-1 {
+		return "", nil, false, errorutils.CheckError(errors.New("Invalid size of git object " + hash))
+	}
+	return ObjectType(header[0]), data[headerEnd+1:], true, nil
+}
+
+// Loads the indexes of all packfiles in the objects/pack directory.
+func (repo *Repository) loadPacks() error {
+	if repo.packsLoaded {
+		return nil
+	}
+	repo.packsLoaded = true
+	indexes, err := filepath.Glob(filepath.Join(repo.objectsPath, "pack", "*.idx"))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	for _, indexPath := range indexes {
+		pack, err := openPackFile(strings.TrimSuffix(indexPath, ".idx"))
+		if err != nil {
+			return err
+		}
+		repo.packs = append(repo.packs, pack)
+	}
+	return nil
+}
@@ -0,0 +1,289 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	packIndexMagic   = 0xff744f63
+	packIndexVersion = 2
+	packIndexHeader  = 8
+	fanoutEntries    = 256
+	hashSize         = 20
+)
+
+// The object types, as stored in the header of each object in a packfile.
+const (
+	packedCommit   = 1
+	packedTree     = 2
+	packedBlob     = 3
+	packedTag      = 4
+	packedOfsDelta = 6
+	packedRefDelta = 7
+)
+
+var errInvalidDelta = errors.New("Invalid delta in git packfile")
+
+var packedTypes = map[byte]ObjectType{packedCommit: CommitObject, packedTree: TreeObject, packedBlob: BlobObject, packedTag: TagObject}
+
+// A packfile and its version 2 index.
+// The index contains a fanout table, the sorted hashes of the packed objects, their CRC32 checksums and their offsets in the packfile.
+type packFile struct {
+	// Path to the packfile, without the .pack or .idx extension.
+	path   string
+	fanout []uint32
+	index  []byte
+	count  int
+}
+
+func openPackFile(path string) (*packFile, error) {
+	index, err := ioutil.ReadFile(path + ".idx")
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if len(index) < packIndexHeader+fanoutEntries*4 || binary.BigEndian.Uint32(index) != packIndexMagic {
+		return nil, errorutils.CheckError(errors.New("Unsupported git pack index " + path + ".idx. Only version 2 indexes are supported."))
+	}
+	if version := binary.BigEndian.Uint32(index[4:]); version != packIndexVersion {
+		return nil, errorutils.CheckError(errors.New("Unsupported git pack index version in " + path + ".idx"))
+	}
+	pack := &packFile{path: path, index: index, fanout: make([]uint32, fanoutEntries)}
+	for i := range pack.fanout {
+		pack.fanout[i] = binary.BigEndian.Uint32(index[packIndexHeader+i*4:])
+	}
+	pack.count = int(pack.fanout[fanoutEntries-1])
+	if len(index) < pack.offsetsStart()+pack.count*4 {
+		return nil, errorutils.CheckError(errors.New("Git pack index " + path + ".idx is truncated"))
+	}
+	return pack, nil
+}
+
+func (pack *packFile) hashesStart() int {
+	return packIndexHeader + fanoutEntries*4
+}
+
+func (pack *packFile) offsetsStart() int {
+	// The offsets table follows the hashes and the CRC32 checksums.
+	return pack.hashesStart() + pack.count*hashSize + pack.count*4
+}
+
+func (pack *packFile) hashAt(i int) []byte {
+	start := pack.hashesStart() + i*hashSize
+	return pack.index[start : start+hashSize]
+}
+
+// Returns the offset of the object in the packfile.
+func (pack *packFile) findOffset(hash string) (int64, bool) {
+	hashBytes, err := hex.DecodeString(hash)
+	if err != nil {
+		return 0, false
+	}
+	low := 0
+	if hashBytes[0] > 0 {
+		low = int(pack.fanout[hashBytes[0]-1])
+	}
+	high := int(pack.fanout[hashBytes[0]])
+	i := low + sort.Search(high-low, func(i int) bool {
+		return bytes.Compare(pack.hashAt(low+i), hashBytes) >= 0
+	})
+	if i >= high || !bytes.Equal(pack.hashAt(i), hashBytes) {
+		return 0, false
+	}
+	offset := binary.BigEndian.Uint32(pack.index[pack.offsetsStart()+i*4:])
+	// If the most significant bit is set, the rest of the value is an index into the table of 8 bytes offsets.
+	if offset&0x80000000 == 0 {
+		return int64(offset), true
+	}
+	largeOffsetStart := pack.offsetsStart() + pack.count*4 + int(offset&0x7fffffff)*8
+	if len(pack.index) < largeOffsetStart+8 {
+		return 0, false
+	}
+	return int64(binary.BigEndian.Uint64(pack.index[largeOffsetStart:])), true
+}
+
+func (pack *packFile) readObject(offset int64, repo *Repository) (ObjectType, []byte, error) {
+	file, err := os.Open(pack.path + ".pack")
+	if err != nil {
+		return "", nil, errorutils.CheckError(err)
+	}
+	defer file.Close()
+	return pack.readObjectAt(file, offset, repo)
+}
+
+// Reads the object at the offset. Deltified objects are resolved by applying the delta to their base object.
+func (pack *packFile) readObjectAt(file *os.File, offset int64, repo *Repository) (ObjectType, []byte, error) {
+	reader := bufio.NewReader(io.NewSectionReader(file, offset, math.MaxInt64-offset))
+	packedType, size, err := readPackedObjectHeader(reader)
+	if err != nil {
+		return "", nil, err
+	}
+	switch packedType {
+	case packedCommit, packedTree, packedBlob, packedTag:
+		content, err := inflate(reader, size)
+		return packedTypes[packedType], content, err
+	case packedOfsDelta:
+		baseOffset, err := readDeltaBaseOffset(reader)
+		if err != nil {
+			return "", nil, err
+		}
+		if baseOffset <= 0 || baseOffset > offset {
+			return "", nil, errorutils.CheckError(errors.New("Invalid delta base offset in git packfile " + pack.path + ".pack"))
+		}
+		delta, err := inflate(reader, size)
+		if err != nil {
+			return "", nil, err
+		}
+		baseType, base, err := pack.readObjectAt(file, offset-baseOffset, repo)
+		if err != nil {
+			return "", nil, err
+		}
+		content, err := applyDelta(base, delta)
+		return baseType, content, err
+	case packedRefDelta:
+		baseHash := make([]byte, hashSize)
+		if _, err = io.ReadFull(reader, baseHash); err != nil {
+			return "", nil, errorutils.CheckError(err)
+		}
+		delta, err := inflate(reader, size)
+		if err != nil {
+			return "", nil, err
+		}
+		baseType, base, err := repo.ReadObject(hex.EncodeToString(baseHash))
+		if err != nil {
+			return "", nil, err
+		}
+		content, err := applyDelta(base, delta)
+		return baseType, content, err
+	}
+	return "", nil, errorutils.CheckError(errors.New("Unknown object type in git packfile " + pack.path + ".pack"))
+}
+
+// The header holds the object type in bits 4-6 of the first byte, and the size as a little-endian base-128 number.
+func readPackedObjectHeader(reader *bufio.Reader) (byte, int64, error) {
+	c, err := reader.ReadByte()
+	if err != nil {
+		return 0, 0, errorutils.CheckError(err)
+	}
+	packedType := (c >> 4) & 7
+	size := int64(c & 0x0f)
+	shift := uint(4)
+	for c&0x80 != 0 {
+		if c, err = reader.ReadByte(); err != nil {
+			return 0, 0, errorutils.CheckError(err)
+		}
+		size |= int64(c&0x7f) << shift
+		shift += 7
+	}
+	return packedType, size, nil
+}
+
+// The offset of the base object is relative to the deltified object, and is encoded as a big-endian base-128 number,
+// in which 1 is added to every continued byte.
+func readDeltaBaseOffset(reader *bufio.Reader) (int64, error) {
+	c, err := reader.ReadByte()
+	if err != nil {
+		return 0, errorutils.CheckError(err)
+	}
+	offset := int64(c & 0x7f)
+	for c&0x80 != 0 {
+		if c, err = reader.ReadByte(); err != nil {
+			return 0, errorutils.CheckError(err)
+		}
+		offset = ((offset + 1) << 7) | int64(c&0x7f)
+	}
+	return offset, nil
+}
+
+func inflate(reader io.Reader, size int64) ([]byte, error) {
+	zlibReader, err := zlib.NewReader(reader)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer zlibReader.Close()
+	content := make([]byte, size)
+	if _, err = io.ReadFull(zlibReader, content); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return content, nil
+}
+
+// A delta starts with the sizes of the base and the result objects, followed by instructions.
+// Each instruction either copies a range of the base object, or inserts the bytes that follow it.
+func applyDelta(base, delta []byte) ([]byte, error) {
+	baseSize, delta := readDeltaSize(delta)
+	if baseSize != int64(len(base)) {
+		return nil, errorutils.CheckError(errInvalidDelta)
+	}
+	resultSize, delta := readDeltaSize(delta)
+	result := make([]byte, 0, resultSize)
+	for len(delta) > 0 {
+		op := delta[0]
+		delta = delta[1:]
+		switch {
+		case op&0x80 != 0:
+			// Copy instruction. Bits 0-3 mark which offset bytes follow, and bits 4-6 mark which size bytes follow.
+			var copyOffset, copySize int64
+			for i := uint(0); i < 7; i++ {
+				if op&(1<<i) == 0 {
+					continue
+				}
+				if len(delta) == 0 {
+					return nil, errorutils.CheckError(errInvalidDelta)
+				}
+				if i < 4 {
+					copyOffset |= int64(delta[0]) << (8 * i)
+				} else {
+					copySize |= int64(delta[0]) << (8 * (i - 4))
+				}
+				delta = delta[1:]
+			}
+			if copySize == 0 {
+				copySize = 0x10000
+			}
+			if copyOffset+copySize > int64(len(base)) {
+				return nil, errorutils.CheckError(errInvalidDelta)
+			}
+			result = append(result, base[copyOffset:copyOffset+copySize]...)
+		case op != 0:
+			// Insert instruction. The op is the number of bytes to insert.
+			if int(op) > len(delta) {
+				return nil, errorutils.CheckError(errInvalidDelta)
+			}
+			result = append(result, delta[:op]...)
+			delta = delta[op:]
+		default:
+			return nil, errorutils.CheckError(errInvalidDelta)
+		}
+	}
+	if int64(len(result)) != resultSize {
+		return nil, errorutils.CheckError(errInvalidDelta)
+	}
+	return result, nil
+}
+
+func readDeltaSize(delta []byte) (int64, []byte) {
+	var size int64
+	shift := uint(0)
+	for len(delta) > 0 {
+		c := delta[0]
+		delta = delta[1:]
+		size |= int64(c&0x7f) << shift
+		shift += 7
+		if c&0x80 == 0 {
+			break
+		}
+	}
+	return size, delta
+}
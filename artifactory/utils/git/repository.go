@@ -0,0 +1,268 @@
+package git
+
+import (
+	"bufio"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+const (
+	DotGit = ".git"
+	// The maximum number of symbolic references to follow while resolving a reference.
+	maxSymbolicRefDepth = 10
+)
+
+// Reads a git repository directly from its .git directory, without using the git client.
+type Repository struct {
+	// Path to the .git directory.
+	dotGitPath string
+	// Path to the directory the repository files are checked out to.
+	workTreePath string
+	// Path to the objects directory. May be shared with other repositories.
+	objectsPath string
+	packs       []*packFile
+	packsLoaded bool
+	// The commits whose parents are missing from a shallow clone.
+	shallowCommits map[string]bool
+}
+
+// Opens the git repository at the provided path.
+// The path can be either the .git directory, or the directory containing it.
+func OpenRepository(path string) (*Repository, error) {
+	dotGitPath, err := findDotGitDir(path)
+	if err != nil {
+		return nil, err
+	}
+	workTreePath := path
+	if dotGitPath == path {
+		workTreePath = filepath.Dir(path)
+	}
+	repo := &Repository{dotGitPath: dotGitPath, workTreePath: workTreePath, objectsPath: filepath.Join(dotGitPath, "objects")}
+	// Linked worktrees keep their objects in the common directory.
+	if commonDir, err := ioutil.ReadFile(filepath.Join(dotGitPath, "commondir")); err == nil {
+		commonPath := strings.TrimSpace(string(commonDir))
+		if !filepath.IsAbs(commonPath) {
+			commonPath = filepath.Join(dotGitPath, commonPath)
+		}
+		repo.objectsPath = filepath.Join(commonPath, "objects")
+	}
+	return repo, nil
+}
+
+func (repo *Repository) GetDotGitPath() string {
+	return repo.dotGitPath
+}
+
+func (repo *Repository) GetWorkTreePath() string {
+	return repo.workTreePath
+}
+
+// Returns the path of the .git directory.
+// If the .git entry is a file, as in submodules and worktrees, the path it points to is returned.
+func findDotGitDir(path string) (string, error) {
+	if isGitDir(path) {
+		return path, nil
+	}
+	dotGitPath := filepath.Join(path, DotGit)
+	exists, err := fileutils.IsDirExists(dotGitPath, false)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return dotGitPath, nil
+	}
+	exists, err = fileutils.IsFileExists(dotGitPath, false)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", errorutils.CheckError(errors.New("Could not find a git repository in " + path))
+	}
+	content, err := ioutil.ReadFile(dotGitPath)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	gitDirLine := strings.TrimSpace(string(content))
+	if !strings.HasPrefix(gitDirLine, "gitdir:") {
+		return "", errorutils.CheckError(errors.New("Unexpected content in " + dotGitPath + ": " + gitDirLine))
+	}
+	gitDir := strings.TrimSpace(strings.TrimPrefix(gitDirLine, "gitdir:"))
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(path, gitDir)
+	}
+	return filepath.Clean(gitDir), nil
+}
+
+func isGitDir(path string) bool {
+	headExists, _ := fileutils.IsFileExists(filepath.Join(path, "HEAD"), false)
+	objectsExists, _ := fileutils.IsDirExists(filepath.Join(path, "objects"), false)
+	commonDirExists, _ := fileutils.IsFileExists(filepath.Join(path, "commondir"), false)
+	return headExists && (objectsExists || commonDirExists)
+}
+
+// Resolves a revision to a commit hash.
+// The revision can be a full commit hash, 'HEAD', a full reference name such as 'refs/heads/master', or a branch or tag name.
+func (repo *Repository) ResolveRevision(revision string) (string, error) {
+	if isHash(revision) {
+		return revision, nil
+	}
+	candidates := []string{revision}
+	if revision != "HEAD" && !strings.HasPrefix(revision, "refs/") {
+		candidates = append(candidates, "refs/tags/"+revision, "refs/heads/"+revision, "refs/remotes/"+revision)
+	}
+	for _, candidate := range candidates {
+		hash, err := repo.resolveRef(candidate, 0)
+		if err != nil {
+			return "", err
+		}
+		if hash == "" {
+			continue
+		}
+		// Only tags may point to annotated tag objects.
+		if strings.HasPrefix(candidate, "refs/tags/") {
+			return repo.peel(hash)
+		}
+		return hash, nil
+	}
+	return "", errorutils.CheckError(errors.New("Unknown git revision: " + revision))
+}
+
+// Returns the name of the branch HEAD points to, or an empty string if HEAD is detached.
+func (repo *Repository) GetBranch() (string, error) {
+	content, err := ioutil.ReadFile(filepath.Join(repo.dotGitPath, "HEAD"))
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	head := strings.TrimSpace(string(content))
+	if !strings.HasPrefix(head, "ref:") {
+		return "", nil
+	}
+	return strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(head, "ref:")), "refs/heads/"), nil
+}
+
+// Returns the hash the reference points to, or an empty string if the reference does not exist.
+func (repo *Repository) resolveRef(ref string, depth int) (string, error) {
+	if depth > maxSymbolicRefDepth {
+		return "", errorutils.CheckError(errors.New("Too many levels of symbolic references while resolving " + ref))
+	}
+	value, err := repo.readLooseRef(ref)
+	if err != nil {
+		return "", err
+	}
+	if value == "" {
+		value, err = repo.readPackedRef(ref)
+		if err != nil || value == "" {
+			return "", err
+		}
+	}
+	if strings.HasPrefix(value, "ref:") {
+		return repo.resolveRef(strings.TrimSpace(strings.TrimPrefix(value, "ref:")), depth+1)
+	}
+	return value, nil
+}
+
+func (repo *Repository) readLooseRef(ref string) (string, error) {
+	for _, dir := range repo.refsDirs() {
+		content, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(ref)))
+		if err == nil {
+			return strings.TrimSpace(string(content)), nil
+		}
+		if !os.IsNotExist(err) {
+			return "", errorutils.CheckError(err)
+		}
+	}
+	return "", nil
+}
+
+// Reads the reference from the packed-refs file.
+// Each line in the file contains a hash followed by the reference name. Lines starting with '^' hold the peeled value of the tag above them.
+func (repo *Repository) readPackedRef(ref string) (string, error) {
+	for _, dir := range repo.refsDirs() {
+		file, err := os.Open(filepath.Join(dir, "packed-refs"))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", errorutils.CheckError(err)
+		}
+		hash, err := findPackedRef(file, ref)
+		file.Close()
+		if err != nil || hash != "" {
+			return hash, err
+		}
+	}
+	return "", nil
+}
+
+func findPackedRef(file *os.File, ref string) (string, error) {
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "^") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[1] == ref {
+			return fields[0], nil
+		}
+	}
+	return "", errorutils.CheckError(scanner.Err())
+}
+
+// Returns the directories references are looked up in. Linked worktrees share the references of the common directory.
+func (repo *Repository) refsDirs() []string {
+	dirs := []string{repo.dotGitPath}
+	commonDir := filepath.Dir(repo.objectsPath)
+	if commonDir != repo.dotGitPath {
+		dirs = append(dirs, commonDir)
+	}
+	return dirs
+}
+
+// Follows annotated tags until reaching a commit.
+func (repo *Repository) peel(hash string) (string, error) {
+	for i := 0; i < maxSymbolicRefDepth; i++ {
+		objType, content, err := repo.ReadObject(hash)
+		if err != nil {
+			return "", err
+		}
+		if objType != TagObject {
+			return hash, nil
+		}
+		hash, err = parseTagTarget(content)
+		if err != nil {
+			return "", err
+		}
+	}
+	return "", errorutils.CheckError(errors.New("Too many levels of annotated tags"))
+}
+
+func parseTagTarget(content []byte) (string, error) {
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.HasPrefix(line, "object ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "object ")), nil
+		}
+		if line == "" {
+			break
+		}
+	}
+	return "", errorutils.CheckError(errors.New("Failed parsing git tag object"))
+}
+
+func isHash(value string) bool {
+	if len(value) != 40 {
+		return false
+	}
+	for _, c := range value {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -8,6 +8,21 @@ import (
 )
 
 func ExtractNpmOptionsFromArgs(args []string) (threads int, jsonOutput bool, cleanArgs []string, buildConfig *utils.BuildConfiguration, err error) {
+	// Since we use --json flag for retrieving the npm config for writing the temp .npmrc, json=true is written to the config list.
+	// We don't want to force the json output for all users, so we check whether the json output was explicitly required.
+	flagIndex, jsonOutput, err := utils.FindBooleanFlag("--json", args)
+	if err != nil {
+		return
+	}
+	// Since boolean flag might appear as --flag or --flag=value, the value index is the same as the flag index.
+	utils.RemoveFlagFromCommand(&args, flagIndex, flagIndex)
+
+	threads, cleanArgs, buildConfig, err = ExtractOptionsFromArgs(args)
+	return
+}
+
+// Extracts the options of the commands which resolve their dependencies from npm repositories: the number of threads and the build details.
+func ExtractOptionsFromArgs(args []string) (threads int, cleanArgs []string, buildConfig *utils.BuildConfiguration, err error) {
 	threads = 3
 	// Extract threads information from the args.
 	flagIndex, valueIndex, numOfThreads, err := utils.FindFlag("--threads", args)
@@ -23,15 +38,6 @@ func ExtractNpmOptionsFromArgs(args []string) (threads int, jsonOutput bool, cle
 		}
 	}
 
-	// Since we use --json flag for retrieving the npm config for writing the temp .npmrc, json=true is written to the config list.
-	// We don't want to force the json output for all users, so we check whether the json output was explicitly required.
-	flagIndex, jsonOutput, err = utils.FindBooleanFlag("--json", args)
-	if err != nil {
-		return
-	}
-	// Since boolean flag might appear as --flag or --flag=value, the value index is the same as the flag index.
-	utils.RemoveFlagFromCommand(&args, flagIndex, flagIndex)
-
 	cleanArgs, buildConfig, err = utils.ExtractBuildDetailsFromArgs(args)
 	return
 }
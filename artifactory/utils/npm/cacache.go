@@ -0,0 +1,70 @@
+package npm
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The npm cache stores the package tarballs by their digests, in the cacache format.
+const cacacheContentDir = "_cacache/content-v2"
+
+// The algorithms of the integrity, in the order of preference.
+var integrityAlgorithms = []string{"sha512", "sha1"}
+
+// Returns the checksums of the package tarball with the integrity from the lockfile, such as sha512-<base64 digest>, by reading the tarball from the npm cache in cacheDir.
+// Returns nil if the tarball isn't in the cache.
+func GetCachedTarballChecksum(cacheDir, integrity string) (*buildinfo.Checksum, error) {
+	if cacheDir == "" {
+		return nil, nil
+	}
+	digests := parseIntegrity(integrity)
+	for _, algorithm := range integrityAlgorithms {
+		digest, exists := digests[algorithm]
+		if !exists || len(digest) < 5 {
+			continue
+		}
+		contentPath := filepath.Join(cacheDir, filepath.FromSlash(cacacheContentDir), algorithm, digest[:2], digest[2:4], digest[4:])
+		exists, err := fileutils.IsFileExists(contentPath, false)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		details, err := fileutils.GetFileDetails(contentPath)
+		if err != nil {
+			return nil, err
+		}
+		return &buildinfo.Checksum{Sha1: details.Checksum.Sha1, Md5: details.Checksum.Md5}, nil
+	}
+	return nil, nil
+}
+
+// Parses the Subresource Integrity string, to the hex digests by their algorithms.
+// The string may have several digests, separated by whitespaces.
+func parseIntegrity(integrity string) map[string]string {
+	digests := make(map[string]string)
+	for _, hash := range strings.Fields(integrity) {
+		// Options may follow the digest after a question mark.
+		if i := strings.Index(hash, "?"); i != -1 {
+			hash = hash[:i]
+		}
+		i := strings.Index(hash, "-")
+		if i == -1 {
+			continue
+		}
+		digest, err := base64.StdEncoding.DecodeString(hash[i+1:])
+		if err != nil {
+			log.Debug("Skipping the invalid integrity", hash, ":", err.Error())
+			continue
+		}
+		digests[hash[:i]] = hex.EncodeToString(digest)
+	}
+	return digests
+}
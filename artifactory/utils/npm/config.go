@@ -3,6 +3,7 @@ package npm
 import (
 	"io"
 	"io/ioutil"
+	"strings"
 
 	gofrogcmd "github.com/jfrog/gofrog/io"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -40,3 +41,25 @@ func createConfigListCmdConfig(executablePath string, splitFlags []string, pipeW
 		ErrWriter:    nil,
 	}
 }
+
+// Returns the environment variables which set the registry and its authentication for clients reading the npm configuration from the npm_config_ environment variables.
+// npmAuth is in the .npmrc format, as returned by Artifactory.
+func CreateRegistryEnv(registry, npmAuth string) map[string]string {
+	env := map[string]string{"npm_config_registry": registry}
+	for key, value := range ParseNpmrc(npmAuth) {
+		env["npm_config_"+strings.Replace(key, "-", "_", -1)] = value
+	}
+	return env
+}
+
+// Parses configuration in the .npmrc format, which consists of "key = value" lines.
+func ParseNpmrc(npmrc string) map[string]string {
+	config := make(map[string]string)
+	for _, line := range strings.Split(npmrc, "\n") {
+		keyValue := strings.SplitN(line, "=", 2)
+		if len(keyValue) == 2 {
+			config[strings.TrimSpace(keyValue[0])] = strings.TrimSpace(keyValue[1])
+		}
+	}
+	return config
+}
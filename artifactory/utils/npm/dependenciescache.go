@@ -0,0 +1,83 @@
+package npm
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const cacheLatestVersion = 1
+
+// The file names and the checksums of the project's npm dependencies, from previous builds of the project.
+type DependenciesCache struct {
+	Version int                              `json:"version,omitempty"`
+	DepsMap map[string]*buildinfo.Dependency `json:"dependencies,omitempty"`
+}
+
+// Reads the cache of the dependencies of the project in projectDir.
+// Returns nil if the cache file does not exist, or if it was written in a different version of the cache format.
+func GetProjectDependenciesCache(projectDir string) (*DependenciesCache, error) {
+	cacheFilePath, exists, err := getCacheFilePath(projectDir)
+	if err != nil || !exists {
+		return nil, err
+	}
+	content, err := ioutil.ReadFile(cacheFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	cache := new(DependenciesCache)
+	if err = json.Unmarshal(content, cache); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if cache.Version != cacheLatestVersion {
+		log.Debug("Ignoring the npm dependencies cache in version", cache.Version, "- the current version is", cacheLatestVersion)
+		return nil, nil
+	}
+	return cache, nil
+}
+
+// Writes the cache of the dependencies of the project in projectDir, with the current dependencies only.
+// updatedMap contains the dependencies by their cache keys.
+func UpdateDependenciesCache(projectDir string, updatedMap map[string]*buildinfo.Dependency) error {
+	updatedCache := DependenciesCache{Version: cacheLatestVersion, DepsMap: updatedMap}
+	content, err := json.Marshal(&updatedCache)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	cacheFilePath, _, err := getCacheFilePath(projectDir)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckError(ioutil.WriteFile(cacheFilePath, content, 0644))
+}
+
+// Returns the dependency from the cache, or nil if the cache doesn't have it.
+// key - The cache key of the dependency, see GetDependencyCacheKey.
+func (cache *DependenciesCache) GetDependency(key string) *buildinfo.Dependency {
+	if cache == nil {
+		return nil
+	}
+	return cache.DepsMap[key]
+}
+
+// Returns the key of a dependency in the cache, such as @scope/name@1.0.0.
+func GetDependencyCacheKey(name, version string) string {
+	return name + "@" + version
+}
+
+// Cache file will be located in the .jfrog/projects/npm-deps.cache.json file of the project.
+func getCacheFilePath(projectDir string) (cacheFilePath string, exists bool, err error) {
+	projectsDirPath := filepath.Join(projectDir, cliutils.JfrogProjectDirName, "projects")
+	if err = fileutils.CreateDirIfNotExist(projectsDirPath); err != nil {
+		return "", false, err
+	}
+	cacheFilePath = filepath.Join(projectsDirPath, "npm-deps.cache.json")
+	exists, err = fileutils.IsFileExists(cacheFilePath, false)
+	return
+}
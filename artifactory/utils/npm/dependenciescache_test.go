@@ -0,0 +1,58 @@
+package npm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/utils/log"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+)
+
+func TestDependenciesCache(t *testing.T) {
+	log.SetDefaultLogger()
+	projectDir, err := ioutil.TempDir("", "npm-cache-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(projectDir)
+
+	cache, err := GetProjectDependenciesCache(projectDir)
+	if err != nil || cache != nil {
+		t.Fatalf("Expected no cache before the first update, got: %v, %v", cache, err)
+	}
+	lodash := &buildinfo.Dependency{Id: "lodash-4.17.20.tgz", Checksum: &buildinfo.Checksum{Sha1: "sha1A", Md5: "md5A"}}
+	scoped := &buildinfo.Dependency{Id: "app-1.0.0.tgz", Checksum: &buildinfo.Checksum{Sha1: "sha1B", Md5: "md5B"}}
+	cacheMap := map[string]*buildinfo.Dependency{
+		GetDependencyCacheKey("lodash", "4.17.20"):   lodash,
+		GetDependencyCacheKey("@jfrog/app", "1.0.0"): scoped,
+	}
+	if err = UpdateDependenciesCache(projectDir, cacheMap); err != nil {
+		t.Fatal(err)
+	}
+
+	cache, err = GetProjectDependenciesCache(projectDir)
+	if err != nil || cache == nil {
+		t.Fatalf("Failed reading the dependencies cache: %v, %v", cache, err)
+	}
+	if !reflect.DeepEqual(lodash, cache.GetDependency("lodash@4.17.20")) {
+		t.Errorf("Expected: %v, got: %v", lodash, cache.GetDependency("lodash@4.17.20"))
+	}
+	if !reflect.DeepEqual(scoped, cache.GetDependency("@jfrog/app@1.0.0")) {
+		t.Errorf("Expected: %v, got: %v", scoped, cache.GetDependency("@jfrog/app@1.0.0"))
+	}
+	if dep := cache.GetDependency("lodash@4.17.19"); dep != nil {
+		t.Errorf("Retrieving a non-existing dependency should return nil, got: %v", dep)
+	}
+
+	// A cache in a different version of the format is ignored.
+	cacheFilePath := filepath.Join(projectDir, ".jfrog", "projects", "npm-deps.cache.json")
+	if err = ioutil.WriteFile(cacheFilePath, []byte(`{"version": 0, "dependencies": {"lodash@4.17.20": {"id": "lodash-4.17.20.tgz"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if cache, err = GetProjectDependenciesCache(projectDir); err != nil || cache != nil {
+		t.Errorf("Expected a cache in an old version to be ignored, got: %v, %v", cache, err)
+	}
+}
@@ -0,0 +1,230 @@
+package npm
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// npm prefers the shrinkwrap file over the lockfile, when a project has both.
+var lockfileNames = []string{"npm-shrinkwrap.json", "package-lock.json"}
+
+const nodeModulesDir = "node_modules"
+
+// A package in the npm lockfile.
+type LockedPackage struct {
+	// The path of the package relative to the project root, such as node_modules/a/node_modules/b.
+	// The project itself is under the empty path, and the workspace packages under their directories.
+	Path      string
+	Name      string
+	Version   string
+	Resolved  string
+	Integrity string
+	Dev       bool
+	Optional  bool
+	// Packages which come inside the tarball of the package which bundles them, rather than downloaded from the registry.
+	Bundled bool
+	// The path of the package this package links to, for workspace packages and local directories.
+	Link string
+	// The names of the packages this package requires.
+	Requires []string
+}
+
+// The npm lockfile, package-lock.json or npm-shrinkwrap.json, in version 1, 2 or 3.
+type Lockfile struct {
+	Version int
+	// The packages by their paths.
+	Packages map[string]*LockedPackage
+}
+
+type lockfileContent struct {
+	LockfileVersion int `json:"lockfileVersion,omitempty"`
+	// Version 2 and above.
+	Packages map[string]*lockfilePackage `json:"packages,omitempty"`
+	// Version 1. Version 2 has both, for the backward compatibility.
+	Dependencies map[string]*lockfileDependency `json:"dependencies,omitempty"`
+}
+
+type lockfilePackage struct {
+	Name                 string            `json:"name,omitempty"`
+	Version              string            `json:"version,omitempty"`
+	Resolved             string            `json:"resolved,omitempty"`
+	Integrity            string            `json:"integrity,omitempty"`
+	Link                 bool              `json:"link,omitempty"`
+	Dev                  bool              `json:"dev,omitempty"`
+	Optional             bool              `json:"optional,omitempty"`
+	DevOptional          bool              `json:"devOptional,omitempty"`
+	InBundle             bool              `json:"inBundle,omitempty"`
+	Dependencies         map[string]string `json:"dependencies,omitempty"`
+	DevDependencies      map[string]string `json:"devDependencies,omitempty"`
+	OptionalDependencies map[string]string `json:"optionalDependencies,omitempty"`
+	PeerDependencies     map[string]string `json:"peerDependencies,omitempty"`
+}
+
+type lockfileDependency struct {
+	Version      string                         `json:"version,omitempty"`
+	Resolved     string                         `json:"resolved,omitempty"`
+	Integrity    string                         `json:"integrity,omitempty"`
+	Dev          bool                           `json:"dev,omitempty"`
+	Optional     bool                           `json:"optional,omitempty"`
+	Bundled      bool                           `json:"bundled,omitempty"`
+	Requires     map[string]string              `json:"requires,omitempty"`
+	Dependencies map[string]*lockfileDependency `json:"dependencies,omitempty"`
+}
+
+// Reads the lockfile of the project in projectDir.
+// Returns nil if the project doesn't have a lockfile.
+func ReadLockfile(projectDir string) (*Lockfile, error) {
+	for _, lockfileName := range lockfileNames {
+		lockfilePath := filepath.Join(projectDir, lockfileName)
+		exists, err := fileutils.IsFileExists(lockfilePath, false)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		log.Debug("Reading the npm lockfile", lockfilePath)
+		data, err := ioutil.ReadFile(lockfilePath)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		return ParseLockfile(data)
+	}
+	return nil, nil
+}
+
+func ParseLockfile(data []byte) (*Lockfile, error) {
+	content := new(lockfileContent)
+	if err := json.Unmarshal(data, content); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	lockfile := &Lockfile{Version: content.LockfileVersion, Packages: make(map[string]*LockedPackage)}
+	if content.Packages != nil {
+		for packagePath, lockfilePackage := range content.Packages {
+			lockfile.Packages[packagePath] = lockfilePackage.toLockedPackage(packagePath)
+		}
+		return lockfile, nil
+	}
+
+	// In version 1, the project itself isn't in the lockfile. It requires all the top level packages.
+	root := &LockedPackage{}
+	lockfile.Packages[root.Path] = root
+	for name := range content.Dependencies {
+		root.Requires = append(root.Requires, name)
+	}
+	lockfile.addDependencies(root.Path, content.Dependencies)
+	return lockfile, nil
+}
+
+func (lp *lockfilePackage) toLockedPackage(packagePath string) *LockedPackage {
+	lockedPackage := &LockedPackage{
+		Path:      packagePath,
+		Name:      lp.Name,
+		Version:   lp.Version,
+		Resolved:  lp.Resolved,
+		Integrity: lp.Integrity,
+		Dev:       lp.Dev || lp.DevOptional,
+		Optional:  lp.Optional || lp.DevOptional,
+		Bundled:   lp.InBundle,
+	}
+	if lockedPackage.Name == "" {
+		lockedPackage.Name = packageNameFromPath(packagePath)
+	}
+	if lp.Link {
+		lockedPackage.Link = lp.Resolved
+	}
+	requires := []map[string]string{lp.Dependencies, lp.OptionalDependencies, lp.PeerDependencies}
+	// The development dependencies are installed only for the project and for its workspace packages.
+	if !isInstalledPackage(packagePath) {
+		requires = append(requires, lp.DevDependencies)
+	}
+	for _, dependencies := range requires {
+		for name := range dependencies {
+			lockedPackage.Requires = append(lockedPackage.Requires, name)
+		}
+	}
+	return lockedPackage
+}
+
+func (lf *Lockfile) addDependencies(parentPath string, dependencies map[string]*lockfileDependency) {
+	for name, lockfileDependency := range dependencies {
+		packagePath := path.Join(parentPath, nodeModulesDir, name)
+		lockedPackage := &LockedPackage{
+			Path:      packagePath,
+			Name:      name,
+			Version:   lockfileDependency.Version,
+			Resolved:  lockfileDependency.Resolved,
+			Integrity: lockfileDependency.Integrity,
+			Dev:       lockfileDependency.Dev,
+			Optional:  lockfileDependency.Optional,
+			Bundled:   lockfileDependency.Bundled,
+		}
+		// Local directories are recorded with a file: version.
+		if strings.HasPrefix(lockfileDependency.Version, "file:") {
+			lockedPackage.Link = strings.TrimPrefix(lockfileDependency.Version, "file:")
+		}
+		for requiredName := range lockfileDependency.Requires {
+			lockedPackage.Requires = append(lockedPackage.Requires, requiredName)
+		}
+		lf.Packages[packagePath] = lockedPackage
+		lf.addDependencies(packagePath, lockfileDependency.Dependencies)
+	}
+}
+
+// Returns the package that the package in fromPath gets when requiring name, following the node_modules lookup from the directory of the package up to the project root.
+// Returns nil if the package isn't installed.
+func (lf *Lockfile) Resolve(fromPath, name string) *LockedPackage {
+	dir := fromPath
+	for {
+		if lockedPackage := lf.Packages[path.Join(dir, nodeModulesDir, name)]; lockedPackage != nil {
+			if lockedPackage.Link != "" {
+				return lf.Packages[path.Clean(lockedPackage.Link)]
+			}
+			return lockedPackage
+		}
+		if dir == "" {
+			return nil
+		}
+		if dir = path.Dir(dir); dir == "." {
+			dir = ""
+		}
+	}
+}
+
+// Returns true if the package was installed to node_modules, rather than being the project itself, a workspace package or a linked directory.
+func (lp *LockedPackage) IsInstalled() bool {
+	return isInstalledPackage(lp.Path) && lp.Link == ""
+}
+
+// Returns the name of the tarball the package was downloaded as, such as lodash-4.17.20.tgz.
+func (lp *LockedPackage) GetTarballName() string {
+	if lp.Resolved != "" && !strings.HasPrefix(lp.Resolved, "file:") {
+		resolved := lp.Resolved
+		if i := strings.IndexAny(resolved, "?#"); i != -1 {
+			resolved = resolved[:i]
+		}
+		if strings.HasSuffix(resolved, ".tgz") {
+			return path.Base(resolved)
+		}
+	}
+	return path.Base(lp.Name) + "-" + lp.Version + ".tgz"
+}
+
+func isInstalledPackage(packagePath string) bool {
+	return strings.HasPrefix(packagePath, nodeModulesDir+"/") || strings.Contains(packagePath, "/"+nodeModulesDir+"/")
+}
+
+// Returns the name of the package from its path, such as @scope/name from node_modules/a/node_modules/@scope/name.
+func packageNameFromPath(packagePath string) string {
+	if i := strings.LastIndex(packagePath, nodeModulesDir+"/"); i != -1 {
+		return packagePath[i+len(nodeModulesDir)+1:]
+	}
+	return ""
+}
@@ -0,0 +1,225 @@
+package npm
+
+import (
+	"crypto/sha1"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/utils/log"
+)
+
+const v1Lockfile = `{
+  "name": "project",
+  "version": "1.0.0",
+  "lockfileVersion": 1,
+  "requires": true,
+  "dependencies": {
+    "debug": {
+      "version": "2.6.9",
+      "resolved": "https://registry.npmjs.org/debug/-/debug-2.6.9.tgz",
+      "integrity": "sha512-...",
+      "requires": {"ms": "2.0.0"},
+      "dependencies": {
+        "ms": {
+          "version": "2.0.0",
+          "resolved": "https://registry.npmjs.org/ms/-/ms-2.0.0.tgz",
+          "integrity": "sha1-..."
+        }
+      }
+    },
+    "local": {"version": "file:../local"},
+    "ms": {
+      "version": "2.1.2",
+      "resolved": "https://registry.npmjs.org/ms/-/ms-2.1.2.tgz",
+      "integrity": "sha512-...",
+      "dev": true
+    }
+  }
+}`
+
+const v2Lockfile = `{
+  "name": "project",
+  "lockfileVersion": 2,
+  "requires": true,
+  "packages": {
+    "": {
+      "name": "project",
+      "workspaces": ["packages/*"],
+      "devDependencies": {"typescript": "^4.0.0"}
+    },
+    "node_modules/@jfrog/app": {"resolved": "packages/app", "link": true},
+    "node_modules/fsevents": {
+      "version": "2.1.3",
+      "resolved": "https://registry.npmjs.org/fsevents/-/fsevents-2.1.3.tgz",
+      "integrity": "sha512-...",
+      "optional": true
+    },
+    "node_modules/lodash": {
+      "version": "4.17.20",
+      "resolved": "https://registry.npmjs.org/lodash/-/lodash-4.17.20.tgz",
+      "integrity": "sha512-..."
+    },
+    "node_modules/typescript": {
+      "version": "4.0.5",
+      "resolved": "https://registry.npmjs.org/typescript/-/typescript-4.0.5.tgz",
+      "integrity": "sha512-...",
+      "dev": true
+    },
+    "packages/app": {
+      "name": "@jfrog/app",
+      "version": "1.0.0",
+      "dependencies": {"lodash": "^4.17.0", "ms": "^2.1.0"},
+      "optionalDependencies": {"fsevents": "^2.1.0"}
+    },
+    "packages/app/node_modules/ms": {
+      "version": "2.1.2",
+      "resolved": "https://registry.npmjs.org/ms/-/ms-2.1.2.tgz",
+      "integrity": "sha512-..."
+    },
+    "packages/utils": {
+      "name": "utils",
+      "version": "1.0.0",
+      "dependencies": {"@jfrog/app": "1.0.0"}
+    }
+  },
+  "dependencies": {}
+}`
+
+func TestParseV1Lockfile(t *testing.T) {
+	log.SetDefaultLogger()
+	lockfile, err := ParseLockfile([]byte(v1Lockfile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lockfile.Version != 1 {
+		t.Errorf("Expected lockfile version 1, got: %d", lockfile.Version)
+	}
+	root := lockfile.Packages[""]
+	sort.Strings(root.Requires)
+	if !reflect.DeepEqual([]string{"debug", "local", "ms"}, root.Requires) {
+		t.Errorf("Unexpected packages required by the project: %v", root.Requires)
+	}
+
+	debug := lockfile.Resolve("", "debug")
+	expectedDebug := &LockedPackage{Path: "node_modules/debug", Name: "debug", Version: "2.6.9",
+		Resolved: "https://registry.npmjs.org/debug/-/debug-2.6.9.tgz", Integrity: "sha512-...", Requires: []string{"ms"}}
+	if !reflect.DeepEqual(expectedDebug, debug) {
+		t.Errorf("Expected: %v, got: %v", expectedDebug, debug)
+	}
+	// The nested package is resolved before the top level one.
+	if ms := lockfile.Resolve(debug.Path, "ms"); ms == nil || ms.Version != "2.0.0" || ms.Dev {
+		t.Errorf("Expected ms 2.0.0 to be resolved for debug, got: %v", ms)
+	}
+	if ms := lockfile.Resolve("", "ms"); ms == nil || ms.Version != "2.1.2" || !ms.Dev {
+		t.Errorf("Expected the development ms 2.1.2 to be resolved for the project, got: %v", ms)
+	}
+	if local := lockfile.Packages["node_modules/local"]; local.IsInstalled() || local.Link != "../local" {
+		t.Errorf("Expected the local package to be linked to ../local, got: %v", local)
+	}
+}
+
+func TestParseV2Lockfile(t *testing.T) {
+	log.SetDefaultLogger()
+	lockfile, err := ParseLockfile([]byte(v2Lockfile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := lockfile.Packages["packages/app"]
+	sort.Strings(app.Requires)
+	if !reflect.DeepEqual([]string{"fsevents", "lodash", "ms"}, app.Requires) {
+		t.Errorf("Unexpected packages required by the workspace package: %v", app.Requires)
+	}
+	if app.IsInstalled() {
+		t.Error("Expected the workspace package not to be installed")
+	}
+
+	tests := []struct {
+		fromPath     string
+		name         string
+		expectedPath string
+	}{
+		{"", "typescript", "node_modules/typescript"},
+		{"packages/app", "ms", "packages/app/node_modules/ms"},
+		{"packages/app", "lodash", "node_modules/lodash"},
+		{"packages/utils", "@jfrog/app", "packages/app"},
+		{"packages/utils", "ms", ""},
+	}
+	for _, test := range tests {
+		resolved := lockfile.Resolve(test.fromPath, test.name)
+		if test.expectedPath == "" {
+			if resolved != nil {
+				t.Errorf("Expected %s not to be resolved for '%s', got: %v", test.name, test.fromPath, resolved)
+			}
+			continue
+		}
+		if resolved == nil || resolved.Path != test.expectedPath {
+			t.Errorf("Expected %s to be resolved to %s for '%s', got: %v", test.name, test.expectedPath, test.fromPath, resolved)
+		}
+	}
+
+	lodash := lockfile.Packages["node_modules/lodash"]
+	if lodash.Name != "lodash" || !lodash.IsInstalled() || lodash.GetTarballName() != "lodash-4.17.20.tgz" {
+		t.Errorf("Unexpected lodash package: %v", lodash)
+	}
+	if fsevents := lockfile.Packages["node_modules/fsevents"]; !fsevents.Optional || fsevents.Dev {
+		t.Errorf("Expected fsevents to be an optional production package, got: %v", fsevents)
+	}
+}
+
+func TestGetTarballName(t *testing.T) {
+	tests := []struct {
+		lockedPackage *LockedPackage
+		expected      string
+	}{
+		{&LockedPackage{Name: "lodash", Version: "4.17.20", Resolved: "https://registry.npmjs.org/lodash/-/lodash-4.17.20.tgz"}, "lodash-4.17.20.tgz"},
+		{&LockedPackage{Name: "@jfrog/app", Version: "1.0.0", Resolved: "http://localhost/api/npm/npm-remote/@jfrog/app/-/app-1.0.0.tgz?token=1"}, "app-1.0.0.tgz"},
+		{&LockedPackage{Name: "@jfrog/app", Version: "1.0.0"}, "app-1.0.0.tgz"},
+	}
+	for _, test := range tests {
+		if actual := test.lockedPackage.GetTarballName(); actual != test.expected {
+			t.Errorf("Expected: %s, got: %s", test.expected, actual)
+		}
+	}
+}
+
+func TestGetCachedTarballChecksum(t *testing.T) {
+	log.SetDefaultLogger()
+	cacheDir, err := ioutil.TempDir("", "npm-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cacheDir)
+
+	tarball := []byte("tarball content")
+	sha512Digest := sha512.Sum512(tarball)
+	sha1Digest := sha1.Sum(tarball)
+	hexDigest := hex.EncodeToString(sha512Digest[:])
+	contentDir := filepath.Join(cacheDir, "_cacache", "content-v2", "sha512", hexDigest[:2], hexDigest[2:4])
+	if err = os.MkdirAll(contentDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(contentDir, hexDigest[4:]), tarball, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	integrity := "sha512-" + base64.StdEncoding.EncodeToString(sha512Digest[:])
+	checksum, err := GetCachedTarballChecksum(cacheDir, integrity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checksum == nil || checksum.Sha1 != hex.EncodeToString(sha1Digest[:]) || checksum.Md5 == "" {
+		t.Errorf("Unexpected checksum of the cached tarball: %v", checksum)
+	}
+
+	missingIntegrity := "sha1-" + base64.StdEncoding.EncodeToString(sha1Digest[:])
+	if checksum, err = GetCachedTarballChecksum(cacheDir, missingIntegrity); err != nil || checksum != nil {
+		t.Errorf("Expected no checksum for a tarball missing in the cache, got: %v, %v", checksum, err)
+	}
+}
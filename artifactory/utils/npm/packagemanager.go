@@ -0,0 +1,73 @@
+package npm
+
+import (
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The utilities shared by the package managers which resolve their dependencies from npm repositories, such as yarn and pnpm.
+
+func GetPackageManagerExecutablePath(executableName string) (string, error) {
+	execPath, err := exec.LookPath(executableName)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if execPath == "" {
+		return "", errorutils.CheckError(errors.New("could not find '" + executableName + "' executable"))
+	}
+	log.Debug("Found", executableName, "executable at:", execPath)
+	return execPath, nil
+}
+
+type PackageManagerConfig struct {
+	Executable string
+	Command    []string
+	Dir        string
+	// Set for the package manager process only, to keep the credentials, such as the ones returned by CreateRegistryEnv, out of the environment of the CLI.
+	Env       map[string]string
+	StrWriter io.WriteCloser
+	ErrWriter io.WriteCloser
+}
+
+func (config *PackageManagerConfig) GetCmd() *exec.Cmd {
+	cmd := exec.Command(config.Executable, config.Command...)
+	cmd.Dir = config.Dir
+	cmd.Env = os.Environ()
+	for key, value := range config.Env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+	return cmd
+}
+
+func (config *PackageManagerConfig) GetEnv() map[string]string {
+	return map[string]string{}
+}
+
+func (config *PackageManagerConfig) GetStdWriter() io.WriteCloser {
+	return config.StrWriter
+}
+
+func (config *PackageManagerConfig) GetErrWriter() io.WriteCloser {
+	return config.ErrWriter
+}
+
+// A dependency resolved by a lockfile, with the scopes of the package.json dependencies which require it.
+type ResolvedDependency struct {
+	Name    string
+	Version string
+	Scopes  []string
+}
+
+func (dep *ResolvedDependency) AddScope(scope string) {
+	for _, existingScope := range dep.Scopes {
+		if existingScope == scope {
+			return
+		}
+	}
+	dep.Scopes = append(dep.Scopes, scope)
+}
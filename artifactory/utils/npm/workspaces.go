@@ -0,0 +1,195 @@
+package npm
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// A package of an npm project, with the names of the packages it depends on.
+type Workspace struct {
+	Dir          string
+	PackageInfo  *PackageInfo
+	Private      bool
+	Dependencies []string
+}
+
+type packageJson struct {
+	Private              bool              `json:"private,omitempty"`
+	Dependencies         map[string]string `json:"dependencies,omitempty"`
+	DevDependencies      map[string]string `json:"devDependencies,omitempty"`
+	OptionalDependencies map[string]string `json:"optionalDependencies,omitempty"`
+	// Either a list of patterns, or an object with a list of patterns in its "packages" field.
+	Workspaces json.RawMessage `json:"workspaces,omitempty"`
+}
+
+// Returns the package in projectDir.
+func ReadWorkspace(projectDir string) (*Workspace, error) {
+	workspace, _, err := readWorkspace(projectDir)
+	return workspace, err
+}
+
+// Returns the workspace packages declared by the "workspaces" field of package.json in projectDir, sorted by their directories.
+// Returns an empty list if the project doesn't have workspaces.
+func ReadWorkspaces(projectDir string) ([]*Workspace, error) {
+	_, patterns, err := readWorkspace(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	var dirs []string
+	for _, pattern := range patterns {
+		matches, err := globDirs(projectDir, strings.Split(filepath.ToSlash(pattern), "/"))
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		dirs = append(dirs, matches...)
+	}
+	sort.Strings(dirs)
+
+	var workspaces []*Workspace
+	for i, dir := range dirs {
+		if i > 0 && dirs[i-1] == dir {
+			continue
+		}
+		exists, err := fileutils.IsFileExists(filepath.Join(dir, "package.json"), false)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		workspace, _, err := readWorkspace(dir)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Found the workspace package", workspace.PackageInfo.BuildInfoModuleId(), "in", dir)
+		workspaces = append(workspaces, workspace)
+	}
+	return workspaces, nil
+}
+
+// Returns the directories under baseDir which match the segments of a workspaces pattern.
+// In addition to the patterns of filepath.Match, a "**" segment matches any number of directories.
+// As done by the package managers, directories in node_modules are never matched.
+// Directories which can't be read are skipped, as done by filepath.Glob.
+func globDirs(baseDir string, segments []string) ([]string, error) {
+	for len(segments) > 0 && (segments[0] == "" || segments[0] == ".") {
+		segments = segments[1:]
+	}
+	if len(segments) == 0 {
+		return []string{baseDir}, nil
+	}
+	segment, rest := segments[0], segments[1:]
+	if segment == "**" {
+		// "**" matches no directory, or a sub directory followed by "**" again.
+		// Symbolic links aren't followed, to avoid walking into cycles.
+		matches, err := globDirs(baseDir, rest)
+		if err != nil {
+			return nil, err
+		}
+		for _, subDir := range listSubDirs(baseDir, false) {
+			subDirMatches, err := globDirs(filepath.Join(baseDir, subDir), segments)
+			if err != nil {
+				return nil, err
+			}
+			matches = append(matches, subDirMatches...)
+		}
+		return matches, nil
+	}
+	var matches []string
+	for _, subDir := range listSubDirs(baseDir, true) {
+		matched, err := filepath.Match(segment, subDir)
+		if err != nil {
+			return nil, err
+		}
+		if !matched {
+			continue
+		}
+		subDirMatches, err := globDirs(filepath.Join(baseDir, subDir), rest)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, subDirMatches...)
+	}
+	return matches, nil
+}
+
+// Returns the names of the sub directories of dir, except node_modules.
+func listSubDirs(dir string, followSymlinks bool) []string {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var subDirs []string
+	for _, file := range files {
+		if file.Name() == "node_modules" {
+			continue
+		}
+		isDir := file.IsDir()
+		if !isDir && followSymlinks && file.Mode()&os.ModeSymlink != 0 {
+			if target, err := os.Stat(filepath.Join(dir, file.Name())); err == nil {
+				isDir = target.IsDir()
+			}
+		}
+		if isDir {
+			subDirs = append(subDirs, file.Name())
+		}
+	}
+	return subDirs
+}
+
+func readWorkspace(dir string) (workspace *Workspace, workspacesPatterns []string, err error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	packageInfo, err := ReadPackageInfo(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	parsedPackageJson := new(packageJson)
+	if err = json.Unmarshal(data, parsedPackageJson); err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	workspace = &Workspace{Dir: dir, PackageInfo: packageInfo, Private: parsedPackageJson.Private}
+	for _, dependencies := range []map[string]string{parsedPackageJson.Dependencies, parsedPackageJson.DevDependencies, parsedPackageJson.OptionalDependencies} {
+		for name := range dependencies {
+			workspace.Dependencies = append(workspace.Dependencies, name)
+		}
+	}
+	sort.Strings(workspace.Dependencies)
+	workspacesPatterns, err = parseWorkspacesPatterns(parsedPackageJson.Workspaces)
+	return
+}
+
+func parseWorkspacesPatterns(workspaces json.RawMessage) ([]string, error) {
+	if len(workspaces) == 0 {
+		return nil, nil
+	}
+	var patterns []string
+	if err := json.Unmarshal(workspaces, &patterns); err == nil {
+		return patterns, nil
+	}
+	var workspacesObject struct {
+		Packages []string `json:"packages,omitempty"`
+	}
+	if err := json.Unmarshal(workspaces, &workspacesObject); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return workspacesObject.Packages, nil
+}
+
+// Returns the full name of the package, including its scope.
+func (pi *PackageInfo) FullName() string {
+	if pi.Scope == "" {
+		return pi.Name
+	}
+	return pi.Scope + "/" + pi.Name
+}
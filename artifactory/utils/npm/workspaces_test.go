@@ -0,0 +1,102 @@
+package npm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/utils/log"
+)
+
+func TestReadWorkspaces(t *testing.T) {
+	log.SetDefaultLogger()
+	tests := []struct {
+		name       string
+		workspaces string
+	}{
+		{"list", `["packages/*"]`},
+		{"object", `{"packages": ["packages/app", "packages/utils"], "nohoist": ["**/react"]}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			projectDir, err := ioutil.TempDir("", "npm-workspaces")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(projectDir)
+			writePackageJson(t, projectDir, `{"name": "root", "private": true, "devDependencies": {"typescript": "^4.0.0"}, "workspaces": `+test.workspaces+`}`)
+			writePackageJson(t, filepath.Join(projectDir, "packages", "app"), `{"name": "@jfrog/app", "version": "1.0.0", "dependencies": {"lodash": "^4.17.0", "utils": "1.0.0"}}`)
+			writePackageJson(t, filepath.Join(projectDir, "packages", "utils"), `{"name": "utils", "version": "1.0.0", "private": true, "optionalDependencies": {"ms": "^2.1.0"}}`)
+			// A directory without package.json is not a workspace package.
+			if err = os.MkdirAll(filepath.Join(projectDir, "packages", "docs"), 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			workspaces, err := ReadWorkspaces(projectDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := []*Workspace{
+				{Dir: filepath.Join(projectDir, "packages", "app"), PackageInfo: &PackageInfo{Name: "app", Version: "1.0.0", Scope: "@jfrog"}, Dependencies: []string{"lodash", "utils"}},
+				{Dir: filepath.Join(projectDir, "packages", "utils"), PackageInfo: &PackageInfo{Name: "utils", Version: "1.0.0"}, Private: true, Dependencies: []string{"ms"}},
+			}
+			if !reflect.DeepEqual(expected, workspaces) {
+				t.Errorf("Expected: %v, got: %v", expected, workspaces)
+			}
+		})
+	}
+}
+
+func TestReadWorkspacesGlobstar(t *testing.T) {
+	log.SetDefaultLogger()
+	projectDir, err := ioutil.TempDir("", "npm-workspaces")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(projectDir)
+	writePackageJson(t, projectDir, `{"name": "root", "private": true, "workspaces": ["packages/**"]}`)
+	writePackageJson(t, filepath.Join(projectDir, "packages", "app"), `{"name": "app", "version": "1.0.0"}`)
+	writePackageJson(t, filepath.Join(projectDir, "packages", "libs", "utils"), `{"name": "utils", "version": "1.0.0"}`)
+	// Packages installed in node_modules are not workspace packages.
+	writePackageJson(t, filepath.Join(projectDir, "packages", "app", "node_modules", "ms"), `{"name": "ms", "version": "2.1.0"}`)
+
+	workspaces, err := ReadWorkspaces(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []*Workspace{
+		{Dir: filepath.Join(projectDir, "packages", "app"), PackageInfo: &PackageInfo{Name: "app", Version: "1.0.0"}},
+		{Dir: filepath.Join(projectDir, "packages", "libs", "utils"), PackageInfo: &PackageInfo{Name: "utils", Version: "1.0.0"}},
+	}
+	if !reflect.DeepEqual(expected, workspaces) {
+		t.Errorf("Expected: %v, got: %v", expected, workspaces)
+	}
+}
+
+func TestReadWorkspacesWithoutWorkspaces(t *testing.T) {
+	log.SetDefaultLogger()
+	projectDir, err := ioutil.TempDir("", "npm-workspaces")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(projectDir)
+	writePackageJson(t, projectDir, `{"name": "project", "version": "1.0.0"}`)
+	workspaces, err := ReadWorkspaces(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(workspaces) != 0 {
+		t.Errorf("Expected no workspaces, got: %v", workspaces)
+	}
+}
+
+func writePackageJson(t *testing.T, dir, content string) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "package.json"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,206 @@
+package pnpm
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli/artifactory/utils/npm"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+)
+
+const LockfileName = "pnpm-lock.yaml"
+
+// The content of pnpm-lock.yaml.
+// In a workspace, the dependencies of each workspace package are listed under its directory in "importers".
+// Otherwise, the dependencies of the project are listed at the top level of the lockfile.
+// From version 9 of the lockfile, the dependencies of the packages are listed in "snapshots", by the package and its peer dependencies.
+type Lockfile struct {
+	Version   string               `yaml:"lockfileVersion"`
+	Importers map[string]*Importer `yaml:"importers"`
+	Importer  `yaml:",inline"`
+	Packages  map[string]*Package `yaml:"packages"`
+	Snapshots map[string]*Package `yaml:"snapshots"`
+	// The packages with their dependencies, by their keys in the lockfile.
+	dependencyGraph map[string]*Package
+	// Up to version 6 of the lockfile, the package keys start with "/".
+	keyPrefix string
+}
+
+// The direct dependencies of a project or of a workspace package, mapped to their resolved versions.
+type Importer struct {
+	Dependencies         map[string]*ResolvedVersion `yaml:"dependencies"`
+	DevDependencies      map[string]*ResolvedVersion `yaml:"devDependencies"`
+	OptionalDependencies map[string]*ResolvedVersion `yaml:"optionalDependencies"`
+}
+
+// A package in the lockfile, with its dependencies mapped to their resolved versions.
+type Package struct {
+	Dependencies         map[string]*ResolvedVersion `yaml:"dependencies"`
+	OptionalDependencies map[string]*ResolvedVersion `yaml:"optionalDependencies"`
+}
+
+// The resolved version of a dependency, such as "4.17.15", "16.13.1_react@16.13.1" or "link:../utils".
+// Lockfiles of version 6 and above write it as the "version" field of an object, with the dependency specifier.
+type ResolvedVersion string
+
+func (resolvedVersion *ResolvedVersion) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var version string
+	if err := unmarshal(&version); err == nil {
+		*resolvedVersion = ResolvedVersion(version)
+		return nil
+	}
+	var dependency struct {
+		Version string `yaml:"version"`
+	}
+	if err := unmarshal(&dependency); err != nil {
+		return err
+	}
+	*resolvedVersion = ResolvedVersion(dependency.Version)
+	return nil
+}
+
+func ReadLockfile(projectDir string) (*Lockfile, error) {
+	log.Debug("Reading dependencies from", filepath.Join(projectDir, LockfileName))
+	data, err := ioutil.ReadFile(filepath.Join(projectDir, LockfileName))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return ParseLockfile(data)
+}
+
+func ParseLockfile(data []byte) (*Lockfile, error) {
+	lockfile := new(Lockfile)
+	if err := yaml.Unmarshal(data, lockfile); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	switch majorVersion := strings.Split(lockfile.Version, ".")[0]; majorVersion {
+	case "5", "6":
+		lockfile.dependencyGraph = lockfile.Packages
+		lockfile.keyPrefix = "/"
+	case "9":
+		lockfile.dependencyGraph = lockfile.Snapshots
+	default:
+		return nil, errorutils.CheckError(errors.New("version '" + lockfile.Version + "' of " + LockfileName + " is not supported. " +
+			"The supported lockfile versions are 5, 6 and 9"))
+	}
+	if len(lockfile.Importers) == 0 {
+		// Not a workspace. The project is the only importer.
+		lockfile.Importers = map[string]*Importer{".": &lockfile.Importer}
+	}
+	return lockfile, nil
+}
+
+// Returns the directories of the workspace packages, relative to the workspace root, sorted.
+// The workspace root is ".". If the project isn't a workspace, it is the only package.
+func (lockfile *Lockfile) ImporterDirs() []string {
+	var dirs []string
+	for dir := range lockfile.Importers {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// Returns the packages resolved for the dependencies of the workspace package in importerDir, and for their transitive dependencies.
+// Packages required by "dependencies" or "optionalDependencies" have the production scope,
+// and packages required by "devDependencies" have the development scope.
+// Workspace packages which are linked as dependencies are not included, since they are modules of their own.
+func (lockfile *Lockfile) CollectDependencies(importerDir string) map[string]*npm.ResolvedDependency {
+	dependencies := make(map[string]*npm.ResolvedDependency)
+	importer := lockfile.Importers[importerDir]
+	if importer == nil {
+		return dependencies
+	}
+	lockfile.collectDependencies(importer.Dependencies, "production", dependencies)
+	lockfile.collectDependencies(importer.OptionalDependencies, "production", dependencies)
+	lockfile.collectDependencies(importer.DevDependencies, "development", dependencies)
+	return dependencies
+}
+
+func (lockfile *Lockfile) collectDependencies(direct map[string]*ResolvedVersion, scope string, dependencies map[string]*npm.ResolvedDependency) {
+	var queue []map[string]*ResolvedVersion
+	queue = append(queue, direct)
+	visited := make(map[string]bool)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for name, resolvedVersion := range current {
+			if resolvedVersion == nil || strings.HasPrefix(string(*resolvedVersion), "link:") {
+				continue
+			}
+			path := lockfile.packagePath(name, string(*resolvedVersion))
+			if visited[path] {
+				continue
+			}
+			visited[path] = true
+			pkg, ok := lockfile.dependencyGraph[path]
+			if !ok {
+				log.Debug("The dependency", name, string(*resolvedVersion), "could not be found in", LockfileName)
+				continue
+			}
+			if pkg != nil {
+				queue = append(queue, pkg.Dependencies, pkg.OptionalDependencies)
+			}
+			pkgName, pkgVersion := parsePackagePath(path)
+			key := pkgName + "-" + pkgVersion
+			if dependencies[key] == nil {
+				dependencies[key] = &npm.ResolvedDependency{Name: pkgName, Version: pkgVersion}
+			}
+			dependencies[key].AddScope(scope)
+		}
+	}
+}
+
+// Returns the key of a dependency in the packages of the lockfile.
+// This is "/name/version" in lockfiles up to version 5, "/name@version" in version 6, and "name@version" from version 9.
+func (lockfile *Lockfile) packagePath(name, resolvedVersion string) string {
+	if lockfile.isAliasedVersion(resolvedVersion) {
+		// Aliased dependencies are resolved to the path of the package.
+		return resolvedVersion
+	}
+	if path := lockfile.keyPrefix + name + "@" + resolvedVersion; lockfile.dependencyGraph[path] != nil || lockfile.keyPrefix == "" {
+		return path
+	}
+	return "/" + name + "/" + resolvedVersion
+}
+
+// Aliased dependencies are resolved to "/name@version" up to version 6 of the lockfile, and to "name@version" from version 9.
+func (lockfile *Lockfile) isAliasedVersion(resolvedVersion string) bool {
+	if lockfile.keyPrefix != "" {
+		return strings.HasPrefix(resolvedVersion, lockfile.keyPrefix)
+	}
+	// Remove the suffix of the peer dependencies, which may contain "@".
+	if i := strings.Index(resolvedVersion, "("); i >= 0 {
+		resolvedVersion = resolvedVersion[:i]
+	}
+	return strings.LastIndex(resolvedVersion, "@") > 0
+}
+
+// Returns the name and the version of a package path, such as "react-dom" and "16.13.1" for "/react-dom/16.13.1_react@16.13.1".
+func parsePackagePath(path string) (name, version string) {
+	path = strings.TrimPrefix(path, "/")
+	// Remove the suffix of the peer dependencies.
+	if i := strings.Index(path, "("); i >= 0 {
+		path = path[:i]
+	}
+	start := 0
+	if strings.HasPrefix(path, "@") {
+		// Scoped package.
+		start = strings.Index(path, "/") + 1
+	}
+	// The name and the version are separated by "/" up to version 5 of the lockfile, and by "@" from version 6.
+	i := strings.IndexAny(path[start:], "/@")
+	if i < 0 {
+		return path, ""
+	}
+	name, version = path[:start+i], path[start+i+1:]
+	if i := strings.Index(version, "_"); i >= 0 {
+		version = version[:i]
+	}
+	return name, version
+}
@@ -0,0 +1,244 @@
+package pnpm
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/artifactory/utils/npm"
+	"github.com/jfrog/jfrog-cli/utils/log"
+)
+
+const workspaceLockfile = `lockfileVersion: 5.2
+importers:
+  .:
+    devDependencies:
+      typescript: 3.9.7
+    specifiers:
+      typescript: ^3.9.0
+  packages/app:
+    dependencies:
+      '@babel/code-frame': 7.8.3
+      react-dom: 16.13.1_react@16.13.1
+      utils: link:../utils
+    specifiers:
+      '@babel/code-frame': ^7.8.3
+      react-dom: ^16.13.0
+      utils: workspace:*
+  packages/utils:
+    dependencies:
+      js-tokens: 4.0.0
+    specifiers:
+      js-tokens: ^4.0.0
+packages:
+  /@babel/code-frame/7.8.3:
+    dependencies:
+      js-tokens: 4.0.0
+    dev: false
+    resolution:
+      integrity: sha512-...
+  /js-tokens/4.0.0:
+    dev: false
+    resolution:
+      integrity: sha512-...
+  /react-dom/16.13.1_react@16.13.1:
+    dependencies:
+      react: 16.13.1
+    dev: false
+    peerDependencies:
+      react: ^16.13.1
+    resolution:
+      integrity: sha512-...
+  /react/16.13.1:
+    dependencies:
+      js-tokens: 4.0.0
+    dev: false
+    resolution:
+      integrity: sha512-...
+  /typescript/3.9.7:
+    dev: true
+    engines:
+      node: '>=4.2.0'
+    hasBin: true
+    resolution:
+      integrity: sha512-...
+`
+
+const v6Lockfile = `lockfileVersion: '6.0'
+
+dependencies:
+  react-dom:
+    specifier: ^16.13.0
+    version: 16.13.1(react@16.13.1)
+
+devDependencies:
+  '@babel/code-frame':
+    specifier: ^7.8.3
+    version: 7.8.3
+
+packages:
+
+  /@babel/code-frame@7.8.3:
+    resolution: {integrity: sha512-...}
+    dependencies:
+      js-tokens: 4.0.0
+    dev: true
+
+  /js-tokens@4.0.0:
+    resolution: {integrity: sha512-...}
+
+  /react-dom@16.13.1(react@16.13.1):
+    resolution: {integrity: sha512-...}
+    peerDependencies:
+      react: ^16.13.1
+    dependencies:
+      react: 16.13.1
+    dev: false
+
+  /react@16.13.1:
+    resolution: {integrity: sha512-...}
+    dependencies:
+      js-tokens: 4.0.0
+    dev: false
+`
+
+const v9Lockfile = `lockfileVersion: '9.0'
+
+settings:
+  autoInstallPeers: true
+  excludeLinksFromLockfile: false
+
+importers:
+
+  .:
+    dependencies:
+      react-dom:
+        specifier: ^16.13.0
+        version: 16.13.1(react@16.13.1)
+      tokens:
+        specifier: npm:js-tokens@^4.0.0
+        version: js-tokens@4.0.0
+    devDependencies:
+      '@babel/code-frame':
+        specifier: ^7.8.3
+        version: 7.8.3
+
+packages:
+
+  '@babel/code-frame@7.8.3':
+    resolution: {integrity: sha512-...}
+
+  js-tokens@4.0.0:
+    resolution: {integrity: sha512-...}
+
+  react-dom@16.13.1:
+    resolution: {integrity: sha512-...}
+    peerDependencies:
+      react: ^16.13.1
+
+  react@16.13.1:
+    resolution: {integrity: sha512-...}
+
+snapshots:
+
+  '@babel/code-frame@7.8.3':
+    dependencies:
+      js-tokens: 4.0.0
+
+  js-tokens@4.0.0: {}
+
+  react-dom@16.13.1(react@16.13.1):
+    dependencies:
+      react: 16.13.1
+
+  react@16.13.1:
+    dependencies:
+      js-tokens: 4.0.0
+`
+
+func TestWorkspaceDependencies(t *testing.T) {
+	log.SetDefaultLogger()
+	lockfile, err := ParseLockfile([]byte(workspaceLockfile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dirs := lockfile.ImporterDirs(); !reflect.DeepEqual([]string{".", "packages/app", "packages/utils"}, dirs) {
+		t.Errorf("Unexpected workspace packages: %v", dirs)
+	}
+	expected := map[string]map[string]*npm.ResolvedDependency{
+		".": {
+			"typescript-3.9.7": {Name: "typescript", Version: "3.9.7", Scopes: []string{"development"}},
+		},
+		"packages/app": {
+			"@babel/code-frame-7.8.3": {Name: "@babel/code-frame", Version: "7.8.3", Scopes: []string{"production"}},
+			"react-dom-16.13.1":       {Name: "react-dom", Version: "16.13.1", Scopes: []string{"production"}},
+			"react-16.13.1":           {Name: "react", Version: "16.13.1", Scopes: []string{"production"}},
+			"js-tokens-4.0.0":         {Name: "js-tokens", Version: "4.0.0", Scopes: []string{"production"}},
+		},
+		"packages/utils": {
+			"js-tokens-4.0.0": {Name: "js-tokens", Version: "4.0.0", Scopes: []string{"production"}},
+		},
+	}
+	for importerDir, expectedDependencies := range expected {
+		if dependencies := lockfile.CollectDependencies(importerDir); !reflect.DeepEqual(expectedDependencies, dependencies) {
+			t.Errorf("Expected the dependencies of %s: %v, got: %v", importerDir, expectedDependencies, dependencies)
+		}
+	}
+}
+
+func TestV6LockfileDependencies(t *testing.T) {
+	log.SetDefaultLogger()
+	lockfile, err := ParseLockfile([]byte(v6Lockfile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dirs := lockfile.ImporterDirs(); !reflect.DeepEqual([]string{"."}, dirs) {
+		t.Errorf("Unexpected workspace packages: %v", dirs)
+	}
+	expected := map[string]*npm.ResolvedDependency{
+		"@babel/code-frame-7.8.3": {Name: "@babel/code-frame", Version: "7.8.3", Scopes: []string{"development"}},
+		"react-dom-16.13.1":       {Name: "react-dom", Version: "16.13.1", Scopes: []string{"production"}},
+		"react-16.13.1":           {Name: "react", Version: "16.13.1", Scopes: []string{"production"}},
+		"js-tokens-4.0.0":         {Name: "js-tokens", Version: "4.0.0", Scopes: []string{"development", "production"}},
+	}
+	dependencies := lockfile.CollectDependencies(".")
+	for _, dependency := range dependencies {
+		sort.Strings(dependency.Scopes)
+	}
+	if !reflect.DeepEqual(expected, dependencies) {
+		t.Errorf("Expected: %v, got: %v", expected, dependencies)
+	}
+}
+
+func TestV9LockfileDependencies(t *testing.T) {
+	log.SetDefaultLogger()
+	lockfile, err := ParseLockfile([]byte(v9Lockfile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dirs := lockfile.ImporterDirs(); !reflect.DeepEqual([]string{"."}, dirs) {
+		t.Errorf("Unexpected workspace packages: %v", dirs)
+	}
+	expected := map[string]*npm.ResolvedDependency{
+		"@babel/code-frame-7.8.3": {Name: "@babel/code-frame", Version: "7.8.3", Scopes: []string{"development"}},
+		"react-dom-16.13.1":       {Name: "react-dom", Version: "16.13.1", Scopes: []string{"production"}},
+		"react-16.13.1":           {Name: "react", Version: "16.13.1", Scopes: []string{"production"}},
+		"js-tokens-4.0.0":         {Name: "js-tokens", Version: "4.0.0", Scopes: []string{"development", "production"}},
+	}
+	dependencies := lockfile.CollectDependencies(".")
+	for _, dependency := range dependencies {
+		sort.Strings(dependency.Scopes)
+	}
+	if !reflect.DeepEqual(expected, dependencies) {
+		t.Errorf("Expected: %v, got: %v", expected, dependencies)
+	}
+}
+
+func TestUnsupportedLockfileVersion(t *testing.T) {
+	log.SetDefaultLogger()
+	for _, content := range []string{"lockfileVersion: '10.0'\n", "importers: {}\n"} {
+		if _, err := ParseLockfile([]byte(content)); err == nil {
+			t.Errorf("Expected an error for the lockfile: %s", content)
+		}
+	}
+}
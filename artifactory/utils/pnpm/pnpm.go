@@ -0,0 +1,35 @@
+package pnpm
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+// Returns the root directory of the project or of the workspace which contains the working directory, where pnpm-lock.yaml is.
+func GetWorkspaceRoot() (string, error) {
+	root, exists, err := fileutils.FindUpstream(LockfileName, fileutils.File)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", errorutils.CheckError(errors.New("could not find " + LockfileName + " in the working directory or in its parent directories"))
+	}
+	return root, nil
+}
+
+// Returns true if package.json in packageDir is marked as private, which means that it shouldn't be published.
+func IsPrivatePackage(packageDir string) (bool, error) {
+	data, err := ioutil.ReadFile(filepath.Join(packageDir, "package.json"))
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	var packageJson struct {
+		Private bool `json:"private,omitempty"`
+	}
+	return packageJson.Private, errorutils.CheckError(json.Unmarshal(data, &packageJson))
+}
@@ -30,6 +30,8 @@ const (
 	Maven
 	Gradle
 	Dotnet
+	Yarn
+	Pnpm
 )
 
 var ProjectTypes = []string{
@@ -40,6 +42,8 @@ var ProjectTypes = []string{
 	"maven",
 	"gradle",
 	"dotnet",
+	"yarn",
+	"pnpm",
 }
 
 func (projectType ProjectType) String() string {
@@ -96,11 +100,21 @@ func GetRepoConfigByPrefix(configFilePath, prefix string, vConfig *viper.Viper)
 		return nil, errorutils.CheckError(fmt.Errorf("%s information is missing within %s", prefix, configFilePath))
 	}
 	log.Debug(fmt.Sprintf("Found %s in the config file %s", prefix, configFilePath))
+	projectDefaults, err := config.GetProjectDefaults()
+	if err != nil {
+		return nil, err
+	}
 	repo := vConfig.GetString(prefix + "." + ProjectConfigRepo)
+	if repo == "" {
+		repo = getProjectDefaultRepo(projectDefaults, prefix)
+	}
 	if repo == "" {
 		return nil, fmt.Errorf("Missing repository for %s within %s", prefix, configFilePath)
 	}
 	serverId := vConfig.GetString(prefix + "." + ProjectConfigServerId)
+	if serverId == "" {
+		serverId = projectDefaults.ServerId
+	}
 	if serverId == "" {
 		return nil, fmt.Errorf("Missing server ID for %s within %s", prefix, configFilePath)
 	}
@@ -111,6 +125,13 @@ func GetRepoConfigByPrefix(configFilePath, prefix string, vConfig *viper.Viper)
 	return &RepositoryConfig{targetRepo: repo, rtDetails: rtDetails}, nil
 }
 
+func getProjectDefaultRepo(projectDefaults *config.ProjectDefaults, prefix string) string {
+	if prefix == ProjectConfigDeployerPrefix {
+		return projectDefaults.Repos.Deploy
+	}
+	return projectDefaults.Repos.Resolve
+}
+
 func (repo *RepositoryConfig) IsRtDetailsEmpty() bool {
 	if repo.rtDetails != nil && reflect.DeepEqual(config.ArtifactoryDetails{}, repo.rtDetails) {
 		return false
@@ -17,4 +17,6 @@ type UploadConfiguration struct {
 	Symlink               bool
 	ExplodeArchive        bool
 	Retries               int
+	// Record the uploaded files in a transfer journal, and skip the files recorded by previous runs.
+	Resume bool
 }
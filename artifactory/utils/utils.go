@@ -17,6 +17,7 @@ import (
 	"github.com/jfrog/jfrog-client-go/distribution"
 	"github.com/jfrog/jfrog-client-go/httpclient"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
 const repoDetailsUrl = "api/repositories/"
@@ -179,16 +180,32 @@ func CheckIfRepoExists(repository string, artDetails auth.ServiceDetails) error
 	return nil
 }
 
-// Get build name and number from env, only if both missing
+// Get build name and number from env, only if both missing.
+// If only the build name is missing, it is taken from the project defaults.
 func GetBuildNameAndNumber(buildName, buildNumber string) (string, string) {
-	if buildName != "" || buildNumber != "" {
-		return buildName, buildNumber
+	if buildName == "" && buildNumber == "" {
+		buildName, buildNumber = os.Getenv(cliutils.BuildName), os.Getenv(cliutils.BuildNumber)
 	}
-	return os.Getenv(cliutils.BuildName), os.Getenv(cliutils.BuildNumber)
+	if buildName == "" && buildNumber != "" {
+		buildName = getProjectBuildName()
+	}
+	return buildName, buildNumber
 }
 
 func GetBuildName(buildName string) string {
-	return getOrDefaultEnv(buildName, cliutils.BuildName)
+	if buildName = getOrDefaultEnv(buildName, cliutils.BuildName); buildName != "" {
+		return buildName
+	}
+	return getProjectBuildName()
+}
+
+func getProjectBuildName() string {
+	projectDefaults, err := config.GetProjectDefaults()
+	if err != nil {
+		log.Warn("Failed reading the project defaults: " + err.Error())
+		return ""
+	}
+	return projectDefaults.BuildName
 }
 
 func GetBuildUrl(buildUrl string) string {
@@ -0,0 +1,221 @@
+package yarn
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli/artifactory/utils/npm"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+)
+
+const LockfileName = "yarn.lock"
+
+// A package resolved in yarn.lock.
+type LockEntry struct {
+	Name         string
+	Version      string
+	Dependencies map[string]string
+	// True for packages which are not downloaded from the registry, such as workspaces and links.
+	Local bool
+}
+
+// The content of yarn.lock, created by Yarn v1 or by Yarn Berry (v2 and above).
+type Lockfile struct {
+	// Maps each descriptor in the lockfile (name@range) to the package it is resolved to.
+	entries map[string]*LockEntry
+}
+
+func ReadLockfile(projectDir string) (*Lockfile, error) {
+	log.Debug("Reading dependencies from", filepath.Join(projectDir, LockfileName))
+	data, err := ioutil.ReadFile(filepath.Join(projectDir, LockfileName))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return ParseLockfile(data)
+}
+
+func ParseLockfile(data []byte) (*Lockfile, error) {
+	// Yarn Berry lockfiles are YAML documents with a __metadata entry.
+	if bytes.Contains(data, []byte("\n__metadata:")) || bytes.HasPrefix(data, []byte("__metadata:")) {
+		return parseBerryLockfile(data)
+	}
+	return parseV1Lockfile(data)
+}
+
+// Returns the package which the dependency name@versionRange is resolved to, or nil if it isn't in the lockfile.
+func (lockfile *Lockfile) Resolve(name, versionRange string) *LockEntry {
+	if entry, ok := lockfile.entries[name+"@"+versionRange]; ok {
+		return entry
+	}
+	// Yarn Berry adds the protocol to the ranges of npm dependencies.
+	return lockfile.entries[name+"@npm:"+versionRange]
+}
+
+// Returns the packages resolved for the dependencies of package.json and for their transitive dependencies.
+// Packages required by "dependencies" or "optionalDependencies" have the production scope,
+// and packages required by "devDependencies" have the development scope.
+func (lockfile *Lockfile) CollectDependencies(packageJson *PackageJson) map[string]*npm.ResolvedDependency {
+	dependencies := make(map[string]*npm.ResolvedDependency)
+	lockfile.collectDependencies(packageJson.Dependencies, "production", dependencies)
+	lockfile.collectDependencies(packageJson.OptionalDependencies, "production", dependencies)
+	lockfile.collectDependencies(packageJson.DevDependencies, "development", dependencies)
+	return dependencies
+}
+
+func (lockfile *Lockfile) collectDependencies(direct map[string]string, scope string, dependencies map[string]*npm.ResolvedDependency) {
+	var queue []map[string]string
+	queue = append(queue, direct)
+	visited := make(map[*LockEntry]bool)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for name, versionRange := range current {
+			entry := lockfile.Resolve(name, versionRange)
+			if entry == nil {
+				log.Debug("The dependency", name+"@"+versionRange, "could not be found in", LockfileName)
+				continue
+			}
+			if visited[entry] {
+				continue
+			}
+			visited[entry] = true
+			queue = append(queue, entry.Dependencies)
+			if entry.Local {
+				continue
+			}
+			key := entry.Name + "-" + entry.Version
+			if dependencies[key] == nil {
+				dependencies[key] = &npm.ResolvedDependency{Name: entry.Name, Version: entry.Version}
+			}
+			dependencies[key].AddScope(scope)
+		}
+	}
+}
+
+// Parses a Yarn v1 lockfile, which looks like this:
+//
+//	"@babel/code-frame@^7.0.0", "@babel/code-frame@^7.8.3":
+//	  version "7.8.3"
+//	  resolved "https://registry.yarnpkg.com/@babel/code-frame/-/code-frame-7.8.3.tgz#..."
+//	  integrity sha512-...
+//	  dependencies:
+//	    "@babel/highlight" "^7.8.3"
+func parseV1Lockfile(data []byte) (*Lockfile, error) {
+	lockfile := &Lockfile{entries: make(map[string]*LockEntry)}
+	var entry *LockEntry
+	var inDependencies bool
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		switch {
+		case indent == 0:
+			// A new package, with all the descriptors resolved to it.
+			entry = &LockEntry{Dependencies: make(map[string]string)}
+			inDependencies = false
+			for _, descriptor := range strings.Split(strings.TrimSuffix(trimmed, ":"), ",") {
+				descriptor = unquote(strings.TrimSpace(descriptor))
+				entry.Name = descriptorName(descriptor)
+				lockfile.entries[descriptor] = entry
+			}
+		case entry == nil:
+			continue
+		case indent == 2:
+			key, value := splitV1Field(trimmed)
+			inDependencies = key == "dependencies:" || key == "optionalDependencies:"
+			if key == "version" {
+				entry.Version = value
+			}
+		case indent > 2 && inDependencies:
+			name, versionRange := splitV1Field(trimmed)
+			entry.Dependencies[name] = versionRange
+		}
+	}
+	return lockfile, errorutils.CheckError(scanner.Err())
+}
+
+type berryLockEntry struct {
+	Version              string            `yaml:"version"`
+	Resolution           string            `yaml:"resolution"`
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+	LinkType             string            `yaml:"linkType"`
+}
+
+// Parses a Yarn Berry lockfile, which looks like this:
+//
+//	"@babel/code-frame@npm:^7.0.0, @babel/code-frame@npm:^7.8.3":
+//	  version: 7.8.3
+//	  resolution: "@babel/code-frame@npm:7.8.3"
+//	  dependencies:
+//	    "@babel/highlight": ^7.8.3
+//	  languageName: node
+//	  linkType: hard
+func parseBerryLockfile(data []byte) (*Lockfile, error) {
+	var berryEntries map[string]*berryLockEntry
+	if err := yaml.Unmarshal(data, &berryEntries); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	lockfile := &Lockfile{entries: make(map[string]*LockEntry)}
+	for descriptors, berryEntry := range berryEntries {
+		if descriptors == "__metadata" || berryEntry == nil {
+			continue
+		}
+		entry := &LockEntry{
+			Name:         descriptorName(berryEntry.Resolution),
+			Version:      berryEntry.Version,
+			Dependencies: make(map[string]string),
+			Local:        berryEntry.LinkType == "soft",
+		}
+		for name, versionRange := range berryEntry.Dependencies {
+			entry.Dependencies[name] = versionRange
+		}
+		for name, versionRange := range berryEntry.OptionalDependencies {
+			entry.Dependencies[name] = versionRange
+		}
+		for _, descriptor := range strings.Split(descriptors, ",") {
+			lockfile.entries[strings.TrimSpace(descriptor)] = entry
+		}
+	}
+	return lockfile, nil
+}
+
+// Returns the package name of a descriptor or a resolution, such as "@babel/code-frame" for "@babel/code-frame@npm:^7.0.0".
+func descriptorName(descriptor string) string {
+	start := 0
+	if strings.HasPrefix(descriptor, "@") {
+		// Scoped package.
+		start = 1
+	}
+	if i := strings.Index(descriptor[start:], "@"); i >= 0 {
+		return descriptor[:start+i]
+	}
+	return descriptor
+}
+
+// Splits a field of a Yarn v1 lockfile, such as `version "7.8.3"` or `"@babel/highlight" "^7.8.3"`.
+func splitV1Field(field string) (key, value string) {
+	var i int
+	if strings.HasPrefix(field, `"`) {
+		i = strings.Index(field[1:], `"`) + 2
+	} else {
+		i = strings.Index(field, " ")
+	}
+	if i <= 0 || i >= len(field) {
+		return unquote(field), ""
+	}
+	return unquote(field[:i]), unquote(strings.TrimSpace(field[i:]))
+}
+
+func unquote(value string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(value, `"`), `"`)
+}
@@ -0,0 +1,141 @@
+package yarn
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/artifactory/utils/npm"
+	"github.com/jfrog/jfrog-cli/utils/log"
+)
+
+const v1Lockfile = `# THIS IS AN AUTOGENERATED FILE. DO NOT EDIT THIS FILE DIRECTLY.
+# yarn lockfile v1
+
+
+"@babel/code-frame@^7.0.0", "@babel/code-frame@^7.8.3":
+  version "7.8.3"
+  resolved "https://registry.yarnpkg.com/@babel/code-frame/-/code-frame-7.8.3.tgz"
+  dependencies:
+    "@babel/highlight" "^7.8.3"
+
+"@babel/highlight@^7.8.3":
+  version "7.9.0"
+  resolved "https://registry.yarnpkg.com/@babel/highlight/-/highlight-7.9.0.tgz"
+  dependencies:
+    js-tokens "^4.0.0"
+
+js-tokens@^4.0.0:
+  version "4.0.0"
+  resolved "https://registry.yarnpkg.com/js-tokens/-/js-tokens-4.0.0.tgz"
+
+lodash@4.17.15:
+  version "4.17.15"
+  resolved "https://registry.yarnpkg.com/lodash/-/lodash-4.17.15.tgz"
+  optionalDependencies:
+    js-tokens "^4.0.0"
+`
+
+const berryLockfile = `# This file is generated by running "yarn install" inside your project.
+
+__metadata:
+  version: 4
+  cacheKey: 6
+
+"@babel/code-frame@npm:^7.0.0, @babel/code-frame@npm:^7.8.3":
+  version: 7.8.3
+  resolution: "@babel/code-frame@npm:7.8.3"
+  dependencies:
+    "@babel/highlight": ^7.8.3
+  languageName: node
+  linkType: hard
+
+"@babel/highlight@npm:^7.8.3":
+  version: 7.9.0
+  resolution: "@babel/highlight@npm:7.9.0"
+  dependencies:
+    js-tokens: ^4.0.0
+  languageName: node
+  linkType: hard
+
+"js-tokens@npm:^4.0.0":
+  version: 4.0.0
+  resolution: "js-tokens@npm:4.0.0"
+  languageName: node
+  linkType: hard
+
+"lodash@npm:4.17.15":
+  version: 4.17.15
+  resolution: "lodash@npm:4.17.15"
+  optionalDependencies:
+    js-tokens: ^4.0.0
+  languageName: node
+  linkType: hard
+
+"project@workspace:.":
+  version: 0.0.0-use.local
+  resolution: "project@workspace:."
+  dependencies:
+    "@babel/code-frame": ^7.0.0
+  languageName: unknown
+  linkType: soft
+`
+
+func TestCollectDependencies(t *testing.T) {
+	log.SetDefaultLogger()
+	packageJson := &PackageJson{
+		Dependencies:    map[string]string{"@babel/code-frame": "^7.0.0", "missing": "^1.0.0"},
+		DevDependencies: map[string]string{"lodash": "4.17.15"},
+	}
+	expected := map[string]*npm.ResolvedDependency{
+		"@babel/code-frame-7.8.3": {Name: "@babel/code-frame", Version: "7.8.3", Scopes: []string{"production"}},
+		"@babel/highlight-7.9.0":  {Name: "@babel/highlight", Version: "7.9.0", Scopes: []string{"production"}},
+		"js-tokens-4.0.0":         {Name: "js-tokens", Version: "4.0.0", Scopes: []string{"development", "production"}},
+		"lodash-4.17.15":          {Name: "lodash", Version: "4.17.15", Scopes: []string{"development"}},
+	}
+	tests := []struct {
+		name     string
+		lockfile string
+	}{
+		{"v1", v1Lockfile},
+		{"berry", berryLockfile},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lockfile, err := ParseLockfile([]byte(test.lockfile))
+			if err != nil {
+				t.Fatal(err)
+			}
+			dependencies := lockfile.CollectDependencies(packageJson)
+			for _, dependency := range dependencies {
+				sort.Strings(dependency.Scopes)
+			}
+			if !reflect.DeepEqual(expected, dependencies) {
+				t.Errorf("Expected: %v, got: %v", expected, dependencies)
+			}
+		})
+	}
+}
+
+func TestCreateRegistryEnv(t *testing.T) {
+	npmAuth := "_auth = YWRtaW46cGFzc3dvcmQ=\nalways-auth = true\nemail = admin@example.com"
+	registry := "http://localhost:8081/artifactory/api/npm/npm-remote"
+	expectedV1 := map[string]string{
+		"npm_config_registry":    registry,
+		"npm_config__auth":       "YWRtaW46cGFzc3dvcmQ=",
+		"npm_config_always_auth": "true",
+		"npm_config_email":       "admin@example.com",
+	}
+	if env := CreateRegistryEnv(registry, npmAuth, false); !reflect.DeepEqual(expectedV1, env) {
+		t.Errorf("Expected: %v, got: %v", expectedV1, env)
+	}
+	expectedBerry := map[string]string{
+		"YARN_NPM_REGISTRY_SERVER":   registry,
+		"YARN_NPM_AUTH_IDENT":        "YWRtaW46cGFzc3dvcmQ=",
+		"YARN_NPM_ALWAYS_AUTH":       "true",
+		"YARN_UNSAFE_HTTP_WHITELIST": "localhost",
+	}
+	if env := CreateRegistryEnv(registry, npmAuth, true); !reflect.DeepEqual(expectedBerry, env) {
+		t.Errorf("Expected: %v, got: %v", expectedBerry, env)
+	}
+}
@@ -0,0 +1,61 @@
+package yarn
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli/artifactory/utils/npm"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/version"
+)
+
+// The dependencies declared in package.json.
+type PackageJson struct {
+	Dependencies         map[string]string `json:"dependencies,omitempty"`
+	DevDependencies      map[string]string `json:"devDependencies,omitempty"`
+	OptionalDependencies map[string]string `json:"optionalDependencies,omitempty"`
+}
+
+func ReadPackageJson(projectDir string) (*PackageJson, error) {
+	data, err := ioutil.ReadFile(filepath.Join(projectDir, "package.json"))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	packageJson := new(PackageJson)
+	return packageJson, errorutils.CheckError(json.Unmarshal(data, packageJson))
+}
+
+func Version(executablePath string) (*version.Version, error) {
+	output, err := gofrogcmd.RunCmdOutput(&npm.PackageManagerConfig{Executable: executablePath, Command: []string{"--version"}})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return version.NewVersion(strings.TrimSpace(output)), nil
+}
+
+// Yarn Berry is Yarn v2 and above. It is configured differently than Yarn v1, and its lockfile has a different format.
+func IsBerry(yarnVersion *version.Version) bool {
+	return yarnVersion.AtLeast("2.0.0")
+}
+
+// Returns the environment variables which make yarn resolve the dependencies from the registry, using the authentication of npmAuth.
+// npmAuth is in the .npmrc format, as returned by Artifactory.
+func CreateRegistryEnv(registry, npmAuth string, berry bool) map[string]string {
+	if !berry {
+		// Yarn v1 reads the npm configuration from the npm_config_ environment variables.
+		return npm.CreateRegistryEnv(registry, npmAuth)
+	}
+	env := map[string]string{
+		"YARN_NPM_REGISTRY_SERVER": registry,
+		"YARN_NPM_AUTH_IDENT":      npm.ParseNpmrc(npmAuth)["_auth"],
+		"YARN_NPM_ALWAYS_AUTH":     "true",
+	}
+	if strings.HasPrefix(registry, "http://") {
+		host := strings.SplitN(strings.TrimPrefix(registry, "http://"), "/", 2)[0]
+		env["YARN_UNSAFE_HTTP_WHITELIST"] = strings.Split(host, ":")[0]
+	}
+	return env
+}
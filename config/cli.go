@@ -0,0 +1,73 @@
+package config
+
+import (
+	"os"
+
+	"github.com/codegangsta/cli"
+	"github.com/jfrog/jfrog-cli/docs/common"
+	"github.com/jfrog/jfrog-cli/docs/config/rotatekey"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	utilsconfig "github.com/jfrog/jfrog-cli/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func GetCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:         "rotate-key",
+			Flags:        getRotateKeyFlags(),
+			Usage:        rotatekey.Description,
+			HelpName:     common.CreateUsage("config rotate-key", rotatekey.Description, rotatekey.Usage),
+			ArgsUsage:    common.CreateEnvVars(rotatekey.EnvVar),
+			BashComplete: common.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return rotateKeyCmd(c)
+			},
+		},
+	}
+}
+
+func getRotateKeyFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "key-file",
+			Usage: "[Optional] Path to a file to write the new master key to, when the master key is set by the $" + cliutils.MasterKey + " environment variable.` `",
+		},
+		cli.BoolFlag{
+			Name:  "print-key",
+			Usage: "[Default: false] Set to true to print the new master key, when the master key is set by the $" + cliutils.MasterKey + " environment variable.` `",
+		},
+	}
+}
+
+func rotateKeyCmd(c *cli.Context) error {
+	if c.NArg() > 0 {
+		return cliutils.PrintHelpAndReturnError("No arguments should be sent.", c)
+	}
+	keyFromEnv := os.Getenv(cliutils.MasterKey) != ""
+	keyFile := c.String("key-file")
+	printKey := c.Bool("print-key")
+	if !keyFromEnv && (keyFile != "" || printKey) {
+		return cliutils.PrintHelpAndReturnError("The --key-file and --print-key options apply only when the master key is set by the $"+cliutils.MasterKey+" environment variable.", c)
+	}
+	// Validate before rotating, since the config can't be decrypted without the new key.
+	if keyFromEnv && (keyFile == "") == !printKey {
+		return cliutils.PrintHelpAndReturnError("The master key is set by the $"+cliutils.MasterKey+" environment variable. "+
+			"Use either the --key-file option to write the new key to a file, or the --print-key option to print it.", c)
+	}
+	newKey, err := utilsconfig.RotateMasterKey(keyFile)
+	if err != nil {
+		return err
+	}
+	if !keyFromEnv {
+		log.Info("The config file was re-encrypted with a new master key.")
+		return nil
+	}
+	if keyFile != "" {
+		log.Info("The config file was re-encrypted with a new master key, which was written to " + keyFile + ". Set the $" + cliutils.MasterKey + " environment variable to the new key.")
+		return nil
+	}
+	log.Info("The config file was re-encrypted with a new master key. Set the $" + cliutils.MasterKey + " environment variable to the new key:")
+	log.Output(newKey)
+	return nil
+}
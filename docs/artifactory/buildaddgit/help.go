@@ -11,4 +11,6 @@ const Arguments string = `	build name
 		Build number.
 
 	path to .git
-		Path to a directory containing the .git directory. If not specified, the .git directory is assumed to be in the current directory or in one of the parent directories.`
+		Path to a directory containing the .git directory. If not specified, the .git directory is assumed to be in the current directory or in one of the parent directories.
+		The VCS details of the repository's initialized submodules are collected as well, and saved with the build.
+		The published build-info includes the VCS details of this repository only, since it supports a single VCS repository.`
@@ -0,0 +1,14 @@
+package builddiff
+
+const Description = "Compare two builds and show the differences between their build-info."
+
+var Usage = []string{"jfrog rt bdiff [command options] <build name> <base build number> <target build number>"}
+
+const Arguments string = `	build name
+		Build name.
+
+	base build number
+		Build number of the published build to compare from.
+
+	target build number
+		Build number of the build to compare to. If the --local option is set, the build-info of this build is read from the locally collected build-info, which was not published yet.`
@@ -0,0 +1,8 @@
+package buildimport
+
+const Description = "Publish a build-info file, which was exported using the build-publish command with the --output-file option."
+
+var Usage = []string{"jfrog rt bi [command options] <build-info file path>"}
+
+const Arguments string = `	build-info file path
+		Path to the build-info file.`
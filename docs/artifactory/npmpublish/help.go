@@ -1,5 +1,6 @@
 package npmpublish
 
-const Description = "Packs and deploys the npm package to the designated npm repository."
+const Description = "Packs and deploys the npm package to the designated npm repository. With the --workspaces option, all the workspace packages which are not private are published."
 
-var Usage = []string{`jfrog rt npmp [command options]`}
+var Usage = []string{`jfrog rt npmp [command options]`,
+	`jfrog rt npmp --workspaces [command options]`}
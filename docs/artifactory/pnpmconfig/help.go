@@ -0,0 +1,5 @@
+package pnpmconfig
+
+const Description = "Generate pnpm configuration."
+
+var Usage = []string{"jfrog rt pnpm-config [command options]"}
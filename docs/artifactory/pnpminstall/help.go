@@ -0,0 +1,8 @@
+package pnpminstall
+
+const Description = "Run pnpm install. In a workspace, a build-info module is created for each workspace package."
+
+var Usage = []string{`jfrog rt pnpmi [pnpm install args] [command options]`}
+
+const Arguments string = `	pnpm install args
+		The pnpm install args to run pnpm install. For example, --frozen-lockfile.`
@@ -0,0 +1,11 @@
+package pnpmpublish
+
+const Description = "Packs and deploys the pnpm package to the designated npm repository. With the --recursive option, all the workspace packages which are not private are published."
+
+var Usage = []string{`jfrog rt pnpmp [path] [pnpm pack args] [command options]`}
+
+const Arguments string = `	path
+		[Optional] The directory of the package to publish. If not set, the package in the current directory is published.
+
+	pnpm pack args
+		The pnpm pack args to run pnpm pack.`
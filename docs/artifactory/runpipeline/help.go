@@ -0,0 +1,31 @@
+package runpipeline
+
+const Description = "Run a list of jfrog rt commands, which share one build name, build number and server ID, as defined in a pipeline file."
+
+var Usage = []string{"jfrog rt run-pipeline <pipeline file>"}
+
+const Arguments string = `	pipeline file
+		Path to a YAML pipeline file. The file has the following structure:
+
+		version: 1
+		pipeline:
+		  build-name: my-build
+		  build-number: 1
+		  server-id: my-server
+		  steps:
+		    - name: Upload the packages
+		      command: upload
+		      args: ["out/*.zip", "my-repo/"]
+		      flags:
+		        flat: true
+		    - command: build-add-git
+		      continue-on-error: true
+		    - command: build-publish
+		    - name: Clean the build
+		      command: build-clean
+		      condition: on-failure
+
+		The build-name and build-number values are shared with the steps through the JFROG_CLI_BUILD_NAME and JFROG_CLI_BUILD_NUMBER environment variables.
+		The server-id value is passed to every step command which supports the --server-id option, unless the step sets it in its flags.
+		A step runs only if all previous steps succeeded, unless its condition is set to 'on-failure' or 'always'.
+		A failing step fails the pipeline, unless it is marked with continue-on-error.`
@@ -0,0 +1,11 @@
+package specvalidate
+
+const Description = "Validate a File Spec offline, and print the AQL query each of its file groups generates."
+
+var Usage = []string{"jfrog rt sv [command options] <File Spec path>"}
+
+const Arguments string = `	File Spec path
+		Path to a File Spec, in JSON or YAML format.
+		In addition to the validations done by the commands using the File Spec, the command reports unknown keys, which are otherwise ignored,
+		and invalid boolean values, such as in 'flat' and 'recursive'. Deprecated keys are reported as warnings.
+		The printed AQL queries are the queries run by the commands which search Artifactory, such as download, search, move, copy and delete.`
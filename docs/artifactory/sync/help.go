@@ -0,0 +1,17 @@
+package sync
+
+const Description = "Sync a local directory and a path in Artifactory, by comparing the checksums of their files."
+
+var Usage = []string{"jfrog rt sync [command options] <local path> <repository path>"}
+
+const Arguments string = `	local path
+		Path to the local directory to sync.
+
+	repository path
+		The path in Artifactory to sync, in the following format: <repository name>/<repository path>.
+
+	The files are compared by their SHA-1 checksums, and the changes are applied according to the --direction option:
+		both: Files which exist only locally are uploaded, and files which exist only in Artifactory are downloaded. Files which differ are reported as conflicts and skipped.
+		upload: New and changed local files are uploaded. With --delete, files which exist only in Artifactory are deleted from Artifactory.
+		download: New and changed files in Artifactory are downloaded. With --delete, files which exist only locally are deleted.
+	Use --plan to print the changes as JSON, without applying them.`
@@ -0,0 +1,7 @@
+package tokenlist
+
+const Description = "Lists the access tokens created by the user. Administrators see all the access tokens."
+
+var Usage = []string{"jfrog rt tl [command options]"}
+
+const Arguments string = ""
@@ -0,0 +1,9 @@
+package tokenrevoke
+
+const Description = "Revokes an access token by its ID, or the token of a token profile. The revoked token profile is removed from the configuration."
+
+var Usage = []string{"jfrog rt tr [command options] <token id>",
+	"jfrog rt tr [command options] --profile=<token profile>"}
+
+const Arguments string = `	token id
+		The ID of the access token to revoke, as listed by the 'jfrog rt token-list' command.`
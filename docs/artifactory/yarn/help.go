@@ -0,0 +1,8 @@
+package yarn
+
+const Description = "Run yarn, resolving the dependencies from Artifactory."
+
+var Usage = []string{`jfrog rt yarn [yarn command] [command options]`}
+
+const Arguments string = `	yarn command
+		The yarn command and its arguments to run. For example, install --frozen-lockfile.`
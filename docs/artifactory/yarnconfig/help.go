@@ -0,0 +1,5 @@
+package yarnconfig
+
+const Description = "Generate yarn configuration."
+
+var Usage = []string{"jfrog rt yarn-config [command options]"}
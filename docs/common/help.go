@@ -7,6 +7,13 @@ const GlobalEnvVars string = `	JFROG_CLI_LOG_LEVEL
 		If set to ERROR, JFrog CLI logs error messages only.
 		It is useful when you wish to read or parse the JFrog CLI output and do not want any other information logged.
 
+	JFROG_CLI_LOG_FORMAT
+		[Default: TEXT]
+		This variable determines the format of the JFrog CLI logs.
+		Possible values are: TEXT and JSON.
+		If set to JSON, every log message is written as a JSON object in a separate line, and structured events
+		are logged for the commands runs, the files transfers and the retries, instead of displaying a progress bar.
+
 	JFROG_CLI_OFFER_CONFIG
 		[Default: true]
 		If true, JFrog CLI prompts for product server details and saves them in its config file.
@@ -34,6 +41,25 @@ const GlobalEnvVars string = `	JFROG_CLI_LOG_LEVEL
 		[Default: *password*;*psw*;*secret*;*key*;*token*] 
 		List of case insensitive patterns in the form of "value1;value2;...". Environment variables match those patterns will be excluded. This environment variable is used by the "jfrog rt build-publish" command, in case the --env-exclude command option is not sent.
 
+	JFROG_CLI_TOKEN_PROFILE
+		The token profile of the configured Artifactory server to authenticate with, unless the --token-profile command option is sent.
+		Token profiles are created by the "jfrog rt access-token-create" command with the --profile option.
+
+	JFROG_CLI_URL
+	JFROG_CLI_DIST_URL
+	JFROG_CLI_USER
+	JFROG_CLI_PASSWORD
+	JFROG_CLI_ACCESS_TOKEN
+	JFROG_CLI_CLIENT_CERT_PATH
+	JFROG_CLI_CLIENT_CERT_KEY_PATH
+	JFROG_CLI_INSECURE_TLS
+		The Artifactory server details, used instead of the servers configured using the "jfrog rt config" command. These details are never saved to the config file.
+		The server is used by commands which don't receive the --server-id option, and takes precedence over the server ID pinned by the .jfrog/config.yaml file of the project and over the default server.
+		Connection details sent as command options take precedence over these environment variables.
+
+	JFROG_CLI_SERVER_ID
+		The server ID of the server set by the above environment variables. Commands receiving this server ID, either by the --server-id option or by a build tool configuration, use the server set by the environment variables instead of the configured server with the same ID.
+
 	CI
 		[Default: false]
 		If true, disables interactive prompts and progress bar.
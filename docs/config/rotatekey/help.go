@@ -0,0 +1,14 @@
+package rotatekey
+
+const Description = "Generate a new master key, and re-encrypt the secrets of the config file with it."
+
+var Usage = []string{"jfrog config rotate-key [command options]"}
+
+const EnvVar string = `	JFROG_CLI_MASTER_KEY
+		The master key for encrypting the config file, instead of the master key in the security configuration file.
+		When this variable is set, the new master key is written to the file set by the --key-file option, or printed with the --print-key option,
+		and the variable should be updated with it.
+
+	JFROG_CLI_MASTER_KEY_FILE
+		Path to a file containing the master key for encrypting the config file, instead of the security configuration file.
+		The new master key is written to this file when this variable is set.`
@@ -5,6 +5,7 @@ import (
 	"github.com/jfrog/jfrog-cli/artifactory"
 	"github.com/jfrog/jfrog-cli/bintray"
 	"github.com/jfrog/jfrog-cli/completion"
+	"github.com/jfrog/jfrog-cli/config"
 	"github.com/jfrog/jfrog-cli/docs/common"
 	"github.com/jfrog/jfrog-cli/missioncontrol"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
@@ -112,6 +113,11 @@ func getCommands() []cli.Command {
 			Usage:       "Xray commands",
 			Subcommands: xray.GetCommands(),
 		},
+		{
+			Name:        cliutils.CmdConfig,
+			Usage:       "Config commands",
+			Subcommands: config.GetCommands(),
+		},
 		{
 			Name:        cliutils.CmdCompletion,
 			Usage:       "Generate autocomplete scripts",
@@ -12,6 +12,7 @@ const (
 	CmdMissionControl = "mc"
 	CmdXray           = "xr"
 	CmdCompletion     = "completion"
+	CmdConfig         = "config"
 
 	// Download
 	DownloadMinSplitKb    = 5120
@@ -33,10 +34,16 @@ const (
 	JfrogBackupDirName       = "backup"
 	JfrogLogsDirName         = "logs"
 	JfrogLockDirName         = "lock"
+	JfrogCacheDirName        = "cache"
+
+	// Project Dir
+	JfrogProjectDirName      = ".jfrog"
+	JfrogProjectDefaultsFile = "config.yaml"
 
 	// Env
 	ReportUsage     = "JFROG_CLI_REPORT_USAGE"
 	LogLevel        = "JFROG_CLI_LOG_LEVEL"
+	LogFormat       = "JFROG_CLI_LOG_FORMAT"
 	OfferConfig     = "JFROG_CLI_OFFER_CONFIG"
 	HomeDir         = "JFROG_CLI_HOME_DIR"
 	ErrorHandling   = "JFROG_CLI_ERROR_HANDLING"
@@ -48,6 +55,24 @@ const (
 	BuildUrl        = "JFROG_CLI_BUILD_URL"
 	EnvExclude      = "JFROG_CLI_ENV_EXCLUDE"
 	UserAgent       = "JFROG_CLI_USER_AGENT"
+	// The maximum size in MB of the download cache.
+	DownloadCacheMaxSize = "JFROG_CLI_DOWNLOAD_CACHE_MAX_SIZE_MB"
+	// The token profile to authenticate with, unless the --token-profile option is sent.
+	TokenProfile = "JFROG_CLI_TOKEN_PROFILE"
+	// The master key for encrypting the config, instead of the master key in the security configuration file.
+	MasterKey = "JFROG_CLI_MASTER_KEY"
+	// Path to a file containing the master key for encrypting the config.
+	MasterKeyFile = "JFROG_CLI_MASTER_KEY_FILE"
+	// Artifactory server details, used instead of the servers configured using the config command.
+	EnvServerId          = "JFROG_CLI_SERVER_ID"
+	EnvUrl               = "JFROG_CLI_URL"
+	EnvDistributionUrl   = "JFROG_CLI_DIST_URL"
+	EnvUser              = "JFROG_CLI_USER"
+	EnvPassword          = "JFROG_CLI_PASSWORD"
+	EnvAccessToken       = "JFROG_CLI_ACCESS_TOKEN"
+	EnvClientCertPath    = "JFROG_CLI_CLIENT_CERT_PATH"
+	EnvClientCertKeyPath = "JFROG_CLI_CLIENT_CERT_KEY_PATH"
+	EnvInsecureTls       = "JFROG_CLI_INSECURE_TLS"
 	// Deprecated:
 	JfrogHomeEnv = "JFROG_CLI_HOME"
 )
@@ -3,7 +3,6 @@ package cliutils
 import (
 	"bytes"
 	"fmt"
-	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/jfrog/jfrog-client-go/utils/io/content"
 	"github.com/jfrog/jfrog-client-go/utils/prompt"
 	"os"
@@ -123,7 +122,17 @@ func traceExit(exitCode ExitCode, err error) {
 
 type detailedSummaryRecord struct {
 	Source string `json:"source"`
-	Target string `json:"target"`
+	Target string `json:"target,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// A record of the results reader used for the detailed summary, describing the operation done on a single file.
+// The keys are shared with the FileInfo records of jfrog-client-go, which describe the uploaded and downloaded files.
+type ResultRecord struct {
+	ArtifactoryPath       string `json:"artifactoryPath,omitempty"`
+	LocalPath             string `json:"localPath,omitempty"`
+	TargetArtifactoryPath string `json:"targetArtifactoryPath,omitempty"`
+	Error                 string `json:"error,omitempty"`
 }
 
 // Print summary report.
@@ -141,7 +150,12 @@ func summaryPrintError(summaryError, originalError error) error {
 
 // If a resultReader is provided, we will iterate over the result and print a detailed summary including the affected files.
 func PrintSummaryReport(success, failed int, resultReader *content.ContentReader, rtUrl string, originalErr error) error {
-	basicSummary, mErr := CreateSummaryReportString(success, failed, originalErr)
+	return PrintSummary(CreateSummaryReport(success, failed, originalErr), resultReader, rtUrl, originalErr)
+}
+
+// Prints the summary of the command. If a reader of the results is provided, the result of each file is printed as well.
+func PrintSummary(summaryReport *summary.Summary, resultReader *content.ContentReader, rtUrl string, originalErr error) error {
+	basicSummary, mErr := marshalSummaryReport(summaryReport)
 	if mErr != nil {
 		return summaryPrintError(mErr, originalErr)
 	}
@@ -161,11 +175,14 @@ func PrintSummaryReport(success, failed int, resultReader *content.ContentReader
 	basicSummary = strings.TrimSuffix(basicSummary, "\n}") + ","
 	log.Output(basicSummary)
 	defer log.Output("}")
-	var file serviceutils.FileInfo
-	for e := resultReader.NextRecord(&file); e == nil; e = resultReader.NextRecord(&file) {
+	for file := new(ResultRecord); resultReader.NextRecord(file) == nil; file = new(ResultRecord) {
 		record := detailedSummaryRecord{
 			Source: rtUrl + file.ArtifactoryPath,
 			Target: file.LocalPath,
+			Error:  file.Error,
+		}
+		if file.TargetArtifactoryPath != "" {
+			record.Target = rtUrl + file.TargetArtifactoryPath
 		}
 		writer.Write(record)
 	}
@@ -174,12 +191,20 @@ func PrintSummaryReport(success, failed int, resultReader *content.ContentReader
 }
 
 func CreateSummaryReportString(success, failed int, err error) (string, error) {
+	return marshalSummaryReport(CreateSummaryReport(success, failed, err))
+}
+
+func CreateSummaryReport(success, failed int, err error) *summary.Summary {
 	summaryReport := summary.New(err)
 	summaryReport.Totals.Success = success
 	summaryReport.Totals.Failure = failed
 	if err == nil && summaryReport.Totals.Failure != 0 {
 		summaryReport.Status = summary.Failure
 	}
+	return summaryReport
+}
+
+func marshalSummaryReport(summaryReport *summary.Summary) (string, error) {
 	content, mErr := summaryReport.Marshal()
 	if errorutils.CheckError(mErr) != nil {
 		return "", mErr
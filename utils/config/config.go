@@ -49,9 +49,18 @@ func IsBintrayConfExists() (bool, error) {
 }
 
 // Returns the configured server or error if the server id was not found.
-// If defaultOrEmpty: return empty details if no configurations found, or default conf for empty serverId.
+// If defaultOrEmpty: return empty details if no configurations found, or for empty serverId, the server pinned by the project defaults or the default conf.
+// The server set by environment variables takes precedence over the config file, for empty serverId if defaultOrEmpty, or if its server ID is serverId.
 // Exclude refreshable tokens when working with external tools (build tools, curl, etc) or when sending requests not via ArtifactoryHttpClient.
 func GetArtifactorySpecificConfig(serverId string, defaultOrEmpty bool, excludeRefreshableTokens bool) (*ArtifactoryDetails, error) {
+	envDetails, err := GetEnvArtifactoryConf()
+	if err != nil {
+		return nil, err
+	}
+	if envDetails != nil && ((defaultOrEmpty && len(serverId) == 0) || (len(serverId) > 0 && envDetails.ServerId == serverId)) {
+		return envDetails, nil
+	}
+
 	configs, err := GetAllArtifactoryConfigs()
 	if err != nil {
 		return nil, err
@@ -60,9 +69,19 @@ func GetArtifactorySpecificConfig(serverId string, defaultOrEmpty bool, excludeR
 		if len(configs) == 0 {
 			return new(ArtifactoryDetails), nil
 		}
+		if len(serverId) == 0 {
+			projectDefaults, err := GetProjectDefaults()
+			if err != nil {
+				return nil, err
+			}
+			serverId = projectDefaults.ServerId
+		}
 		if len(serverId) == 0 {
 			details, err := GetDefaultConfiguredArtifactoryConf(configs)
-			return details, errorutils.CheckError(err)
+			if err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+			return details, readCredentialsFromHelperIfNeeded(details)
 		}
 	}
 
@@ -70,6 +89,9 @@ func GetArtifactorySpecificConfig(serverId string, defaultOrEmpty bool, excludeR
 	if err != nil {
 		return nil, err
 	}
+	if err = readCredentialsFromHelperIfNeeded(details); err != nil {
+		return nil, err
+	}
 	if excludeRefreshableTokens {
 		if details.AccessToken != "" && details.RefreshToken != "" {
 			details.AccessToken = ""
@@ -168,6 +190,11 @@ func ReadBintrayConf() (*BintrayDetails, error) {
 }
 
 func SaveArtifactoryConf(details []*ArtifactoryDetails) error {
+	for _, serverDetails := range details {
+		if err := validateNotFromEnv(serverDetails); err != nil {
+			return err
+		}
+	}
 	conf, err := readConf()
 	if err != nil {
 		return err
@@ -196,6 +223,7 @@ func SaveBintrayConf(details *BintrayDetails) error {
 
 func saveConfig(config *ConfigV2) error {
 	config.Version = cliutils.GetConfigVersion()
+	removeHelperSecrets(config)
 	err := config.encrypt()
 	if err != nil {
 		return err
@@ -382,7 +410,7 @@ func createHomeDirBackup() error {
 	backupName := ".jfrog-" + strconv.FormatInt(time.Now().Unix(), 10)
 	curBackupPath := filepath.Join(backupDir, backupName)
 	log.Debug("Creating a homedir backup at: " + curBackupPath)
-	exclude := []string{cliutils.JfrogBackupDirName, cliutils.JfrogDependenciesDirName, cliutils.JfrogLockDirName, cliutils.JfrogLogsDirName}
+	exclude := []string{cliutils.JfrogBackupDirName, cliutils.JfrogDependenciesDirName, cliutils.JfrogLockDirName, cliutils.JfrogLogsDirName, cliutils.JfrogCacheDirName}
 	return fileutils.CopyDir(homeDir, curBackupPath, true, exclude)
 }
 
@@ -477,7 +505,28 @@ type ArtifactoryDetails struct {
 	IsDefault            bool   `json:"isDefault,omitempty"`
 	InsecureTls          bool   `json:"-"`
 	// Deprecated, use password option instead.
-	ApiKey string `json:"apiKey,omitempty"`
+	ApiKey        string          `json:"apiKey,omitempty"`
+	TokenProfiles []*TokenProfile `json:"tokenProfiles,omitempty"`
+	// An executable which stores the password or access token of the server, instead of the config file.
+	// The executable is used like a docker credential helper, with the server URL as the key of the credentials.
+	CredentialsHelper string `json:"credentialsHelper,omitempty"`
+	// The name of the token profile selected for authentication, or empty if the server credentials are used.
+	TokenProfile string `json:"-"`
+	// True for the details set by environment variables, which are never saved to the config file.
+	fromEnv bool
+}
+
+// A named access token of a server, created with a specific scope, audience and expiry.
+// Commands can select a token profile to authenticate with its token instead of the server credentials.
+// The token is refreshed before it expires, and a new token with the same parameters is created if it can't be refreshed.
+type TokenProfile struct {
+	Name         string `json:"name,omitempty"`
+	User         string `json:"user,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+	Audience     string `json:"audience,omitempty"`
+	ExpiresIn    int    `json:"expiresIn,omitempty"`
+	AccessToken  string `json:"accessToken,omitempty"`
+	RefreshToken string `json:"refreshToken,omitempty"`
 }
 
 type BintrayDetails struct {
@@ -497,6 +546,57 @@ func (artifactoryDetails *ArtifactoryDetails) IsEmpty() bool {
 	return len(artifactoryDetails.Url) == 0
 }
 
+func (artifactoryDetails *ArtifactoryDetails) GetTokenProfile(name string) *TokenProfile {
+	for _, profile := range artifactoryDetails.TokenProfiles {
+		if profile.Name == name {
+			return profile
+		}
+	}
+	return nil
+}
+
+// Adds the token profile, or replaces the token profile with the same name.
+func (artifactoryDetails *ArtifactoryDetails) SetTokenProfile(profile *TokenProfile) {
+	for i, existing := range artifactoryDetails.TokenProfiles {
+		if existing.Name == profile.Name {
+			artifactoryDetails.TokenProfiles[i] = profile
+			return
+		}
+	}
+	artifactoryDetails.TokenProfiles = append(artifactoryDetails.TokenProfiles, profile)
+}
+
+// Returns the removed token profile, or nil if the server has no token profile with this name.
+func (artifactoryDetails *ArtifactoryDetails) RemoveTokenProfile(name string) *TokenProfile {
+	for i, profile := range artifactoryDetails.TokenProfiles {
+		if profile.Name == name {
+			artifactoryDetails.TokenProfiles = append(artifactoryDetails.TokenProfiles[:i], artifactoryDetails.TokenProfiles[i+1:]...)
+			return profile
+		}
+	}
+	return nil
+}
+
+// Sets the tokens of the token profile as the credentials of the server.
+// If excludeRefreshToken is true, the access token is used as is, without refreshing it before it expires.
+func (artifactoryDetails *ArtifactoryDetails) SelectTokenProfile(name string, excludeRefreshToken bool) error {
+	profile := artifactoryDetails.GetTokenProfile(name)
+	if profile == nil {
+		return errorutils.CheckError(errors.New("the server '" + artifactoryDetails.ServerId + "' has no token profile named '" + name + "'"))
+	}
+	artifactoryDetails.TokenProfile = name
+	artifactoryDetails.AccessToken = profile.AccessToken
+	artifactoryDetails.RefreshToken = profile.RefreshToken
+	if excludeRefreshToken {
+		artifactoryDetails.RefreshToken = ""
+	}
+	artifactoryDetails.TokenRefreshInterval = cliutils.TokenRefreshDisabled
+	artifactoryDetails.User = ""
+	artifactoryDetails.Password = ""
+	artifactoryDetails.ApiKey = ""
+	return nil
+}
+
 func (artifactoryDetails *ArtifactoryDetails) SetApiKey(apiKey string) {
 	artifactoryDetails.ApiKey = apiKey
 }
@@ -579,8 +679,8 @@ func (artifactoryDetails *ArtifactoryDetails) createArtAuthConfig(details auth.S
 	// If refresh token is not empty, set a refresh handler and skip other credentials.
 	if artifactoryDetails.RefreshToken != "" {
 		// Save serverId for refreshing if needed. If empty serverId is saved, default will be used.
-		tokenRefreshServerId = artifactoryDetails.ServerId
-		details.AppendPreRequestInterceptor(AccessTokenRefreshPreRequestInterceptor)
+		refresher := &tokenRefresher{serverId: artifactoryDetails.ServerId, profile: artifactoryDetails.TokenProfile}
+		details.AppendPreRequestInterceptor(refresher.accessTokenRefreshPreRequestInterceptor)
 	} else {
 		details.SetApiKey(artifactoryDetails.ApiKey)
 		details.SetUser(artifactoryDetails.User)
@@ -259,7 +259,8 @@ func TestHandleSecrets(t *testing.T) {
 
 	original := new(ConfigV2)
 	original.Artifactory = []*ArtifactoryDetails{{User: "user", Password: "password", Url: "http://localhost:8080/artifactory/", AccessToken: "accessToken",
-		RefreshToken: "refreshToken", ApiKey: "apiKEY", SshPassphrase: "sshPass"}}
+		RefreshToken: "refreshToken", ApiKey: "apiKEY", SshPassphrase: "sshPass",
+		TokenProfiles: []*TokenProfile{{Name: "deploy", AccessToken: "profileAccessToken", RefreshToken: "profileRefreshToken"}}}}
 	original.Bintray = &BintrayDetails{ApiUrl: "APIurl", Key: "bintrayKey"}
 	original.MissionControl = &MissionControlDetails{Url: "url", AccessToken: "mcToken"}
 
@@ -274,6 +275,55 @@ func TestHandleSecrets(t *testing.T) {
 	verifyEncryptionStatus(t, original, newConf, false)
 }
 
+func TestTokenProfiles(t *testing.T) {
+	details := &ArtifactoryDetails{ServerId: "server", User: "user", Password: "password", AccessToken: "accessToken", RefreshToken: "refreshToken"}
+	details.SetTokenProfile(&TokenProfile{Name: "deploy", Scope: "member-of-groups:deployers", AccessToken: "oldAccessToken"})
+	details.SetTokenProfile(&TokenProfile{Name: "read", Scope: "member-of-groups:readers", AccessToken: "readAccessToken"})
+	details.SetTokenProfile(&TokenProfile{Name: "deploy", Scope: "member-of-groups:deployers", AccessToken: "deployAccessToken", RefreshToken: "deployRefreshToken"})
+	assert.Len(t, details.TokenProfiles, 2)
+	assert.Equal(t, "deployAccessToken", details.GetTokenProfile("deploy").AccessToken)
+
+	// Select a missing profile.
+	selected := *details
+	assert.Error(t, selected.SelectTokenProfile("missing", false))
+
+	// Select a profile with its refresh token.
+	assert.NoError(t, selected.SelectTokenProfile("deploy", false))
+	assert.Equal(t, "deploy", selected.TokenProfile)
+	assert.Equal(t, "deployAccessToken", selected.AccessToken)
+	assert.Equal(t, "deployRefreshToken", selected.RefreshToken)
+	assert.Empty(t, selected.User)
+	assert.Empty(t, selected.Password)
+
+	// Select a profile without its refresh token.
+	selected = *details
+	assert.NoError(t, selected.SelectTokenProfile("deploy", true))
+	assert.Equal(t, "deployAccessToken", selected.AccessToken)
+	assert.Empty(t, selected.RefreshToken)
+
+	assert.Nil(t, details.RemoveTokenProfile("missing"))
+	assert.Equal(t, "read", details.RemoveTokenProfile("read").Name)
+	assert.Len(t, details.TokenProfiles, 1)
+	assert.Nil(t, details.GetTokenProfile("read"))
+}
+
+func TestTokenProfilesRefresh(t *testing.T) {
+	details := &ArtifactoryDetails{ServerId: "server", AccessToken: "serverAccessToken", RefreshToken: "serverRefreshToken"}
+	details.SetTokenProfile(&TokenProfile{Name: "deploy", AccessToken: "deployAccessToken", RefreshToken: "deployRefreshToken"})
+	details.SetTokenProfile(&TokenProfile{Name: "read", AccessToken: "readAccessToken", RefreshToken: "readRefreshToken"})
+
+	// Services of the same process which authenticate with different profiles refresh the tokens of their own profiles.
+	for _, profileName := range []string{"deploy", "read"} {
+		refresher := &tokenRefresher{serverId: "server", profile: profileName}
+		profile, err := refresher.getRefreshedTokens(details, "")
+		assert.NoError(t, err)
+		assert.Equal(t, profileName+"RefreshToken", profile.RefreshToken)
+	}
+	refresher := &tokenRefresher{serverId: "server", profile: "missing"}
+	_, err := refresher.getRefreshedTokens(details, "")
+	assert.Error(t, err)
+}
+
 func copyConfig(t *testing.T, original *ConfigV2) *ConfigV2 {
 	b, err := json.Marshal(&original)
 	assert.NoError(t, err)
@@ -298,6 +348,10 @@ func verifyEncryptionStatus(t *testing.T, original, actual *ConfigV2, encryption
 		if original.Artifactory[i].ApiKey != "" {
 			equals = append(equals, original.Artifactory[i].ApiKey == actual.Artifactory[i].ApiKey)
 		}
+		for j, profile := range actual.Artifactory[i].TokenProfiles {
+			equals = append(equals, original.Artifactory[i].TokenProfiles[j].AccessToken == profile.AccessToken)
+			equals = append(equals, original.Artifactory[i].TokenProfiles[j].RefreshToken == profile.RefreshToken)
+		}
 	}
 	if actual.Bintray != nil {
 		equals = append(equals, original.Bintray.Key == actual.Bintray.Key)
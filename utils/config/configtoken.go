@@ -10,20 +10,21 @@ import (
 const tokenVersion = 1
 
 type configToken struct {
-	Version              int    `json:"version,omitempty"`
-	Url                  string `json:"url,omitempty"`
-	DistributionUrl      string `json:"distributionUrl,omitempty"`
-	User                 string `json:"user,omitempty"`
-	Password             string `json:"password,omitempty"`
-	SshKeyPath           string `json:"sshKeyPath,omitempty"`
-	SshPassphrase        string `json:"sshPassphrase,omitempty"`
-	AccessToken          string `json:"accessToken,omitempty"`
-	RefreshToken         string `json:"refreshToken,omitempty"`
-	TokenRefreshInterval int    `json:"tokenRefreshInterval,omitempty"`
-	ClientCertPath       string `json:"clientCertPath,omitempty"`
-	ClientCertKeyPath    string `json:"clientCertKeyPath,omitempty"`
-	ServerId             string `json:"serverId,omitempty"`
-	ApiKey               string `json:"apiKey,omitempty"`
+	Version              int             `json:"version,omitempty"`
+	Url                  string          `json:"url,omitempty"`
+	DistributionUrl      string          `json:"distributionUrl,omitempty"`
+	User                 string          `json:"user,omitempty"`
+	Password             string          `json:"password,omitempty"`
+	SshKeyPath           string          `json:"sshKeyPath,omitempty"`
+	SshPassphrase        string          `json:"sshPassphrase,omitempty"`
+	AccessToken          string          `json:"accessToken,omitempty"`
+	RefreshToken         string          `json:"refreshToken,omitempty"`
+	TokenRefreshInterval int             `json:"tokenRefreshInterval,omitempty"`
+	ClientCertPath       string          `json:"clientCertPath,omitempty"`
+	ClientCertKeyPath    string          `json:"clientCertKeyPath,omitempty"`
+	ServerId             string          `json:"serverId,omitempty"`
+	ApiKey               string          `json:"apiKey,omitempty"`
+	TokenProfiles        []*TokenProfile `json:"tokenProfiles,omitempty"`
 }
 
 func fromArtifactoryDetails(details *ArtifactoryDetails) *configToken {
@@ -42,6 +43,7 @@ func fromArtifactoryDetails(details *ArtifactoryDetails) *configToken {
 		ClientCertKeyPath:    details.ClientCertKeyPath,
 		ServerId:             details.ServerId,
 		ApiKey:               details.ApiKey,
+		TokenProfiles:        details.TokenProfiles,
 	}
 }
 
@@ -60,6 +62,7 @@ func toArtifactoryDetails(detailsSerialization *configToken) *ArtifactoryDetails
 		ClientCertKeyPath:    detailsSerialization.ClientCertKeyPath,
 		ServerId:             detailsSerialization.ServerId,
 		ApiKey:               detailsSerialization.ApiKey,
+		TokenProfiles:        detailsSerialization.TokenProfiles,
 	}
 }
 
@@ -70,7 +73,7 @@ func Export(details *ArtifactoryDetails) (string, error) {
 	}
 	// If config is encrypted, ask for master key.
 	if conf.Enc {
-		masterKeyFromFile, _, err := getMasterKeyFromSecurityConfFile()
+		masterKeyFromFile, _, err := getMasterKey()
 		if err != nil {
 			return "", err
 		}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os/exec"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The username a credentials helper returns when the secret is an access token, as in the docker credential helpers protocol.
+const credentialsHelperTokenUsername = "<token>"
+
+// The credentials exchanged with a credentials helper.
+// The format is the one used by the docker credential helpers, so that these helpers can be used as credentials helpers.
+type helperCredentials struct {
+	ServerURL string
+	Username  string
+	Secret    string
+}
+
+func readCredentialsFromHelperIfNeeded(details *ArtifactoryDetails) error {
+	if details.CredentialsHelper == "" {
+		return nil
+	}
+	return readCredentialsFromHelper(details)
+}
+
+// Reads the credentials of the server from its credentials helper, by running '<helper> get' with the server URL as input.
+// The helper's secret is set as the access token if the helper's username is "<token>", and as the password otherwise.
+// The tokens can't be refreshed, since the refreshed tokens are not stored in the helper.
+func readCredentialsFromHelper(details *ArtifactoryDetails) error {
+	output, err := runCredentialsHelper(details.CredentialsHelper, "get", details.Url)
+	if err != nil {
+		return err
+	}
+	credentials := new(helperCredentials)
+	if err = json.Unmarshal(output, credentials); err != nil {
+		return errorutils.CheckError(errors.New("the credentials helper " + details.CredentialsHelper + " returned invalid credentials: " + err.Error()))
+	}
+	if credentials.Username == credentialsHelperTokenUsername {
+		details.AccessToken = credentials.Secret
+	} else {
+		if credentials.Username != "" {
+			details.User = credentials.Username
+		}
+		details.Password = credentials.Secret
+	}
+	details.TokenRefreshInterval = cliutils.TokenRefreshDisabled
+	return nil
+}
+
+// Stores the secret of the server in its credentials helper, by running '<helper> store' with the credentials as input.
+// Does nothing if the server has no secret, for example if the credentials were stored in the helper beforehand.
+func StoreCredentialsInHelper(details *ArtifactoryDetails) error {
+	credentials := helperCredentials{ServerURL: details.Url, Username: details.User, Secret: details.Password}
+	switch {
+	case details.AccessToken != "":
+		credentials.Username = credentialsHelperTokenUsername
+		credentials.Secret = details.AccessToken
+	case details.Password == "":
+		// The API key is deprecated and used as a password.
+		credentials.Secret = details.ApiKey
+	}
+	if credentials.Secret == "" {
+		return nil
+	}
+	input, err := json.Marshal(credentials)
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	_, err = runCredentialsHelper(details.CredentialsHelper, "store", string(input))
+	return err
+}
+
+// Removes the credentials of the server from its credentials helper, by running '<helper> erase' with the server URL as input.
+func EraseCredentialsFromHelper(details *ArtifactoryDetails) error {
+	_, err := runCredentialsHelper(details.CredentialsHelper, "erase", details.Url)
+	return err
+}
+
+// Returns the other server in the configurations which uses the same credentials helper and URL as the server, or nil if there is none.
+// The credentials helper keeps a single secret for each URL, so these servers share their credentials.
+func GetServerSharingHelperCredentials(details *ArtifactoryDetails, configurations []*ArtifactoryDetails) *ArtifactoryDetails {
+	url := utils.AddTrailingSlashIfNeeded(details.Url)
+	for _, other := range configurations {
+		if other.ServerId != details.ServerId && other.CredentialsHelper == details.CredentialsHelper && utils.AddTrailingSlashIfNeeded(other.Url) == url {
+			return other
+		}
+	}
+	return nil
+}
+
+func runCredentialsHelper(helper, action, input string) ([]byte, error) {
+	log.Debug("Running the credentials helper:", helper, action)
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(helper, action)
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String() + stdout.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return nil, errorutils.CheckError(errors.New("the credentials helper '" + helper + " " + action + "' failed: " + message))
+	}
+	return stdout.Bytes(), nil
+}
+
+// The secrets of servers using a credentials helper are kept in the helper, and not saved in the config file.
+func removeHelperSecrets(config *ConfigV2) {
+	for _, details := range config.Artifactory {
+		if details.CredentialsHelper == "" {
+			continue
+		}
+		details.Password = ""
+		details.ApiKey = ""
+		details.AccessToken = ""
+		details.RefreshToken = ""
+		for _, profile := range details.TokenProfiles {
+			profile.AccessToken = ""
+			profile.RefreshToken = ""
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/stretchr/testify/assert"
+)
+
+// A credentials helper which keeps the stored credentials in a file next to it, regardless of the server URL.
+const credentialsHelperScript = `#!/bin/sh
+store="$(dirname "$0")/credentials.json"
+case "$1" in
+	store) cat > "$store" ;;
+	get) [ -f "$store" ] && cat "$store" || { echo "credentials not found" >&2; exit 1; } ;;
+	erase) rm -f "$store" ;;
+esac
+`
+
+func TestCredentialsHelper(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The credentials helper of the test is a shell script.")
+	}
+	tempDirPath, oldHomeDir := createTempEnv(t)
+	defer os.RemoveAll(tempDirPath)
+	defer os.Setenv(cliutils.HomeDir, oldHomeDir)
+	helper := filepath.Join(tempDirPath, "credentials-helper")
+	assert.NoError(t, ioutil.WriteFile(helper, []byte(credentialsHelperScript), 0700))
+
+	// Getting the credentials before they are stored fails.
+	details := &ArtifactoryDetails{ServerId: "server", Url: "http://localhost:8080/artifactory/", User: "user", Password: "password", CredentialsHelper: helper}
+	assert.NoError(t, SaveArtifactoryConf([]*ArtifactoryDetails{details}))
+	_, err := GetArtifactorySpecificConfig("server", true, false)
+	assert.Error(t, err)
+
+	// The password is stored in the helper, and not in the config file.
+	details = &ArtifactoryDetails{ServerId: "server", Url: "http://localhost:8080/artifactory/", User: "user", Password: "password", CredentialsHelper: helper}
+	assert.NoError(t, StoreCredentialsInHelper(details))
+	assert.NoError(t, SaveArtifactoryConf([]*ArtifactoryDetails{details}))
+	savedConfig := readConfFromFile(t)
+	assert.Equal(t, "user", savedConfig.Artifactory[0].User)
+	assert.Empty(t, savedConfig.Artifactory[0].Password)
+	readDetails, err := GetArtifactorySpecificConfig("server", true, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "user", readDetails.User)
+	assert.Equal(t, "password", readDetails.Password)
+
+	// An access token is stored with the "<token>" username.
+	details = &ArtifactoryDetails{ServerId: "server", IsDefault: true, Url: "http://localhost:8080/artifactory/", AccessToken: "token", CredentialsHelper: helper}
+	assert.NoError(t, StoreCredentialsInHelper(details))
+	assert.NoError(t, SaveArtifactoryConf([]*ArtifactoryDetails{details}))
+	assert.Empty(t, readConfFromFile(t).Artifactory[0].AccessToken)
+	readDetails, err = GetArtifactorySpecificConfig("", true, false)
+	assert.NoError(t, err)
+	assert.Empty(t, readDetails.User)
+	assert.Equal(t, "token", readDetails.AccessToken)
+
+	assert.NoError(t, EraseCredentialsFromHelper(details))
+	_, err = GetArtifactorySpecificConfig("server", true, false)
+	assert.Error(t, err)
+}
+
+func TestGetServerSharingHelperCredentials(t *testing.T) {
+	server := &ArtifactoryDetails{ServerId: "server", Url: "http://localhost:8080/artifactory/", CredentialsHelper: "helper"}
+	sameUrl := &ArtifactoryDetails{ServerId: "sameUrl", Url: "http://localhost:8080/artifactory", CredentialsHelper: "helper"}
+	otherHelper := &ArtifactoryDetails{ServerId: "otherHelper", Url: "http://localhost:8080/artifactory/", CredentialsHelper: "otherHelper"}
+	otherUrl := &ArtifactoryDetails{ServerId: "otherUrl", Url: "http://localhost:8081/artifactory/", CredentialsHelper: "helper"}
+
+	assert.Nil(t, GetServerSharingHelperCredentials(server, []*ArtifactoryDetails{server, otherHelper, otherUrl}))
+	assert.Equal(t, sameUrl, GetServerSharingHelperCredentials(server, []*ArtifactoryDetails{server, sameUrl, otherHelper}))
+}
+
+func TestRemoveHelperSecrets(t *testing.T) {
+	profiles := []*TokenProfile{{Name: "deploy", AccessToken: "profileAccessToken", RefreshToken: "profileRefreshToken"}}
+	config := &ConfigV2{Artifactory: []*ArtifactoryDetails{
+		{ServerId: "helper", AccessToken: "token", RefreshToken: "refreshToken", TokenProfiles: profiles, CredentialsHelper: "helper"},
+		{ServerId: "noHelper", AccessToken: "token", TokenProfiles: []*TokenProfile{{Name: "deploy", AccessToken: "profileAccessToken"}}},
+	}}
+	removeHelperSecrets(config)
+
+	assert.Empty(t, config.Artifactory[0].AccessToken)
+	assert.Empty(t, config.Artifactory[0].RefreshToken)
+	assert.Equal(t, "deploy", config.Artifactory[0].TokenProfiles[0].Name)
+	assert.Empty(t, config.Artifactory[0].TokenProfiles[0].AccessToken)
+	assert.Empty(t, config.Artifactory[0].TokenProfiles[0].RefreshToken)
+	assert.Equal(t, "token", config.Artifactory[1].AccessToken)
+	assert.Equal(t, "profileAccessToken", config.Artifactory[1].TokenProfiles[0].AccessToken)
+	assert.Error(t, ValidateTokenProfilesSupported(config.Artifactory[0]))
+	assert.NoError(t, ValidateTokenProfilesSupported(config.Artifactory[1]))
+}
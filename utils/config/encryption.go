@@ -14,8 +14,10 @@ import (
 	"github.com/spf13/viper"
 	"golang.org/x/crypto/ssh/terminal"
 	"io"
+	"io/ioutil"
 	"os"
 	"strconv"
+	"strings"
 	"syscall"
 )
 
@@ -33,7 +35,7 @@ type secretHandler func(string, string) (string, error)
 
 // Encrypt config file if security configuration file exists and contains master key.
 func (config *ConfigV2) encrypt() error {
-	key, _, err := getMasterKeyFromSecurityConfFile()
+	key, _, err := getMasterKey()
 	if err != nil || key == "" {
 		return err
 	}
@@ -47,12 +49,12 @@ func (config *ConfigV2) decrypt() error {
 	if !config.Enc {
 		return updateEncryptionIfNeeded(config)
 	}
-	key, secFileExists, err := getMasterKeyFromSecurityConfFile()
+	key, keyConfigured, err := getMasterKey()
 	if err != nil {
 		return err
 	}
-	if !secFileExists {
-		return errorutils.CheckError(errors.New(decryptErrorPrefix + "security configuration file was not found"))
+	if !keyConfigured {
+		return errorutils.CheckError(errors.New(decryptErrorPrefix + "security configuration file was not found, and the " + cliutils.MasterKey + " and " + cliutils.MasterKeyFile + " environment variables are not set"))
 	}
 	if key == "" {
 		return errorutils.CheckError(errors.New(decryptErrorPrefix + "security configuration file does not contain a master key"))
@@ -62,7 +64,7 @@ func (config *ConfigV2) decrypt() error {
 
 // Encrypt the config file if it is decrypted while security configuration file exists and contains a master key.
 func updateEncryptionIfNeeded(originalConfig *ConfigV2) error {
-	masterKey, _, err := getMasterKeyFromSecurityConfFile()
+	masterKey, _, err := getMasterKey()
 	if err != nil || masterKey == "" {
 		return err
 	}
@@ -110,6 +112,16 @@ func handleSecrets(config *ConfigV2, handler secretHandler, key string) error {
 		if err != nil {
 			return err
 		}
+		for _, profile := range rtDetails.TokenProfiles {
+			profile.AccessToken, err = handler(profile.AccessToken, key)
+			if err != nil {
+				return err
+			}
+			profile.RefreshToken, err = handler(profile.RefreshToken, key)
+			if err != nil {
+				return err
+			}
+		}
 	}
 	if config.Bintray != nil {
 		config.Bintray.Key, err = handler(config.Bintray.Key, key)
@@ -126,6 +138,23 @@ func handleSecrets(config *ConfigV2, handler secretHandler, key string) error {
 	return nil
 }
 
+// Returns the master key from the JFROG_CLI_MASTER_KEY environment variable, from the file which the JFROG_CLI_MASTER_KEY_FILE environment variable points to,
+// or from the security configuration file, by this order.
+// keyConfigured is false if the master key isn't configured in any of them.
+func getMasterKey() (key string, keyConfigured bool, err error) {
+	if key = os.Getenv(cliutils.MasterKey); key != "" {
+		return key, true, nil
+	}
+	if keyFile := os.Getenv(cliutils.MasterKeyFile); keyFile != "" {
+		content, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return "", false, errorutils.CheckError(err)
+		}
+		return strings.TrimSpace(string(content)), true, nil
+	}
+	return getMasterKeyFromSecurityConfFile()
+}
+
 func getMasterKeyFromSecurityConfFile() (key string, secFileExists bool, err error) {
 	secFile, err := cliutils.GetJfrogSecurityConfFilePath()
 	if err != nil {
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"os"
+)
+
+// Returns the Artifactory server details set by the JFROG_CLI_URL, JFROG_CLI_USER, JFROG_CLI_PASSWORD... environment variables,
+// or nil if neither the JFROG_CLI_URL nor the JFROG_CLI_DIST_URL environment variables are set.
+// These details are never saved to the config file. They allow running commands in environments without a persistent JFrog home.
+func GetEnvArtifactoryConf() (*ArtifactoryDetails, error) {
+	details := &ArtifactoryDetails{
+		ServerId:          os.Getenv(cliutils.EnvServerId),
+		Url:               utils.AddTrailingSlashIfNeeded(os.Getenv(cliutils.EnvUrl)),
+		DistributionUrl:   utils.AddTrailingSlashIfNeeded(os.Getenv(cliutils.EnvDistributionUrl)),
+		User:              os.Getenv(cliutils.EnvUser),
+		Password:          os.Getenv(cliutils.EnvPassword),
+		AccessToken:       os.Getenv(cliutils.EnvAccessToken),
+		ClientCertPath:    os.Getenv(cliutils.EnvClientCertPath),
+		ClientCertKeyPath: os.Getenv(cliutils.EnvClientCertKeyPath),
+		// The tokens can't be refreshed, since the refreshed tokens can't be saved.
+		TokenRefreshInterval: cliutils.TokenRefreshDisabled,
+		fromEnv:              true,
+	}
+	insecureTls, err := utils.GetBoolEnvValue(cliutils.EnvInsecureTls, false)
+	if err != nil {
+		return nil, err
+	}
+	details.InsecureTls = insecureTls
+
+	if details.Url == "" && details.DistributionUrl == "" {
+		// For security reasons, we'd like to avoid using credentials from the environment with a server from the config.
+		if details.ServerId != "" || details.User != "" || details.Password != "" || details.AccessToken != "" ||
+			details.ClientCertPath != "" || details.ClientCertKeyPath != "" {
+			return nil, errorutils.CheckError(errors.New(fmt.Sprintf("the %s or %s environment variables are mandatory when setting the server details using environment variables", cliutils.EnvUrl, cliutils.EnvDistributionUrl)))
+		}
+		return nil, nil
+	}
+	return details, nil
+}
+
+// Returns true if the details are set by environment variables.
+func (o *ArtifactoryDetails) IsFromEnv() bool {
+	return o.fromEnv
+}
+
+// Returns an error for details set by environment variables, which can't be saved to the config file.
+func validateNotFromEnv(details *ArtifactoryDetails) error {
+	if details == nil || !details.fromEnv {
+		return nil
+	}
+	return errorutils.CheckError(errors.New(fmt.Sprintf("the details of the server set by the %s environment variable can't be saved to the config file. "+
+		"Configure the server using the config command instead", cliutils.EnvUrl)))
+}
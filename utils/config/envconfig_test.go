@@ -0,0 +1,99 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvArtifactoryConf(t *testing.T) {
+	tempDirPath, oldHomeDir := createTempEnv(t)
+	defer os.RemoveAll(tempDirPath)
+	defer os.Setenv(cliutils.HomeDir, oldHomeDir)
+	assert.NoError(t, SaveArtifactoryConf([]*ArtifactoryDetails{
+		{ServerId: "default", Url: "http://default/artifactory/", User: "admin", Password: "password", IsDefault: true},
+		{ServerId: "other", Url: "http://other/artifactory/"},
+	}))
+
+	// Credentials without a URL are not allowed.
+	setEnv(t, cliutils.EnvUser, "ci-user")
+	_, err := GetArtifactorySpecificConfig("", true, false)
+	assert.Error(t, err)
+
+	setEnv(t, cliutils.EnvUrl, "http://env/artifactory")
+	setEnv(t, cliutils.EnvAccessToken, "token")
+	setEnv(t, cliutils.EnvInsecureTls, "true")
+	expected := &ArtifactoryDetails{Url: "http://env/artifactory/", User: "ci-user", AccessToken: "token", InsecureTls: true,
+		TokenRefreshInterval: cliutils.TokenRefreshDisabled, fromEnv: true}
+
+	// The server set by the environment variables replaces the default server.
+	details, err := GetArtifactorySpecificConfig("", true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, details)
+	details, err = GetArtifactorySpecificConfig("other", true, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://other/artifactory/", details.Url)
+
+	// The server set by the environment variables replaces the configured server with its server ID.
+	setEnv(t, cliutils.EnvServerId, "default")
+	expected.ServerId = "default"
+	details, err = GetArtifactorySpecificConfig("default", false, true)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, details)
+
+	// The config file is left untouched.
+	configs, err := GetAllArtifactoryConfigs()
+	assert.NoError(t, err)
+	assert.Len(t, configs, 2)
+	assert.Equal(t, "http://default/artifactory/", configs[0].Url)
+}
+
+func TestEnvArtifactoryConfNotSaved(t *testing.T) {
+	tempDirPath, oldHomeDir := createTempEnv(t)
+	defer os.RemoveAll(tempDirPath)
+	defer os.Setenv(cliutils.HomeDir, oldHomeDir)
+	assert.NoError(t, SaveArtifactoryConf([]*ArtifactoryDetails{
+		{ServerId: "default", Url: "http://default/artifactory/", User: "admin", Password: "password", IsDefault: true,
+			TokenProfiles: []*TokenProfile{{Name: "existing", User: "admin"}}},
+	}))
+	confFilePath, err := getConfFilePath()
+	assert.NoError(t, err)
+	originalContent, err := ioutil.ReadFile(confFilePath)
+	assert.NoError(t, err)
+
+	setEnv(t, cliutils.EnvUrl, "http://env/artifactory")
+	setEnv(t, cliutils.EnvUser, "ci-user")
+	setEnv(t, cliutils.EnvPassword, "env-password")
+	setEnv(t, cliutils.EnvServerId, "default")
+	details, err := GetArtifactorySpecificConfig("default", true, false)
+	assert.NoError(t, err)
+	assert.True(t, details.IsFromEnv())
+
+	// The paths saving the server details to the config file refuse the server set by the environment variables.
+	assert.Error(t, SaveTokenProfile("", &TokenProfile{Name: "p", User: "ciuser"}))
+	assert.Error(t, SaveTokenProfile("default", &TokenProfile{Name: "p", User: "ciuser"}))
+	_, err = RemoveTokenProfile("default", "existing")
+	assert.Error(t, err)
+	assert.Error(t, saveServerConfiguration(details, "default"))
+	assert.Error(t, SaveArtifactoryConf([]*ArtifactoryDetails{details}))
+
+	content, err := ioutil.ReadFile(confFilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, string(originalContent), string(content))
+	assert.NotContains(t, string(content), "env-password")
+}
+
+func setEnv(t *testing.T, key, value string) {
+	oldValue, exists := os.LookupEnv(key)
+	assert.NoError(t, os.Setenv(key, value))
+	t.Cleanup(func() {
+		if exists {
+			os.Setenv(key, oldValue)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
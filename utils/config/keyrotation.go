@@ -0,0 +1,158 @@
+package config
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/lock"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"gopkg.in/yaml.v2"
+)
+
+// Generates a new master key, and re-encrypts the secrets of the config file with it while the config is locked.
+// The new key replaces the current key where it is configured - in the file the JFROG_CLI_MASTER_KEY_FILE environment variable points to,
+// or in the security configuration file. If no key is configured, the key is saved in the security configuration file, and the config is encrypted for the first time.
+// If the key is configured by the JFROG_CLI_MASTER_KEY environment variable, the new key is written to newKeyFile, and the variable should be updated with it.
+// newKeyFile may be empty in this case, if the caller handles the returned key itself.
+func RotateMasterKey(newKeyFile string) (newKey string, err error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	lockFile, err := lock.CreateLock()
+	defer lockFile.Unlock()
+	if err != nil {
+		return "", err
+	}
+
+	// Reading the config decrypts its secrets with the current key.
+	conf, err := readConf()
+	if err != nil {
+		return "", err
+	}
+	newKey, err = generateMasterKey()
+	if err != nil {
+		return "", err
+	}
+	if err = handleSecrets(conf, encrypt, newKey); err != nil {
+		return "", err
+	}
+	conf.Enc = true
+	conf.Version = cliutils.GetConfigVersion()
+	confContent, err := conf.getContent()
+	if err != nil {
+		return "", err
+	}
+	confFilePath, err := getConfFilePath()
+	if err != nil {
+		return "", err
+	}
+	keyFilePath, keyContent, err := getMasterKeyFileContent(newKey, newKeyFile)
+	if err != nil {
+		return "", err
+	}
+
+	// Write the new files next to the current files before replacing them, so that a failure while writing leaves the current files unchanged.
+	if err = writeTempFile(confFilePath, confContent); err != nil {
+		return "", err
+	}
+	defer os.Remove(confFilePath + ".tmp")
+	if keyFilePath == "" {
+		return newKey, errorutils.CheckError(os.Rename(confFilePath+".tmp", confFilePath))
+	}
+	if err = writeTempFile(keyFilePath, keyContent); err != nil {
+		return "", err
+	}
+	defer os.Remove(keyFilePath + ".tmp")
+	oldKeyContent, err := readFileIfExists(keyFilePath)
+	if err != nil {
+		return "", err
+	}
+	if err = os.Rename(keyFilePath+".tmp", keyFilePath); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if err = os.Rename(confFilePath+".tmp", confFilePath); err != nil {
+		// Restore the current key, which the config is still encrypted with.
+		if oldKeyContent != nil {
+			ioutil.WriteFile(keyFilePath, oldKeyContent, 0600)
+		} else {
+			os.Remove(keyFilePath)
+		}
+		return "", errorutils.CheckError(err)
+	}
+	return newKey, nil
+}
+
+// Returns a random key, with the length expected by the AES-256 encryption.
+func generateMasterKey() (string, error) {
+	// The base64 encoding of 24 bytes is 32 characters long.
+	randomBytes := make([]byte, masterKeyLength*3/4)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return base64.StdEncoding.EncodeToString(randomBytes), nil
+}
+
+// Returns the path of the file the master key should be saved in, and the content of the file with the new key.
+// If the master key is configured by the JFROG_CLI_MASTER_KEY environment variable, returns newKeyFile, which may be empty.
+func getMasterKeyFileContent(newKey, newKeyFile string) (path string, content []byte, err error) {
+	if os.Getenv(cliutils.MasterKey) != "" {
+		if newKeyFile == "" {
+			return "", nil, nil
+		}
+		return newKeyFile, []byte(newKey + "\n"), nil
+	}
+	if path = os.Getenv(cliutils.MasterKeyFile); path != "" {
+		return path, []byte(newKey + "\n"), nil
+	}
+	path, err = cliutils.GetJfrogSecurityConfFilePath()
+	if err != nil {
+		return "", nil, err
+	}
+	// Keep the other fields of the security configuration file.
+	securityConf := yaml.MapSlice{}
+	currentContent, err := readFileIfExists(path)
+	if err != nil {
+		return "", nil, err
+	}
+	if err = yaml.Unmarshal(currentContent, &securityConf); err != nil {
+		return "", nil, errorutils.CheckError(err)
+	}
+	securityConf = setYamlField(securityConf, "version", 1, false)
+	securityConf = setYamlField(securityConf, masterKeyField, newKey, true)
+	content, err = yaml.Marshal(securityConf)
+	return path, content, errorutils.CheckError(err)
+}
+
+// Sets the value of the field, or adds the field if it doesn't exist. Existing fields are kept if override is false.
+func setYamlField(slice yaml.MapSlice, key string, value interface{}, override bool) yaml.MapSlice {
+	for i, item := range slice {
+		if item.Key == key {
+			if override {
+				slice[i].Value = value
+			}
+			return slice
+		}
+	}
+	return append(slice, yaml.MapItem{Key: key, Value: value})
+}
+
+func writeTempFile(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(ioutil.WriteFile(path+".tmp", content, 0600))
+}
+
+// Returns nil if the file doesn't exist.
+func readFileIfExists(path string) ([]byte, error) {
+	exists, err := fileutils.IsFileExists(path, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	content, err := ioutil.ReadFile(path)
+	return content, errorutils.CheckError(err)
+}
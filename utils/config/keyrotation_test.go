@@ -0,0 +1,95 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotateMasterKey(t *testing.T) {
+	tempDirPath, oldHomeDir := createTempEnv(t)
+	defer os.RemoveAll(tempDirPath)
+	defer os.Setenv(cliutils.HomeDir, oldHomeDir)
+	copyResources(t, encryptionResources, tempDirPath)
+	originalConfig := readConfFromFile(t)
+	oldKey, _, err := getMasterKeyFromSecurityConfFile()
+	assert.NoError(t, err)
+
+	newKey, err := RotateMasterKey("")
+	assert.NoError(t, err)
+	assert.Len(t, newKey, masterKeyLength)
+	assert.NotEqual(t, oldKey, newKey)
+	savedKey, _, err := getMasterKeyFromSecurityConfFile()
+	assert.NoError(t, err)
+	assert.Equal(t, newKey, savedKey)
+
+	// The secrets are encrypted in the file, and decrypted with the new key.
+	encryptedConfig := readConfFromFile(t)
+	assert.True(t, encryptedConfig.Enc)
+	verifyEncryptionStatus(t, originalConfig, encryptedConfig, true)
+	readConfig, err := readConf()
+	assert.NoError(t, err)
+	verifyEncryptionStatus(t, originalConfig, readConfig, false)
+}
+
+func TestRotateMasterKeyFromFile(t *testing.T) {
+	tempDirPath, oldHomeDir := createTempEnv(t)
+	defer os.RemoveAll(tempDirPath)
+	defer os.Setenv(cliutils.HomeDir, oldHomeDir)
+	copyResources(t, encryptionResources, tempDirPath)
+	originalConfig := readConfFromFile(t)
+	keyFile := filepath.Join(tempDirPath, "master.key")
+	assert.NoError(t, ioutil.WriteFile(keyFile, []byte("anotherkeywithlengthofexactly32!\n"), 0600))
+	assert.NoError(t, os.Setenv(cliutils.MasterKeyFile, keyFile))
+	defer os.Unsetenv(cliutils.MasterKeyFile)
+
+	newKey, err := RotateMasterKey("")
+	assert.NoError(t, err)
+	content, err := ioutil.ReadFile(keyFile)
+	assert.NoError(t, err)
+	assert.Equal(t, newKey+"\n", string(content))
+	// The security configuration file is left unchanged.
+	securityConfKey, _, err := getMasterKeyFromSecurityConfFile()
+	assert.NoError(t, err)
+	assert.Equal(t, "randomkeywithlengthofexactly32!!", securityConfKey)
+
+	// The key from the environment variable takes precedence over the key file.
+	assert.NoError(t, os.Setenv(cliutils.MasterKey, newKey))
+	defer os.Unsetenv(cliutils.MasterKey)
+	assert.NoError(t, os.Remove(keyFile))
+	readConfig, err := readConf()
+	assert.NoError(t, err)
+	verifyEncryptionStatus(t, originalConfig, readConfig, false)
+}
+
+func TestRotateMasterKeyFromEnv(t *testing.T) {
+	tempDirPath, oldHomeDir := createTempEnv(t)
+	defer os.RemoveAll(tempDirPath)
+	defer os.Setenv(cliutils.HomeDir, oldHomeDir)
+	copyResources(t, encryptionResources, tempDirPath)
+	originalConfig := readConfFromFile(t)
+	oldKey, _, err := getMasterKeyFromSecurityConfFile()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Setenv(cliutils.MasterKey, oldKey))
+	defer os.Unsetenv(cliutils.MasterKey)
+
+	// The new key is written to the requested file, since it can't replace the environment variable.
+	newKeyFile := filepath.Join(tempDirPath, "new.key")
+	newKey, err := RotateMasterKey(newKeyFile)
+	assert.NoError(t, err)
+	content, err := ioutil.ReadFile(newKeyFile)
+	assert.NoError(t, err)
+	assert.Equal(t, newKey+"\n", string(content))
+	securityConfKey, _, err := getMasterKeyFromSecurityConfFile()
+	assert.NoError(t, err)
+	assert.Equal(t, oldKey, securityConfKey)
+
+	assert.NoError(t, os.Setenv(cliutils.MasterKey, newKey))
+	readConfig, err := readConf()
+	assert.NoError(t, err)
+	verifyEncryptionStatus(t, originalConfig, readConfig, false)
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+)
+
+// The defaults of a project are pinned in the .jfrog/config.yaml file in the project root directory.
+// For example:
+//
+//	version: 1
+//	serverId: my-server
+//	buildName: my-build
+//	repos:
+//	  resolve: libs-remote
+//	  deploy: libs-local
+type ProjectDefaults struct {
+	Version int `yaml:"version,omitempty"`
+	// Used instead of the default server, when a server ID isn't sent to the command.
+	ServerId string `yaml:"serverId,omitempty"`
+	// Used when a build number is sent to the command without a build name.
+	BuildName string `yaml:"buildName,omitempty"`
+	// Used by the build tools commands, when their project configuration doesn't include a repository.
+	Repos ProjectDefaultRepos `yaml:"repos,omitempty"`
+}
+
+type ProjectDefaultRepos struct {
+	Resolve string `yaml:"resolve,omitempty"`
+	Deploy  string `yaml:"deploy,omitempty"`
+}
+
+// Returns the defaults of the project the working directory is in, from the first .jfrog/config.yaml file found in the working directory or in one of its parent directories.
+// Returns empty defaults if there is no such file.
+func GetProjectDefaults() (*ProjectDefaults, error) {
+	defaultsFile := filepath.Join(cliutils.JfrogProjectDirName, cliutils.JfrogProjectDefaultsFile)
+	projectDir, exists, err := fileutils.FindUpstream(defaultsFile, fileutils.File)
+	if err != nil || !exists {
+		return new(ProjectDefaults), err
+	}
+	defaultsFilePath := filepath.Join(projectDir, defaultsFile)
+	log.Debug("Reading the project defaults from", defaultsFilePath)
+	content, err := ioutil.ReadFile(defaultsFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defaults := new(ProjectDefaults)
+	if err = yaml.Unmarshal(content, defaults); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return defaults, nil
+}
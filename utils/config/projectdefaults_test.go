@@ -0,0 +1,53 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectDefaults(t *testing.T) {
+	tempDirPath, oldHomeDir := createTempEnv(t)
+	defer os.RemoveAll(tempDirPath)
+	defer os.Setenv(cliutils.HomeDir, oldHomeDir)
+	assert.NoError(t, SaveArtifactoryConf([]*ArtifactoryDetails{
+		{ServerId: "default", Url: "http://default/artifactory/", IsDefault: true},
+		{ServerId: "pinned", Url: "http://pinned/artifactory/"},
+	}))
+
+	projectDir := filepath.Join(tempDirPath, "project")
+	workingDir := filepath.Join(projectDir, "module", "src")
+	assert.NoError(t, os.MkdirAll(workingDir, 0755))
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	defer os.Chdir(wd)
+	assert.NoError(t, os.Chdir(workingDir))
+
+	// Without project defaults, the default server is used.
+	defaults, err := GetProjectDefaults()
+	assert.NoError(t, err)
+	assert.Equal(t, ProjectDefaults{}, *defaults)
+	details, err := GetArtifactorySpecificConfig("", true, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "default", details.ServerId)
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(projectDir, ".jfrog"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(projectDir, ".jfrog", "config.yaml"), []byte(
+		"version: 1\nserverId: pinned\nbuildName: my-build\nrepos:\n  resolve: libs-remote\n  deploy: libs-local\n"), 0644))
+	defaults, err = GetProjectDefaults()
+	assert.NoError(t, err)
+	assert.Equal(t, ProjectDefaults{Version: 1, ServerId: "pinned", BuildName: "my-build",
+		Repos: ProjectDefaultRepos{Resolve: "libs-remote", Deploy: "libs-local"}}, *defaults)
+
+	// The pinned server replaces the default server, but not a server sent to the command.
+	details, err = GetArtifactorySpecificConfig("", true, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "pinned", details.ServerId)
+	details, err = GetArtifactorySpecificConfig("default", true, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "default", details.ServerId)
+}
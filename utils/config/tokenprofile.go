@@ -0,0 +1,64 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/jfrog/jfrog-cli/utils/lock"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Adds the token profile to the configuration of the server, or replaces its token profile with the same name.
+func SaveTokenProfile(serverId string, profile *TokenProfile) error {
+	return updateServerConfiguration(serverId, func(serverConfiguration *ArtifactoryDetails) error {
+		if err := ValidateTokenProfilesSupported(serverConfiguration); err != nil {
+			return err
+		}
+		serverConfiguration.SetTokenProfile(profile)
+		return nil
+	})
+}
+
+// The tokens of the profiles are saved in the config file, which doesn't keep the secrets of servers using a credentials helper.
+func ValidateTokenProfilesSupported(details *ArtifactoryDetails) error {
+	if details.CredentialsHelper != "" {
+		return errorutils.CheckError(errors.New("token profiles are not supported for the server '" + details.ServerId + "', since it uses a credentials helper"))
+	}
+	return nil
+}
+
+// Removes the token profile from the configuration of the server, and returns it.
+func RemoveTokenProfile(serverId, name string) (profile *TokenProfile, err error) {
+	err = updateServerConfiguration(serverId, func(serverConfiguration *ArtifactoryDetails) error {
+		profile = serverConfiguration.RemoveTokenProfile(name)
+		if profile == nil {
+			return errorutils.CheckError(errors.New("the server '" + serverConfiguration.ServerId + "' has no token profile named '" + name + "'"))
+		}
+		return nil
+	})
+	return
+}
+
+// Updates the configuration of the server while the config is locked, to prevent overriding tokens refreshed by other processes.
+func updateServerConfiguration(serverId string, update func(*ArtifactoryDetails) error) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+	lockFile, err := lock.CreateLock()
+	defer lockFile.Unlock()
+	if err != nil {
+		return err
+	}
+	serverConfiguration, err := GetArtifactorySpecificConfig(serverId, true, false)
+	if err != nil {
+		return err
+	}
+	if err = validateNotFromEnv(serverConfiguration); err != nil {
+		return err
+	}
+	if serverConfiguration.IsEmpty() {
+		return errorutils.CheckError(errors.New("token profiles can only be saved for an Artifactory server configured using the config command"))
+	}
+	if err = update(serverConfiguration); err != nil {
+		return err
+	}
+	return saveServerConfiguration(serverConfiguration, serverConfiguration.ServerId)
+}
@@ -1,12 +1,14 @@
 package config
 
 import (
+	"errors"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
 	"github.com/jfrog/jfrog-cli/utils/lock"
 	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	"github.com/jfrog/jfrog-client-go/auth"
 	"github.com/jfrog/jfrog-client-go/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"sync"
@@ -16,10 +18,15 @@ import (
 // Internal golang locking for the same process.
 var mutex sync.Mutex
 
-// The serverId used for authentication. Use for reading and writing tokens from/to the config file, and for reading the credentials if needed.
-var tokenRefreshServerId string
+// Refreshes the access token used for authentication before it expires.
+type tokenRefresher struct {
+	// The serverId used for authentication. Use for reading and writing tokens from/to the config file, and for reading the credentials if needed.
+	serverId string
+	// The name of the token profile used for authentication, or empty if the server tokens are used.
+	profile string
+}
 
-func AccessTokenRefreshPreRequestInterceptor(fields *auth.CommonConfigFields, httpClientDetails *httputils.HttpClientDetails) (err error) {
+func (refresher *tokenRefresher) accessTokenRefreshPreRequestInterceptor(fields *auth.CommonConfigFields, httpClientDetails *httputils.HttpClientDetails) (err error) {
 	if fields.GetAccessToken() == "" || httpClientDetails.AccessToken == "" {
 		return nil
 	}
@@ -34,7 +41,7 @@ func AccessTokenRefreshPreRequestInterceptor(fields *auth.CommonConfigFields, ht
 	defer mutex.Unlock()
 	// Refresh only if a new token wasn't acquired (by another thread) while waiting at mutex.
 	if fields.AccessToken == httpClientDetails.AccessToken {
-		newAccessToken, err := tokenRefreshHandler(httpClientDetails.AccessToken)
+		newAccessToken, err := refresher.tokenRefreshHandler(httpClientDetails.AccessToken)
 		if err != nil {
 			return err
 		}
@@ -47,7 +54,7 @@ func AccessTokenRefreshPreRequestInterceptor(fields *auth.CommonConfigFields, ht
 	return nil
 }
 
-func tokenRefreshHandler(currentAccessToken string) (newAccessToken string, err error) {
+func (refresher *tokenRefresher) tokenRefreshHandler(currentAccessToken string) (newAccessToken string, err error) {
 	log.Debug("Refreshing token...")
 	// Lock config to prevent access from different processes
 	lockFile, err := lock.CreateLock()
@@ -56,17 +63,24 @@ func tokenRefreshHandler(currentAccessToken string) (newAccessToken string, err
 		return "", err
 	}
 
-	serverConfiguration, err := GetArtifactorySpecificConfig(tokenRefreshServerId, true, false)
+	serverConfiguration, err := GetArtifactorySpecificConfig(refresher.serverId, true, false)
 	if err != nil {
 		return "", err
 	}
-	if tokenRefreshServerId == "" && serverConfiguration != nil {
-		tokenRefreshServerId = serverConfiguration.ServerId
+	if err = validateNotFromEnv(serverConfiguration); err != nil {
+		return "", err
+	}
+	if refresher.serverId == "" && serverConfiguration != nil {
+		refresher.serverId = serverConfiguration.ServerId
+	}
+	profile, err := refresher.getRefreshedTokens(serverConfiguration, currentAccessToken)
+	if err != nil {
+		return "", err
 	}
 	// If token already refreshed, get new token from config
-	if serverConfiguration.AccessToken != "" && serverConfiguration.AccessToken != currentAccessToken {
+	if profile.AccessToken != "" && profile.AccessToken != currentAccessToken {
 		log.Debug("Fetched new token from config.")
-		return serverConfiguration.AccessToken, nil
+		return profile.AccessToken, nil
 	}
 
 	// If token isn't already expired, Wait to make sure requests using the current token are sent before it is refreshed and becomes invalid
@@ -78,7 +92,8 @@ func tokenRefreshHandler(currentAccessToken string) (newAccessToken string, err
 		time.Sleep(auth.WaitBeforeRefreshSeconds * time.Second)
 	}
 
-	refreshToken := serverConfiguration.RefreshToken
+	refreshToken := profile.RefreshToken
+	serverAccessToken, serverRefreshToken := serverConfiguration.AccessToken, serverConfiguration.RefreshToken
 	// Remove previous tokens
 	serverConfiguration.AccessToken = ""
 	serverConfiguration.RefreshToken = ""
@@ -89,12 +104,7 @@ func tokenRefreshHandler(currentAccessToken string) (newAccessToken string, err
 		log.Debug("Refresh token failed: " + err.Error())
 		log.Debug("Trying to create new tokens...")
 
-		expirySeconds, err := auth.ExtractExpiryFromAccessToken(currentAccessToken)
-		if err != nil {
-			return "", err
-		}
-
-		newToken, err = createTokensForConfig(serverConfiguration, expirySeconds)
+		newToken, err = createTokensForConfig(serverConfiguration, *profile)
 		if err != nil {
 			return "", nil
 		}
@@ -102,18 +112,54 @@ func tokenRefreshHandler(currentAccessToken string) (newAccessToken string, err
 	} else {
 		log.Debug("Token refreshed successfully.")
 	}
+	if refresher.profile != "" {
+		// Restore the server tokens, which are not refreshed when a token profile is used.
+		serverConfiguration.AccessToken, serverConfiguration.RefreshToken = serverAccessToken, serverRefreshToken
+	}
 
-	err = writeNewTokens(serverConfiguration, tokenRefreshServerId, newToken.AccessToken, newToken.RefreshToken)
+	err = refresher.writeNewTokens(serverConfiguration, newToken.AccessToken, newToken.RefreshToken)
 	if err != nil {
 		log.Error("Failed writing new tokens to config after handling access token expiry: " + err.Error())
 	}
 	return newToken.AccessToken, nil
 }
 
-func writeNewTokens(serverConfiguration *ArtifactoryDetails, serverId, accessToken, refreshToken string) error {
-	serverConfiguration.SetAccessToken(accessToken)
-	serverConfiguration.SetRefreshToken(refreshToken)
+// Returns the tokens to refresh - the tokens of the token profile used for authentication, or the server tokens.
+func (refresher *tokenRefresher) getRefreshedTokens(serverConfiguration *ArtifactoryDetails, currentAccessToken string) (*TokenProfile, error) {
+	if refresher.profile == "" {
+		expirySeconds, err := auth.ExtractExpiryFromAccessToken(currentAccessToken)
+		if err != nil {
+			return nil, err
+		}
+		return &TokenProfile{
+			User: serverConfiguration.User,
+			// User-scoped token
+			Scope:        "member-of-groups:*",
+			ExpiresIn:    expirySeconds,
+			AccessToken:  serverConfiguration.AccessToken,
+			RefreshToken: serverConfiguration.RefreshToken}, nil
+	}
+	profile := serverConfiguration.GetTokenProfile(refresher.profile)
+	if profile == nil {
+		return nil, errorutils.CheckError(errors.New("the token profile '" + refresher.profile + "' was removed from the configuration of the server '" + serverConfiguration.ServerId + "'"))
+	}
+	return profile, nil
+}
 
+// Writes the new tokens to the token profile used for authentication, or to the server if no token profile is used.
+func (refresher *tokenRefresher) writeNewTokens(serverConfiguration *ArtifactoryDetails, accessToken, refreshToken string) error {
+	if refresher.profile == "" {
+		serverConfiguration.SetAccessToken(accessToken)
+		serverConfiguration.SetRefreshToken(refreshToken)
+	} else {
+		profile := serverConfiguration.GetTokenProfile(refresher.profile)
+		profile.AccessToken = accessToken
+		profile.RefreshToken = refreshToken
+	}
+	return saveServerConfiguration(serverConfiguration, refresher.serverId)
+}
+
+func saveServerConfiguration(serverConfiguration *ArtifactoryDetails, serverId string) error {
 	// Get configurations list
 	configurations, err := GetAllArtifactoryConfigs()
 	if err != nil {
@@ -128,17 +174,18 @@ func writeNewTokens(serverConfiguration *ArtifactoryDetails, serverId, accessTok
 	return SaveArtifactoryConf(configurations)
 }
 
-func createTokensForConfig(artifactoryDetails *ArtifactoryDetails, expirySeconds int) (services.CreateTokenResponseData, error) {
+// Creates refreshable tokens with the parameters of the token profile, using the server credentials.
+func createTokensForConfig(artifactoryDetails *ArtifactoryDetails, profile TokenProfile) (services.CreateTokenResponseData, error) {
 	servicesManager, err := createTokensServiceManager(artifactoryDetails)
 	if err != nil {
 		return services.CreateTokenResponseData{}, err
 	}
 
 	createTokenParams := services.NewCreateTokenParams()
-	createTokenParams.Username = artifactoryDetails.User
-	createTokenParams.ExpiresIn = expirySeconds
-	// User-scoped token
-	createTokenParams.Scope = "member-of-groups:*"
+	createTokenParams.Username = profile.User
+	createTokenParams.ExpiresIn = profile.ExpiresIn
+	createTokenParams.Scope = profile.Scope
+	createTokenParams.Audience = profile.Audience
 	createTokenParams.Refreshable = true
 
 	newToken, err := servicesManager.CreateToken(createTokenParams)
@@ -160,13 +207,17 @@ func CreateInitialRefreshableTokensIfNeeded(artifactoryDetails *ArtifactoryDetai
 		return err
 	}
 
-	newToken, err := createTokensForConfig(artifactoryDetails, artifactoryDetails.TokenRefreshInterval*60)
+	// User-scoped token
+	profile := TokenProfile{User: artifactoryDetails.User, Scope: "member-of-groups:*", ExpiresIn: artifactoryDetails.TokenRefreshInterval * 60}
+	newToken, err := createTokensForConfig(artifactoryDetails, profile)
 	if err != nil {
 		return err
 	}
 	// Remove initializing value.
 	artifactoryDetails.TokenRefreshInterval = 0
-	return writeNewTokens(artifactoryDetails, artifactoryDetails.ServerId, newToken.AccessToken, newToken.RefreshToken)
+	artifactoryDetails.SetAccessToken(newToken.AccessToken)
+	artifactoryDetails.SetRefreshToken(newToken.RefreshToken)
+	return saveServerConfiguration(artifactoryDetails, artifactoryDetails.ServerId)
 }
 
 func refreshExpiredToken(artifactoryDetails *ArtifactoryDetails, currentAccessToken string, refreshToken string) (services.CreateTokenResponseData, error) {
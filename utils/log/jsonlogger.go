@@ -0,0 +1,148 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Structured events names
+const (
+	CommandStartedEvent   = "command_started"
+	CommandFinishedEvent  = "command_finished"
+	TransferStartedEvent  = "transfer_started"
+	TransferFinishedEvent = "transfer_finished"
+	RetryEvent            = "retry"
+)
+
+// Matches the warning logged by jfrog-client-go before retrying an operation. For example: "Attempt 1 - Failure occurred while uploading".
+var retryMessageRegexp = regexp.MustCompile(`^(?:.*\s)?Attempt (\d+) - `)
+
+// Writes every log message as a JSON object in a separate line (NDJSON), so that the logs can be indexed by log pipelines.
+// Besides the log messages, the logger also writes structured events, such as the start and end of commands and files transfers.
+type jsonLogger struct {
+	logLevel     log.LevelType
+	outputWriter io.Writer
+	logsWriter   io.Writer
+	mutex        sync.Mutex
+}
+
+// Creates a new JSON logger with a given log level.
+// If logToWriter is nil, the logs are written to Stderr.
+func NewJsonLogger(logLevel log.LevelType, logToWriter io.Writer) log.Log {
+	logger := new(jsonLogger)
+	logger.SetLogLevel(logLevel)
+	logger.SetOutputWriter(os.Stdout)
+	logger.SetLogsWriter(logToWriter)
+	return logger
+}
+
+func (logger *jsonLogger) GetLogLevel() log.LevelType {
+	return logger.logLevel
+}
+
+func (logger *jsonLogger) SetLogLevel(logLevel log.LevelType) {
+	logger.logLevel = logLevel
+}
+
+func (logger *jsonLogger) SetOutputWriter(writer io.Writer) {
+	logger.outputWriter = writer
+}
+
+func (logger *jsonLogger) SetLogsWriter(writer io.Writer) {
+	if writer == nil {
+		writer = os.Stderr
+	}
+	logger.logsWriter = writer
+}
+
+func (logger *jsonLogger) Debug(a ...interface{}) {
+	logger.log(log.DEBUG, a...)
+}
+
+func (logger *jsonLogger) Info(a ...interface{}) {
+	logger.log(log.INFO, a...)
+}
+
+func (logger *jsonLogger) Warn(a ...interface{}) {
+	logger.log(log.WARN, a...)
+}
+
+func (logger *jsonLogger) Error(a ...interface{}) {
+	logger.log(log.ERROR, a...)
+}
+
+// The command output, such as the search results, is written as is, since it is usually parsed by the caller.
+func (logger *jsonLogger) Output(a ...interface{}) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	fmt.Fprintln(logger.outputWriter, a...)
+}
+
+func (logger *jsonLogger) log(level log.LevelType, a ...interface{}) {
+	if logger.logLevel < level {
+		return
+	}
+	message := strings.TrimSuffix(fmt.Sprintln(a...), "\n")
+	record := map[string]interface{}{"message": message}
+	if level == log.WARN {
+		if match := retryMessageRegexp.FindStringSubmatch(message); match != nil {
+			record["event"] = RetryEvent
+			record["attempt"], _ = strconv.Atoi(match[1])
+		}
+	}
+	logger.write(level, record)
+}
+
+func (logger *jsonLogger) event(level log.LevelType, event string, fields map[string]interface{}) {
+	if logger.logLevel < level {
+		return
+	}
+	record := map[string]interface{}{"event": event}
+	for key, value := range fields {
+		record[key] = value
+	}
+	logger.write(level, record)
+}
+
+func (logger *jsonLogger) write(level log.LevelType, record map[string]interface{}) {
+	record["timestamp"] = time.Now().UTC().Format(time.RFC3339Nano)
+	record["level"] = levelName(level)
+	content, err := json.Marshal(record)
+	if err != nil {
+		content, _ = json.Marshal(map[string]string{"timestamp": record["timestamp"].(string), "level": levelName(log.ERROR),
+			"message": "Failed to encode a log record: " + err.Error()})
+	}
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.logsWriter.Write(append(content, '\n'))
+}
+
+func levelName(level log.LevelType) string {
+	switch level {
+	case log.ERROR:
+		return "error"
+	case log.WARN:
+		return "warn"
+	case log.DEBUG:
+		return "debug"
+	default:
+		return "info"
+	}
+}
+
+// Logs a structured event with additional fields, such as the command name or the transferred file path.
+// Events are logged only when the JSON log format is used, since the text logs describe the same operations in their messages.
+func LogEvent(event string, fields map[string]interface{}) {
+	if logger, ok := log.Logger.(*jsonLogger); ok {
+		logger.event(log.INFO, event, fields)
+	}
+}
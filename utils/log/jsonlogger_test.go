@@ -0,0 +1,66 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonLogger(t *testing.T) {
+	previousLogger := log.Logger
+	defer log.SetLogger(previousLogger)
+	logs, output := new(bytes.Buffer), new(bytes.Buffer)
+	logger := NewJsonLogger(log.INFO, logs)
+	logger.SetOutputWriter(output)
+	log.SetLogger(logger)
+
+	log.Debug("Filtered by the log level")
+	log.Info("Uploading", 3, "files")
+	log.Warn("[Thread 2] Attempt 1 - Failure occurred while uploading - connection reset")
+	log.Error("Failed")
+	log.Output(`{"results": []}`)
+	LogEvent(CommandFinishedEvent, map[string]interface{}{"command": "rt_upload", "success": true})
+
+	assert.Equal(t, "{\"results\": []}\n", output.String())
+	records := readRecords(t, logs.String())
+	if !assert.Len(t, records, 4) {
+		return
+	}
+	for _, record := range records {
+		assert.NotEmpty(t, record["timestamp"])
+	}
+	assert.Equal(t, "info", records[0]["level"])
+	assert.Equal(t, "Uploading 3 files", records[0]["message"])
+	assert.Equal(t, "warn", records[1]["level"])
+	assert.Equal(t, RetryEvent, records[1]["event"])
+	assert.Equal(t, float64(1), records[1]["attempt"])
+	assert.Equal(t, "error", records[2]["level"])
+	assert.Nil(t, records[2]["event"])
+	assert.Equal(t, CommandFinishedEvent, records[3]["event"])
+	assert.Equal(t, "rt_upload", records[3]["command"])
+	assert.Equal(t, true, records[3]["success"])
+}
+
+func TestLogEventWithTextLogger(t *testing.T) {
+	previousLogger := log.Logger
+	defer log.SetLogger(previousLogger)
+	logs := new(bytes.Buffer)
+	log.SetLogger(log.NewLogger(log.DEBUG, logs))
+
+	LogEvent(CommandStartedEvent, map[string]interface{}{"command": "rt_upload"})
+	assert.Empty(t, logs.String())
+}
+
+func readRecords(t *testing.T, logs string) []map[string]interface{} {
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(logs), "\n") {
+		record := make(map[string]interface{})
+		assert.NoError(t, json.Unmarshal([]byte(line), &record), line)
+		records = append(records, record)
+	}
+	return records
+}
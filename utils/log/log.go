@@ -5,12 +5,20 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
+	"io"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// Log formats
+const (
+	TextFormat = "TEXT"
+	JsonFormat = "JSON"
+)
+
 func GetCliLogLevel() log.LevelType {
 	switch os.Getenv(cliutils.LogLevel) {
 	case "ERROR":
@@ -24,8 +32,28 @@ func GetCliLogLevel() log.LevelType {
 	}
 }
 
+func GetCliLogFormat() string {
+	if strings.ToUpper(os.Getenv(cliutils.LogFormat)) == JsonFormat {
+		return JsonFormat
+	}
+	return TextFormat
+}
+
+func IsJsonLogFormat() bool {
+	return GetCliLogFormat() == JsonFormat
+}
+
+// Creates a new logger, according to the log format set in the JFROG_CLI_LOG_FORMAT environment variable.
+// If logToWriter is nil, the logs are written to Stderr.
+func NewCliLogger(logLevel log.LevelType, logToWriter io.Writer) log.Log {
+	if IsJsonLogFormat() {
+		return NewJsonLogger(logLevel, logToWriter)
+	}
+	return log.NewLogger(logLevel, logToWriter)
+}
+
 func SetDefaultLogger() {
-	log.SetLogger(log.NewLogger(GetCliLogLevel(), nil))
+	log.SetLogger(NewCliLogger(GetCliLogLevel(), nil))
 }
 
 func CreateLogFile() (*os.File, error) {
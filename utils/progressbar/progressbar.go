@@ -220,7 +220,11 @@ func (p *progressBarManager) Quit() {
 // Initializes progress bar if possible (all conditions in 'shouldInitProgressBar' are met).
 // Creates a log file and sets the Logger to it. Caller responsible to close the file.
 // Returns nil, nil, err if failed.
+// When the JSON log format is used, the progress of the transfers is reported as log events instead.
 func InitProgressBarIfPossible() (ioUtils.Progress, *os.File, error) {
+	if logUtils.IsJsonLogFormat() {
+		return newTransferEvents(), nil, nil
+	}
 	shouldInit, err := shouldInitProgressBar()
 	if !shouldInit || err != nil {
 		return nil, nil, err
@@ -230,7 +234,7 @@ func InitProgressBarIfPossible() (ioUtils.Progress, *os.File, error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	log.SetLogger(log.NewLogger(logUtils.GetCliLogLevel(), logFile))
+	log.SetLogger(logUtils.NewCliLogger(logUtils.GetCliLogLevel(), logFile))
 
 	newProgressBar := &progressBarManager{}
 	newProgressBar.barsWg = new(sync.WaitGroup)
@@ -1,7 +1,15 @@
 package progressbar
 
 import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
 	"testing"
+
+	logUtils "github.com/jfrog/jfrog-cli/utils/log"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestBuildProgressDescription(t *testing.T) {
@@ -47,3 +55,34 @@ type testCase struct {
 	extraCharsLen int
 	expectedDesc  string
 }
+
+func TestTransferEvents(t *testing.T) {
+	previousLogger := log.Logger
+	defer log.SetLogger(previousLogger)
+	logs := new(bytes.Buffer)
+	log.SetLogger(logUtils.NewJsonLogger(log.INFO, logs))
+
+	progress := newTransferEvents()
+	id := progress.New(5, "Uploading", "a/b.zip")
+	content, err := ioutil.ReadAll(progress.ReadWithProgress(id, strings.NewReader("12345")))
+	assert.NoError(t, err)
+	assert.Equal(t, "12345", string(content))
+	progress.Abort(id)
+	progress.Quit()
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	if !assert.Len(t, lines, 2) {
+		return
+	}
+	started, finished := make(map[string]interface{}), make(map[string]interface{})
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &started))
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &finished))
+	assert.Equal(t, logUtils.TransferStartedEvent, started["event"])
+	assert.Equal(t, "Uploading", started["operation"])
+	assert.Equal(t, "a/b.zip", started["path"])
+	assert.Equal(t, float64(5), started["total_bytes"])
+	assert.Equal(t, logUtils.TransferFinishedEvent, finished["event"])
+	assert.Equal(t, float64(5), finished["transferred_bytes"])
+	assert.Equal(t, true, finished["completed"])
+	assert.Contains(t, finished, "duration_ms")
+}
@@ -0,0 +1,98 @@
+package progressbar
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	logUtils "github.com/jfrog/jfrog-cli/utils/log"
+)
+
+// Reports the files transfers as structured log events, instead of displaying progress bars.
+// Used when the JSON log format is set.
+type transferEvents struct {
+	transfers      []*transfer
+	transfersMutex sync.RWMutex
+}
+
+type transfer struct {
+	// Updated atomically while the file is transferred. Kept first in the struct for 64-bit alignment.
+	transferred int64
+	total       int64
+	operation   string
+	path        string
+	startTime   time.Time
+}
+
+func newTransferEvents() *transferEvents {
+	return &transferEvents{}
+}
+
+func (te *transferEvents) New(total int64, prefix, path string) (id int) {
+	return te.start(total, prefix, path)
+}
+
+func (te *transferEvents) NewReplacement(replaceId int, prefix, path string) (id int) {
+	return te.start(0, prefix, path)
+}
+
+func (te *transferEvents) start(total int64, prefix, path string) int {
+	newTransfer := &transfer{operation: strings.TrimSpace(prefix), path: path, total: total, startTime: time.Now()}
+	te.transfersMutex.Lock()
+	te.transfers = append(te.transfers, newTransfer)
+	id := len(te.transfers)
+	te.transfersMutex.Unlock()
+
+	fields := map[string]interface{}{"operation": newTransfer.operation, "path": path}
+	if total > 0 {
+		fields["total_bytes"] = total
+	}
+	logUtils.LogEvent(logUtils.TransferStartedEvent, fields)
+	return id
+}
+
+func (te *transferEvents) getTransfer(id int) *transfer {
+	te.transfersMutex.RLock()
+	defer te.transfersMutex.RUnlock()
+	return te.transfers[id-1]
+}
+
+func (te *transferEvents) ReadWithProgress(id int, reader io.Reader) io.Reader {
+	if reader == nil {
+		return nil
+	}
+	return &countingReader{Reader: reader, transfer: te.getTransfer(id)}
+}
+
+// Called when the transfer ends, whether it succeeded or not.
+func (te *transferEvents) Abort(id int) {
+	transfer := te.getTransfer(id)
+	transferred := atomic.LoadInt64(&transfer.transferred)
+	fields := map[string]interface{}{
+		"operation":   transfer.operation,
+		"path":        transfer.path,
+		"duration_ms": time.Since(transfer.startTime).Milliseconds(),
+	}
+	if transfer.total > 0 {
+		fields["total_bytes"] = transfer.total
+		fields["transferred_bytes"] = transferred
+		fields["completed"] = transferred >= transfer.total
+	}
+	logUtils.LogEvent(logUtils.TransferFinishedEvent, fields)
+}
+
+func (te *transferEvents) Quit() {}
+
+// Counts the bytes read from the wrapped reader.
+type countingReader struct {
+	io.Reader
+	transfer *transfer
+}
+
+func (cr *countingReader) Read(p []byte) (n int, err error) {
+	n, err = cr.Reader.Read(p)
+	atomic.AddInt64(&cr.transfer.transferred, int64(n))
+	return
+}
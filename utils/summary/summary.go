@@ -35,11 +35,27 @@ func (summary *Summary) Marshal() ([]byte, error) {
 }
 
 type Summary struct {
-	Status StatusType `json:"status"`
-	Totals *Totals    `json:"totals"`
+	Status   StatusType    `json:"status"`
+	Totals   *Totals       `json:"totals"`
+	Failures []FileFailure `json:"failures,omitempty"`
 }
 
 type Totals struct {
 	Success int `json:"success"`
 	Failure int `json:"failure"`
+	// The number of retried uploads.
+	Retries int `json:"retries,omitempty"`
+	// The number of bytes transferred by uploads and downloads.
+	Bytes int64 `json:"bytes,omitempty"`
+	// The command duration in milliseconds.
+	DurationMs int64 `json:"durationMs,omitempty"`
+}
+
+// Describes the failure of a single file.
+type FileFailure struct {
+	Path string `json:"path"`
+	// The HTTP status returned by Artifactory, if the failure was caused by an unexpected response.
+	StatusCode int    `json:"statusCode,omitempty"`
+	Error      string `json:"error"`
+	Attempts   int    `json:"attempts,omitempty"`
 }